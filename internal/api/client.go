@@ -5,35 +5,57 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 )
 
 const (
 	kamuiClientTypeHeader = "X-Kamui-Client-Type"
 	kamuiClientTypeCLI    = "cli"
+
+	// orgHeader selects the organization a request acts in
+	orgHeader = "X-Kamui-Org"
+
+	// DefaultTimeout is the HTTP timeout applied to regular API requests
+	DefaultTimeout = 30 * time.Second
+
+	// DefaultUploadTimeout is the HTTP timeout applied to ZIP uploads,
+	// which can take far longer than a JSON round trip
+	DefaultUploadTimeout = 10 * time.Minute
 )
 
+// TokenRefresher obtains a fresh access token after the API rejected the
+// current one
+type TokenRefresher func(ctx context.Context) (string, error)
+
 // Client is an HTTP client for the Kamui API
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
-	token      string
+	baseURL        string
+	httpClient     *http.Client
+	uploadTimeout  time.Duration
+	token          string
+	tokenRefresher TokenRefresher
+	org            string
 }
 
-// NewClient creates a new API client
+// NewClient creates a new API client. Trailing slashes on baseURL are
+// dropped since request paths start with "/".
 func NewClient(baseURL, token string) *Client {
 	return &Client{
-		baseURL: baseURL,
+		baseURL: strings.TrimRight(baseURL, "/"),
 		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout: DefaultTimeout,
 		},
-		token: token,
+		uploadTimeout: DefaultUploadTimeout,
+		token:         token,
 	}
 }
 
@@ -42,28 +64,127 @@ func (c *Client) SetToken(token string) {
 	c.token = token
 }
 
-// Request performs an HTTP request to the API
+// SetOrg makes every request act in the given organization. An empty org
+// leaves the choice to the API, which uses the account's default.
+func (c *Client) SetOrg(org string) {
+	c.org = org
+}
+
+// SetTokenRefresher installs a callback used to refresh the access token
+// when a request comes back 401. The request is then retried once.
+func (c *Client) SetTokenRefresher(fn TokenRefresher) {
+	c.tokenRefresher = fn
+}
+
+// withAuthRetry runs do and, if it fails with 401 and a token refresher is
+// installed, refreshes the token and runs do exactly once more
+func (c *Client) withAuthRetry(ctx context.Context, do func() error) error {
+	err := do()
+	var apiErr *APIError
+	if c.tokenRefresher == nil || !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+		return err
+	}
+
+	token, refreshErr := c.tokenRefresher(ctx)
+	if refreshErr != nil {
+		return fmt.Errorf("%w (token refresh failed: %v)", err, refreshErr)
+	}
+	c.token = token
+	return do()
+}
+
+// SetTransport replaces the transport used for all requests, including
+// uploads. Call it before EnableDebug, which wraps the current transport.
+func (c *Client) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// SetTimeout overrides the timeout for regular API requests.
+// A zero duration keeps the current value.
+func (c *Client) SetTimeout(d time.Duration) {
+	if d > 0 {
+		c.httpClient.Timeout = d
+	}
+}
+
+// SetUploadTimeout overrides the timeout for file uploads.
+// A zero duration keeps the current value.
+func (c *Client) SetUploadTimeout(d time.Duration) {
+	if d > 0 {
+		c.uploadTimeout = d
+	}
+}
+
+// Request performs an HTTP request to the API. POST requests carry an
+// Idempotency-Key header that is generated once here and sent unchanged on
+// every retry, so the API can tell a retry from a new request.
 func (c *Client) Request(ctx context.Context, method, path string, body interface{}, result interface{}) error {
+	var idempotencyKey string
+	if method == http.MethodPost {
+		idempotencyKey = newIdempotencyKey()
+	}
+	return c.withAuthRetry(ctx, func() error {
+		return c.doRequest(ctx, method, path, body, idempotencyKey, result)
+	})
+}
+
+// doRequest performs a single HTTP request attempt
+func (c *Client) doRequest(ctx context.Context, method, path string, body interface{}, idempotencyKey string, result interface{}) error {
+	var header http.Header
+	if idempotencyKey != "" {
+		header = http.Header{idempotencyKeyHeader: {idempotencyKey}}
+	}
+	_, respBody, err := c.send(ctx, method, path, body, header)
+	if err != nil {
+		return err
+	}
+
+	// Parse response if result is provided
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to parse response: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// send performs a single HTTP request attempt and returns the response
+// headers and body of a successful (non-4xx/5xx) response. header holds
+// extra request headers, such as Idempotency-Key or If-None-Match; a 304
+// answer to the latter returns ErrNotModified.
+func (c *Client) send(ctx context.Context, method, path string, body interface{}, header http.Header) (http.Header, []byte, error) {
 	url := c.baseURL + path
 
 	var bodyReader io.Reader
 	if body != nil {
 		jsonBody, err := json.Marshal(body)
 		if err != nil {
-			return fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, nil, fmt.Errorf("failed to marshal request body: %w", err)
 		}
 		bodyReader = bytes.NewReader(jsonBody)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	req.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
+	req.Header.Set("User-Agent", UserAgent())
+	req.Header.Set(cliVersionHeader, version)
+	if c.org != "" {
+		req.Header.Set(orgHeader, c.org)
+	}
+	for key, values := range header {
+		for _, v := range values {
+			req.Header.Add(key, v)
+		}
+	}
 
 	if c.token != "" {
 		req.Header.Set("Authorization", "Bearer "+c.token)
@@ -71,39 +192,25 @@ func (c *Client) Request(ctx context.Context, method, path string, body interfac
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("request failed: %w", err)
+		return nil, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Read response body
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := readBody(resp)
 	if err != nil {
-		return fmt.Errorf("failed to read response: %w", err)
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Check for error status codes
 	if resp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Message != "" {
-			return &APIError{
-				StatusCode: resp.StatusCode,
-				Message:    errResp.Message,
-			}
-		}
-		return &APIError{
-			StatusCode: resp.StatusCode,
-			Message:    fmt.Sprintf("request failed with status %d", resp.StatusCode),
-		}
+		return nil, nil, newAPIError(resp, respBody)
 	}
-
-	// Parse response if result is provided
-	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
-			return fmt.Errorf("failed to parse response: %w", err)
-		}
+	if resp.StatusCode == http.StatusNotModified {
+		return resp.Header, nil, ErrNotModified
 	}
 
-	return nil
+	return resp.Header, respBody, nil
 }
 
 // Get performs a GET request
@@ -121,24 +228,110 @@ func (c *Client) Put(ctx context.Context, path string, body interface{}, result
 	return c.Request(ctx, http.MethodPut, path, body, result)
 }
 
+// Patch performs a PATCH request
+func (c *Client) Patch(ctx context.Context, path string, body interface{}, result interface{}) error {
+	return c.Request(ctx, http.MethodPatch, path, body, result)
+}
+
 // Delete performs a DELETE request
 func (c *Client) Delete(ctx context.Context, path string, result interface{}) error {
 	return c.Request(ctx, http.MethodDelete, path, nil, result)
 }
 
+// requestIDHeader carries the platform's per-request identifier
+const requestIDHeader = "X-Request-Id"
+
 // ErrorResponse represents an error response from the API
 type ErrorResponse struct {
+	Message   string      `json:"message"`
+	Code      string      `json:"code,omitempty"`
+	RequestID string      `json:"request_id,omitempty"`
+	Errors    FieldErrors `json:"errors,omitempty"`
+}
+
+// ErrorCodeClientOutdated is the error code the API returns when it no
+// longer supports the CLI version sent in X-Kamui-CLI-Version
+const ErrorCodeClientOutdated = "client_outdated"
+
+// FieldError is a validation error for a single request field
+type FieldError struct {
+	Field   string `json:"field"`
 	Message string `json:"message"`
 }
 
+// FieldErrors is a list of field validation errors. It accepts both a
+// list of {"field","message"} objects and a {"field": "message"} map.
+type FieldErrors []FieldError
+
+// UnmarshalJSON implements json.Unmarshaler
+func (f *FieldErrors) UnmarshalJSON(data []byte) error {
+	var list []FieldError
+	if err := json.Unmarshal(data, &list); err == nil {
+		*f = list
+		return nil
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	list = make([]FieldError, 0, len(m))
+	for field, msg := range m {
+		list = append(list, FieldError{Field: field, Message: msg})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Field < list[j].Field })
+	*f = list
+	return nil
+}
+
 // APIError represents an error returned by the API
 type APIError struct {
-	StatusCode int
-	Message    string
+	StatusCode  int
+	Message     string
+	Code        string
+	RequestID   string
+	FieldErrors []FieldError
 }
 
 func (e *APIError) Error() string {
-	return fmt.Sprintf("API error (status %d): %s", e.StatusCode, e.Message)
+	var b strings.Builder
+	if e.IsClientOutdated() {
+		fmt.Fprintf(&b, "your kamui CLI (%s) is out of date; please upgrade to the latest release\n  server: %s", version, e.Message)
+	} else {
+		fmt.Fprintf(&b, "API error (status %d): %s", e.StatusCode, e.Message)
+	}
+	for _, fe := range e.FieldErrors {
+		fmt.Fprintf(&b, "\n  - %s: %s", fe.Field, fe.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, "\n  request id: %s", e.RequestID)
+	}
+	return b.String()
+}
+
+// newAPIError builds an APIError from an error response. The body's
+// message, request ID, and field errors are used when present; the
+// X-Request-Id header takes precedence over a request ID in the body.
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Message:    fmt.Sprintf("request failed with status %d", resp.StatusCode),
+	}
+
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		if errResp.Message != "" {
+			apiErr.Message = errResp.Message
+		}
+		apiErr.Code = errResp.Code
+		apiErr.RequestID = errResp.RequestID
+		apiErr.FieldErrors = errResp.Errors
+	}
+	if id := resp.Header.Get(requestIDHeader); id != "" {
+		apiErr.RequestID = id
+	}
+
+	return apiErr
 }
 
 // IsUnauthorized checks if the error is an unauthorized error
@@ -151,6 +344,12 @@ func (e *APIError) IsNotFound() bool {
 	return e.StatusCode == http.StatusNotFound
 }
 
+// IsClientOutdated checks if the API rejected the request because the CLI
+// is too old, by error code or 426 Upgrade Required
+func (e *APIError) IsClientOutdated() bool {
+	return e.Code == ErrorCodeClientOutdated || e.StatusCode == http.StatusUpgradeRequired
+}
+
 // Installation represents a GitHub App installation with repositories
 type Installation struct {
 	ID        int64  `json:"id"`
@@ -182,10 +381,17 @@ type CreateAppRequest struct {
 	AppDisplayName      string            `json:"app_display_name,omitempty"`
 	Replicas            int               `json:"replicas"`
 	EnvVars             map[string]string `json:"env_vars"`
+	SecretKeys          []string          `json:"secret_keys,omitempty"`
 	PreCommand          string            `json:"pre_command"`
 	StartCommand        string            `json:"start_command"`
 	SetupCommand        string            `json:"setup_command"`
 	HealthCheckEndpoint string            `json:"health_check_endpoint,omitempty"`
+	HealthCheckMethod   string            `json:"health_check_method,omitempty"`
+	HealthCheckPort     int               `json:"health_check_port,omitempty"`
+	HealthCheckStatus   int               `json:"health_check_expected_status,omitempty"`
+	HealthCheckInterval int               `json:"health_check_interval_seconds,omitempty"`
+	HealthCheckTimeout  int               `json:"health_check_timeout_seconds,omitempty"`
+	HealthCheckDelay    int               `json:"health_check_initial_delay_seconds,omitempty"`
 	DeployType          string            `json:"deploy_type"`
 	AppType             string            `json:"app_type"`
 	LanguageType        string            `json:"language_type"`
@@ -194,8 +400,15 @@ type CreateAppRequest struct {
 	RepositoryName      string            `json:"repository_name,omitempty"`
 	RepositoryBranch    string            `json:"repository_branch,omitempty"`
 	Directory           string            `json:"directory,omitempty"`
+	ImageName           string            `json:"image_name,omitempty"`
+	ImageTag            string            `json:"image_tag,omitempty"`
+	ImageDigest         string            `json:"image_digest,omitempty"`
+	RegistryURL         string            `json:"registry_url,omitempty"`
+	RegistryUsername    string            `json:"registry_username,omitempty"`
+	RegistryPassword    string            `json:"registry_password,omitempty"`
 	DatabaseID          string            `json:"database_id,omitempty"`
 	AppSpecType         string            `json:"app_spec_type,omitempty"`
+	SessionAffinity     bool              `json:"session_affinity,omitempty"`
 	Status              *ProjectStatus    `json:"status"`
 }
 
@@ -222,6 +435,21 @@ func (c *Client) GetInstallations(ctx context.Context) ([]Installation, error) {
 	return resp.Installations, nil
 }
 
+// InstallURLResponse represents the response from GET /api/installations/new
+type InstallURLResponse struct {
+	URL string `json:"url"`
+}
+
+// GetGithubInstallURL fetches the URL that installs the Kamui GitHub App on
+// the user's repositories
+func (c *Client) GetGithubInstallURL(ctx context.Context) (string, error) {
+	var resp InstallURLResponse
+	if err := c.Get(ctx, "/api/installations/new", &resp); err != nil {
+		return "", err
+	}
+	return resp.URL, nil
+}
+
 // GetBranches fetches branches for a repository
 func (c *Client) GetBranches(ctx context.Context, owner, repo string) ([]Branch, error) {
 	path := fmt.Sprintf("/api/repositories/%s/%s/branches", owner, repo)
@@ -249,6 +477,13 @@ type CreateProjectRequest struct {
 	Region      string `json:"region"`
 }
 
+// UpdateProjectRequest represents the request body for updating a project.
+// Nil fields are omitted so only explicitly changed values are sent.
+type UpdateProjectRequest struct {
+	Name        *string `json:"name,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
 // BasicSuccessResponse represents a simple success response from the API
 type BasicSuccessResponse struct {
 	Message string `json:"message"`
@@ -275,17 +510,40 @@ func (c *Client) DeleteApp(ctx context.Context, appID string) error {
 	return c.Delete(ctx, path, nil)
 }
 
+// RedeployAppRequest represents the request body for POST /api/apps/{id}/redeploy
+type RedeployAppRequest struct {
+	Branch string `json:"branch,omitempty"`
+}
+
+// RedeployAppResponse represents the response from POST /api/apps/{id}/redeploy
+type RedeployAppResponse struct {
+	Message      string `json:"message"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+// RedeployApp triggers a fresh deployment of an app with its current configuration
+func (c *Client) RedeployApp(ctx context.Context, appID string, req *RedeployAppRequest) (*RedeployAppResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s/redeploy", appID)
+	var resp RedeployAppResponse
+	if err := c.Post(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // AppDetailResponse represents the response from GET /api/apps/{id}
 type AppDetailResponse struct {
-	DisplayName   string         `json:"display_name"`
-	PodStatus     *ProjectStatus `json:"pod_status"`
-	LanguageType  string         `json:"language_type"`
-	AppSpec       string         `json:"app_spec"`
-	AppType       string         `json:"app_type"`
-	GithubOrgRepo string         `json:"github_org_repo,omitempty"`
-	GithubBranch  string         `json:"github_branch,omitempty"`
-	URL           string         `json:"url"`
-	CustomDomain  string         `json:"custom_domain,omitempty"`
+	DisplayName     string         `json:"display_name"`
+	PodStatus       *ProjectStatus `json:"pod_status"`
+	LanguageType    string         `json:"language_type"`
+	AppSpec         string         `json:"app_spec"`
+	Replicas        int            `json:"replicas,omitempty"`
+	AppType         string         `json:"app_type"`
+	GithubOrgRepo   string         `json:"github_org_repo,omitempty"`
+	GithubBranch    string         `json:"github_branch,omitempty"`
+	URL             string         `json:"url"`
+	CustomDomain    string         `json:"custom_domain,omitempty"`
+	SessionAffinity bool           `json:"session_affinity"`
 }
 
 // GetApp fetches app details by ID
@@ -298,6 +556,173 @@ func (c *Client) GetApp(ctx context.Context, appID string) (*AppDetailResponse,
 	return &resp, nil
 }
 
+// DeployStatusResponse represents the response from GET /api/apps/{id}/status
+type DeployStatusResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// AppEnvResponse represents the response from GET /api/apps/{id}/env
+type AppEnvResponse struct {
+	EnvVars    map[string]string `json:"env_vars"`
+	SecretKeys []string          `json:"secret_keys,omitempty"`
+}
+
+// GetAppEnv fetches the environment variables of an app
+func (c *Client) GetAppEnv(ctx context.Context, appID string) (*AppEnvResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s/env", appID)
+	var resp AppEnvResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetDeployStatus fetches the deployment status of an app
+func (c *Client) GetDeployStatus(ctx context.Context, appID string) (*DeployStatusResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s/status", appID)
+	var resp DeployStatusResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Deployment represents one deployment of an app
+type Deployment struct {
+	ID            string    `json:"id"`
+	Status        string    `json:"status"`
+	CommitSHA     string    `json:"commit_sha,omitempty"`
+	CommitMessage string    `json:"commit_message,omitempty"`
+	Branch        string    `json:"branch,omitempty"`
+	Current       bool      `json:"current"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// DeploymentListResponse represents the response from GET /api/apps/{id}/deployments
+type DeploymentListResponse struct {
+	Deployments []Deployment `json:"deployments"`
+}
+
+// ListDeployments fetches the recent deployments of an app, newest first
+func (c *Client) ListDeployments(ctx context.Context, appID string) ([]Deployment, error) {
+	path := fmt.Sprintf("/api/apps/%s/deployments", appID)
+	var resp DeploymentListResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Deployments, nil
+}
+
+// RollbackAppRequest represents the request body for POST /api/apps/{id}/rollback
+type RollbackAppRequest struct {
+	DeploymentID string `json:"deployment_id"`
+}
+
+// RollbackAppResponse represents the response from POST /api/apps/{id}/rollback
+type RollbackAppResponse struct {
+	Message      string `json:"message"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+// RollbackApp redeploys an app from one of its previous deployments
+func (c *Client) RollbackApp(ctx context.Context, appID string, req *RollbackAppRequest) (*RollbackAppResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s/rollback", appID)
+	var resp RollbackAppResponse
+	if err := c.Post(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// DNSRecord is a DNS record that must exist for a custom domain to resolve
+type DNSRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AppDomainResponse represents the response from /api/apps/{id}/domain
+type AppDomainResponse struct {
+	Domain     string      `json:"domain"`
+	Status     string      `json:"status,omitempty"`
+	DNSRecords []DNSRecord `json:"dns_records,omitempty"`
+}
+
+// SetAppDomainRequest represents the request body for PUT /api/apps/{id}/domain
+type SetAppDomainRequest struct {
+	Domain string `json:"domain"`
+}
+
+// GetAppDomain fetches the custom domain of an app
+func (c *Client) GetAppDomain(ctx context.Context, appID string) (*AppDomainResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s/domain", appID)
+	var resp AppDomainResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// SetAppDomain sets the custom domain of an app
+func (c *Client) SetAppDomain(ctx context.Context, appID string, req *SetAppDomainRequest) (*AppDomainResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s/domain", appID)
+	var resp AppDomainResponse
+	if err := c.Put(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// RemoveAppDomain removes the custom domain of an app
+func (c *Client) RemoveAppDomain(ctx context.Context, appID string) error {
+	path := fmt.Sprintf("/api/apps/%s/domain", appID)
+	return c.Delete(ctx, path, nil)
+}
+
+// TransferAppRequest represents the request body for POST /api/apps/{id}/transfer
+type TransferAppRequest struct {
+	ProjectID string `json:"project_id"`
+}
+
+// TransferAppResponse represents the response from POST /api/apps/{id}/transfer
+type TransferAppResponse struct {
+	Message   string `json:"message"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// TransferApp moves an app to another project
+func (c *Client) TransferApp(ctx context.Context, appID string, req *TransferAppRequest) (*TransferAppResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s/transfer", appID)
+	var resp TransferAppResponse
+	if err := c.Post(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// UpdateAppRequest represents the request body for PATCH /api/apps/{id}.
+// Every field is a pointer and nil fields are left out, so the API keeps
+// their current values; a zero value such as replicas 0 is never sent by
+// accident.
+type UpdateAppRequest struct {
+	DisplayName *string            `json:"display_name,omitempty"`
+	Replicas    *int               `json:"replicas,omitempty"`
+	AppSpecType *string            `json:"app_spec_type,omitempty"`
+	EnvVars     *map[string]string `json:"env_vars,omitempty"`
+	SecretKeys  *[]string          `json:"secret_keys,omitempty"`
+}
+
+// UpdateApp changes the settings of an app set in req
+func (c *Client) UpdateApp(ctx context.Context, appID string, req *UpdateAppRequest) (*AppDetailResponse, error) {
+	path := fmt.Sprintf("/api/apps/%s", appID)
+	var resp AppDetailResponse
+	if err := c.Patch(ctx, path, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
 // CreateStaticAppRequest represents the request body for creating a static app via GitHub
 type CreateStaticAppRequest struct {
 	AppName          string `json:"app_name"`
@@ -321,107 +746,247 @@ func (c *Client) CreateStaticApp(ctx context.Context, req *CreateStaticAppReques
 	return &resp, nil
 }
 
+// ProgressFunc is called as an upload proceeds with the number of bytes
+// sent so far and the total request body size.
+type ProgressFunc func(sent, total int64)
+
 // CreateStaticAppUploadRequest represents the parameters for creating a static app via file upload
 type CreateStaticAppUploadRequest struct {
 	ProjectID   string
 	AppName     string
 	Replicas    int
 	AppSpecType string
-	FilePath    string // local path to the ZIP file
+	FilePath    string       // local path to the ZIP file
+	Progress    ProgressFunc // optional; called as the body is sent
+}
+
+// progressReader reports the number of bytes read through it
+type progressReader struct {
+	r     io.Reader
+	sent  int64
+	total int64
+	fn    ProgressFunc
 }
 
-// CreateStaticAppUpload creates a new static app by uploading a ZIP file
+func (p *progressReader) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if n > 0 {
+		p.sent += int64(n)
+		p.fn(p.sent, p.total)
+	}
+	return n, err
+}
+
+// CreateStaticAppUpload creates a new static app by uploading a ZIP file.
+// Like Post, it sends one Idempotency-Key for all attempts.
 func (c *Client) CreateStaticAppUpload(ctx context.Context, req *CreateStaticAppUploadRequest) (*AppCreateResponse, error) {
-	// Open the file
-	file, err := os.Open(req.FilePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+	idempotencyKey := newIdempotencyKey()
+	var resp *AppCreateResponse
+	err := c.withAuthRetry(ctx, func() error {
+		var err error
+		resp, err = c.createStaticAppUpload(ctx, req, idempotencyKey)
+		return err
+	})
+	return resp, err
+}
+
+// createStaticAppUpload performs a single upload attempt
+func (c *Client) createStaticAppUpload(ctx context.Context, req *CreateStaticAppUploadRequest, idempotencyKey string) (*AppCreateResponse, error) {
+	fields := []formField{
+		{"project_id", req.ProjectID},
+		{"app_name", req.AppName},
+		{"replicas", fmt.Sprintf("%d", req.Replicas)},
+		{"app_spec_type", req.AppSpecType},
 	}
-	defer file.Close()
+	var resp AppCreateResponse
+	if err := c.upload(ctx, "/api/static-apps/upload", fields, req.FilePath, req.Progress, idempotencyKey, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
 
-	// Create a buffer and multipart writer
-	body := &bytes.Buffer{}
-	writer := multipart.NewWriter(body)
+// UpdateStaticAppUploadRequest represents the parameters for uploading a
+// new build of an existing static app
+type UpdateStaticAppUploadRequest struct {
+	Replicas    int          // optional; 0 keeps the current replicas
+	AppSpecType string       // optional; empty keeps the current spec
+	FilePath    string       // local path to the ZIP file
+	Progress    ProgressFunc // optional; called as the body is sent
+}
 
-	// Add form fields
-	if err := writer.WriteField("project_id", req.ProjectID); err != nil {
-		return nil, fmt.Errorf("failed to write project_id field: %w", err)
+// UpdateStaticAppUploadResponse represents the response from
+// POST /api/static-apps/{id}/upload
+type UpdateStaticAppUploadResponse struct {
+	Message      string `json:"message"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+// UpdateStaticAppUpload uploads a new ZIP build of an existing static app.
+// Like Post, it sends one Idempotency-Key for all attempts.
+func (c *Client) UpdateStaticAppUpload(ctx context.Context, appID string, req *UpdateStaticAppUploadRequest) (*UpdateStaticAppUploadResponse, error) {
+	var fields []formField
+	if req.Replicas > 0 {
+		fields = append(fields, formField{"replicas", fmt.Sprintf("%d", req.Replicas)})
 	}
-	if err := writer.WriteField("app_name", req.AppName); err != nil {
-		return nil, fmt.Errorf("failed to write app_name field: %w", err)
+	if req.AppSpecType != "" {
+		fields = append(fields, formField{"app_spec_type", req.AppSpecType})
 	}
-	if err := writer.WriteField("replicas", fmt.Sprintf("%d", req.Replicas)); err != nil {
-		return nil, fmt.Errorf("failed to write replicas field: %w", err)
+
+	path := fmt.Sprintf("/api/static-apps/%s/upload", appID)
+	idempotencyKey := newIdempotencyKey()
+	var resp UpdateStaticAppUploadResponse
+	err := c.withAuthRetry(ctx, func() error {
+		return c.upload(ctx, path, fields, req.FilePath, req.Progress, idempotencyKey, &resp)
+	})
+	if err != nil {
+		return nil, err
 	}
-	if err := writer.WriteField("app_spec_type", req.AppSpecType); err != nil {
-		return nil, fmt.Errorf("failed to write app_spec_type field: %w", err)
+	return &resp, nil
+}
+
+// formField is a multipart form field sent before the uploaded file
+type formField struct {
+	name  string
+	value string
+}
+
+// upload POSTs fields and the file at filePath as a multipart form to path
+// and decodes the JSON response into result. The body is rebuilt from the
+// file on each call so a retry re-sends the whole file.
+func (c *Client) upload(ctx context.Context, path string, fields []formField, filePath string, progress ProgressFunc, idempotencyKey string, result interface{}) error {
+	// Open the file
+	file, err := os.Open(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
 	}
+	defer file.Close()
 
-	// Add the file
-	part, err := writer.CreateFormFile("file", filepath.Base(req.FilePath))
+	stat, err := file.Stat()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create form file: %w", err)
+		return fmt.Errorf("failed to stat file: %w", err)
 	}
-	if _, err := io.Copy(part, file); err != nil {
-		return nil, fmt.Errorf("failed to copy file content: %w", err)
+
+	// Build the multipart envelope in memory and stream the file between
+	// its header and trailer, so the ZIP is never buffered as a whole and
+	// the exact body size is known up front.
+	envelope := &bytes.Buffer{}
+	writer := multipart.NewWriter(envelope)
+
+	// Add form fields
+	for _, f := range fields {
+		if err := writer.WriteField(f.name, f.value); err != nil {
+			return fmt.Errorf("failed to write %s field: %w", f.name, err)
+		}
+	}
+
+	// Add the file part header
+	if _, err := writer.CreateFormFile("file", filepath.Base(filePath)); err != nil {
+		return fmt.Errorf("failed to create form file: %w", err)
 	}
+	header := append([]byte(nil), envelope.Bytes()...)
+	envelope.Reset()
 
-	// Close the writer to finalize the multipart form
+	// Close the writer to produce the closing boundary
 	if err := writer.Close(); err != nil {
-		return nil, fmt.Errorf("failed to close multipart writer: %w", err)
+		return fmt.Errorf("failed to close multipart writer: %w", err)
+	}
+	trailer := envelope.Bytes()
+
+	total := int64(len(header)) + stat.Size() + int64(len(trailer))
+	var body io.Reader = io.MultiReader(bytes.NewReader(header), file, bytes.NewReader(trailer))
+	if progress != nil {
+		body = &progressReader{r: body, total: total, fn: progress}
 	}
 
 	// Create the request
-	url := c.baseURL + "/api/static-apps/upload"
+	url := c.baseURL + path
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
+	httpReq.ContentLength = total
 
 	// Set headers
 	httpReq.Header.Set("Content-Type", writer.FormDataContentType())
+	httpReq.Header.Set("Accept-Encoding", acceptEncoding)
 	httpReq.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
+	httpReq.Header.Set("User-Agent", UserAgent())
+	httpReq.Header.Set(cliVersionHeader, version)
+	if c.org != "" {
+		httpReq.Header.Set(orgHeader, c.org)
+	}
+	httpReq.Header.Set(idempotencyKeyHeader, idempotencyKey)
 	if c.token != "" {
 		httpReq.Header.Set("Authorization", "Bearer "+c.token)
 	}
 
-	// Send the request
-	httpResp, err := c.httpClient.Do(httpReq)
+	// Send the request with the (longer) upload timeout
+	uploadClient := *c.httpClient
+	uploadClient.Timeout = c.uploadTimeout
+	httpResp, err := uploadClient.Do(httpReq)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return fmt.Errorf("request failed: %w", err)
 	}
 	defer httpResp.Body.Close()
 
 	// Read response body
-	respBody, err := io.ReadAll(httpResp.Body)
+	respBody, err := readBody(httpResp)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return fmt.Errorf("failed to read response: %w", err)
 	}
 
 	// Check for error status codes
 	if httpResp.StatusCode >= 400 {
-		var errResp ErrorResponse
-		if err := json.Unmarshal(respBody, &errResp); err == nil && errResp.Message != "" {
-			return nil, &APIError{
-				StatusCode: httpResp.StatusCode,
-				Message:    errResp.Message,
-			}
-		}
-		return nil, &APIError{
-			StatusCode: httpResp.StatusCode,
-			Message:    fmt.Sprintf("request failed with status %d", httpResp.StatusCode),
-		}
+		return newAPIError(httpResp, respBody)
 	}
 
 	// Parse response
-	var resp AppCreateResponse
-	if err := json.Unmarshal(respBody, &resp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
 	}
+	return nil
+}
+
+// MeResponse represents the response from GET /api/me
+type MeResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
 
+// GetMe returns the user the current token belongs to
+func (c *Client) GetMe(ctx context.Context) (*MeResponse, error) {
+	var resp MeResponse
+	if err := c.Get(ctx, "/api/me", &resp); err != nil {
+		return nil, err
+	}
 	return &resp, nil
 }
 
+// ── Organizations ────────────────────────────────────────────────────────────
+
+// Org is an organization the current user belongs to
+type Org struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Role    string `json:"role,omitempty"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// OrgListResponse represents the response from GET /api/orgs
+type OrgListResponse struct {
+	Orgs []Org `json:"orgs"`
+}
+
+// ListOrgs returns the organizations the current user belongs to
+func (c *Client) ListOrgs(ctx context.Context) ([]Org, error) {
+	var resp OrgListResponse
+	if err := c.Get(ctx, "/api/orgs", &resp); err != nil {
+		return nil, err
+	}
+	return resp.Orgs, nil
+}
+
 // ── Personal Access Token ────────────────────────────────────────────────────
 
 // PATInfo is a token's metadata (no plaintext value).
@@ -478,3 +1043,27 @@ func (c *Client) ListPATs(ctx context.Context, includeOAuth bool) ([]PATInfo, er
 func (c *Client) DeletePAT(ctx context.Context, id string) error {
 	return c.Delete(ctx, "/api/tokens/"+id, nil)
 }
+
+// ── Database ─────────────────────────────────────────────────────────────────
+
+// DatabaseConnectionResponse represents the response from
+// GET /api/databases/{id}/connection
+type DatabaseConnectionResponse struct {
+	Engine   string `json:"engine,omitempty"`
+	Host     string `json:"host"`
+	Port     int    `json:"port"`
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Database string `json:"database"`
+}
+
+// GetDatabaseConnection fetches the connection details of a database,
+// including its password
+func (c *Client) GetDatabaseConnection(ctx context.Context, dbID string) (*DatabaseConnectionResponse, error) {
+	path := fmt.Sprintf("/api/databases/%s/connection", dbID)
+	var resp DatabaseConnectionResponse
+	if err := c.Get(ctx, path, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
@@ -0,0 +1,221 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestClient_ErrorResponses(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		header    string
+		body      string
+		wantError string
+	}{
+		{
+			name:      "message only",
+			status:    http.StatusNotFound,
+			body:      `{"message":"project not found"}`,
+			wantError: "API error (status 404): project not found",
+		},
+		{
+			name:      "no body",
+			status:    http.StatusBadGateway,
+			wantError: "API error (status 502): request failed with status 502",
+		},
+		{
+			name:   "field error list with request id in body",
+			status: http.StatusBadRequest,
+			body:   `{"message":"validation failed","request_id":"req-body","errors":[{"field":"name","message":"must not be empty"},{"field":"region","message":"unsupported region"}]}`,
+			wantError: "API error (status 400): validation failed\n" +
+				"  - name: must not be empty\n" +
+				"  - region: unsupported region\n" +
+				"  request id: req-body",
+		},
+		{
+			name:   "field error map with request id header",
+			status: http.StatusUnprocessableEntity,
+			header: "req-header",
+			body:   `{"message":"validation failed","request_id":"req-body","errors":{"replicas":"must be at least 1","app_name":"already taken"}}`,
+			wantError: "API error (status 422): validation failed\n" +
+				"  - app_name: already taken\n" +
+				"  - replicas: must be at least 1\n" +
+				"  request id: req-header",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.header != "" {
+					w.Header().Set(requestIDHeader, tt.header)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			err := NewClient(srv.URL, "").Get(context.Background(), "/api/projects", nil)
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.status {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.status)
+			}
+			if got := apiErr.Error(); got != tt.wantError {
+				t.Errorf("Error() =\n%s\nwant\n%s", got, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestClient_RetriesOnceAfterTokenRefresh(t *testing.T) {
+	tests := []struct {
+		name         string
+		validToken   string
+		refreshErr   error
+		wantRequests int
+		wantRefresh  int
+		wantErr      bool
+	}{
+		{name: "refreshed token accepted", validToken: "fresh", wantRequests: 2, wantRefresh: 1},
+		{name: "still unauthorized after refresh", validToken: "never", wantRequests: 2, wantRefresh: 1, wantErr: true},
+		{name: "refresh fails", validToken: "fresh", refreshErr: errors.New("no refresh token"), wantRequests: 1, wantRefresh: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get("Authorization") != "Bearer "+tt.validToken {
+					w.WriteHeader(http.StatusUnauthorized)
+					w.Write([]byte(`{"message":"token expired"}`))
+					return
+				}
+				w.Write([]byte(`{"ok":"yes"}`))
+			}))
+			defer srv.Close()
+
+			refreshes := 0
+			client := NewClient(srv.URL, "stale")
+			client.SetTokenRefresher(func(ctx context.Context) (string, error) {
+				refreshes++
+				return "fresh", tt.refreshErr
+			})
+
+			var result map[string]string
+			err := client.Get(context.Background(), "/api/projects", &result)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				var apiErr *APIError
+				if !errors.As(err, &apiErr) || !apiErr.IsUnauthorized() {
+					t.Errorf("error = %v, want wrapped 401 APIError", err)
+				}
+			} else if result["ok"] != "yes" {
+				t.Errorf("result = %v", result)
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+			if refreshes != tt.wantRefresh {
+				t.Errorf("refreshes = %d, want %d", refreshes, tt.wantRefresh)
+			}
+		})
+	}
+}
+
+func TestClient_PostReusesIdempotencyKeyOnRetry(t *testing.T) {
+	var keys []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keys = append(keys, r.Header.Get(idempotencyKeyHeader))
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"token expired"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "stale")
+	client.SetTokenRefresher(func(ctx context.Context) (string, error) {
+		return "fresh", nil
+	})
+
+	if err := client.Post(context.Background(), "/api/projects", map[string]string{"name": "p"}, nil); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("requests = %d, want 2", len(keys))
+	}
+	if keys[0] == "" || keys[0] != keys[1] {
+		t.Errorf("Idempotency-Key = %q then %q, want the same non-empty key", keys[0], keys[1])
+	}
+
+	// A new logical request gets a new key, and GETs carry none
+	if err := client.Post(context.Background(), "/api/projects", map[string]string{"name": "p"}, nil); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if err := client.Get(context.Background(), "/api/projects", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if keys[2] == keys[0] {
+		t.Errorf("second Post reused key %q", keys[2])
+	}
+	if keys[3] != "" {
+		t.Errorf("Get sent Idempotency-Key %q", keys[3])
+	}
+}
+
+func TestClient_UpdateStaticAppUpload(t *testing.T) {
+	var gotPath, gotFile string
+	var gotFields map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Errorf("ParseMultipartForm() error = %v", err)
+			return
+		}
+		gotFields = r.MultipartForm.Value
+		if f, _, err := r.FormFile("file"); err == nil {
+			data, _ := io.ReadAll(f)
+			gotFile = string(data)
+		}
+		w.Write([]byte(`{"message":"ok","deployment_id":"dep-2"}`))
+	}))
+	defer srv.Close()
+
+	zipPath := filepath.Join(t.TempDir(), "site.zip")
+	if err := os.WriteFile(zipPath, []byte("new build"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := NewClient(srv.URL, "token").UpdateStaticAppUpload(context.Background(), "app-1", &UpdateStaticAppUploadRequest{FilePath: zipPath})
+	if err != nil {
+		t.Fatalf("UpdateStaticAppUpload() error = %v", err)
+	}
+	if resp.DeploymentID != "dep-2" {
+		t.Errorf("DeploymentID = %q, want dep-2", resp.DeploymentID)
+	}
+	if gotPath != "/api/static-apps/app-1/upload" {
+		t.Errorf("path = %q, want /api/static-apps/app-1/upload", gotPath)
+	}
+	if len(gotFields) != 0 {
+		t.Errorf("form fields = %v, want none when replicas and spec are unchanged", gotFields)
+	}
+	if gotFile != "new build" {
+		t.Errorf("uploaded file = %q, want %q", gotFile, "new build")
+	}
+}
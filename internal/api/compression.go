@@ -0,0 +1,60 @@
+package api
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// acceptEncoding is sent on every request. Setting it ourselves turns off
+// net/http's transparent gzip handling, so readBody decodes responses
+// explicitly; that also covers deflate, which net/http never decodes.
+const acceptEncoding = "gzip, deflate"
+
+// readBody reads a response body, undoing its Content-Encoding
+func readBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return decodeBody(resp.Header.Get("Content-Encoding"), body)
+}
+
+// decodeBody decompresses body according to a Content-Encoding value.
+// Unknown and identity encodings are returned unchanged.
+func decodeBody(encoding string, body []byte) ([]byte, error) {
+	if len(body) == 0 {
+		return body, nil
+	}
+
+	var r io.ReadCloser
+	var err error
+	switch strings.ToLower(strings.TrimSpace(encoding)) {
+	case "gzip", "x-gzip":
+		r, err = gzip.NewReader(bytes.NewReader(body))
+	case "deflate":
+		// "deflate" is meant to be zlib-wrapped, but some servers send a
+		// raw deflate stream
+		r, err = zlib.NewReader(bytes.NewReader(body))
+		if err != nil {
+			r, err = flate.NewReader(bytes.NewReader(body)), nil
+		}
+	default:
+		return body, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", encoding, err)
+	}
+	defer r.Close()
+
+	decoded, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s response: %w", encoding, err)
+	}
+	return decoded, nil
+}
@@ -0,0 +1,123 @@
+package api
+
+import (
+	"bytes"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func compress(t *testing.T, encoding, s string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.WriteCloser
+	switch encoding {
+	case "gzip":
+		w = gzip.NewWriter(&buf)
+	case "deflate":
+		w = zlib.NewWriter(&buf)
+	case "raw-deflate":
+		w, _ = flate.NewWriter(&buf, flate.DefaultCompression)
+	default:
+		return []byte(s)
+	}
+	if _, err := io.WriteString(w, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestClient_DecodesCompressedResponses(t *testing.T) {
+	const body = `{"id":"proj-1","name":"my-project"}`
+
+	tests := []struct {
+		name     string
+		encoding string // what the server compresses with
+		header   string // Content-Encoding sent
+	}{
+		{name: "gzip", encoding: "gzip", header: "gzip"},
+		{name: "zlib deflate", encoding: "deflate", header: "deflate"},
+		{name: "raw deflate", encoding: "raw-deflate", header: "deflate"},
+		{name: "identity", header: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Accept-Encoding"); !strings.Contains(got, "gzip") || !strings.Contains(got, "deflate") {
+					t.Errorf("Accept-Encoding = %q, want gzip and deflate", got)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				if tt.header != "" {
+					w.Header().Set("Content-Encoding", tt.header)
+				}
+				w.Write(compress(t, tt.encoding, body))
+			}))
+			defer srv.Close()
+
+			var resp struct {
+				ID   string `json:"id"`
+				Name string `json:"name"`
+			}
+			if err := NewClient(srv.URL, "token").Get(context.Background(), "/api/projects/proj-1", &resp); err != nil {
+				t.Fatalf("Get() error = %v", err)
+			}
+			if resp.ID != "proj-1" || resp.Name != "my-project" {
+				t.Errorf("response = %+v", resp)
+			}
+		})
+	}
+}
+
+func TestClient_DecodesCompressedErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write(compress(t, "gzip", `{"message":"project not found"}`))
+	}))
+	defer srv.Close()
+
+	err := NewClient(srv.URL, "").Get(context.Background(), "/api/projects/nope", nil)
+	if err == nil || !strings.Contains(err.Error(), "project not found") {
+		t.Fatalf("Get() error = %v, want the decoded message", err)
+	}
+}
+
+func TestClient_EnableDebug_LogsDecodedResponse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		w.Write(compress(t, "gzip", `{"token":"plaintext-pat","id":"tok-1"}`))
+	}))
+	defer srv.Close()
+
+	var log bytes.Buffer
+	client := NewClient(srv.URL, "")
+	client.EnableDebug(&log, true)
+
+	var resp map[string]string
+	if err := client.Get(context.Background(), "/api/tokens/tok-1", &resp); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if resp["id"] != "tok-1" {
+		t.Errorf("response = %v", resp)
+	}
+	out := log.String()
+	if !strings.Contains(out, `"id":"tok-1"`) || strings.Contains(out, "plaintext-pat") {
+		t.Errorf("debug log should show the decoded, redacted body:\n%s", out)
+	}
+}
+
+func TestDecodeBody_Invalid(t *testing.T) {
+	if _, err := decodeBody("gzip", []byte("not gzip")); err == nil {
+		t.Error("decodeBody() accepted a corrupt gzip body")
+	}
+}
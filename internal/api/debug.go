@@ -0,0 +1,174 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/redact"
+)
+
+// maxDebugBodyBytes caps how much of a request/response body is logged
+const maxDebugBodyBytes = 4096
+
+// redactSecretEnvVars masks the env_vars entries of a JSON request body
+// that the body itself names in secret_keys. Bodies without secret keys are
+// returned unchanged.
+func redactSecretEnvVars(body []byte) []byte {
+	var doc map[string]json.RawMessage
+	if json.Unmarshal(body, &doc) != nil {
+		return body
+	}
+	var secretKeys []string
+	if json.Unmarshal(doc["secret_keys"], &secretKeys) != nil || len(secretKeys) == 0 {
+		return body
+	}
+	var envVars map[string]json.RawMessage
+	if json.Unmarshal(doc["env_vars"], &envVars) != nil {
+		return body
+	}
+
+	for _, key := range secretKeys {
+		if _, ok := envVars[key]; ok {
+			envVars[key] = json.RawMessage(`"` + redact.Mask + `"`)
+		}
+	}
+	redacted, err := json.Marshal(envVars)
+	if err != nil {
+		return body
+	}
+	doc["env_vars"] = redacted
+	out, err := json.Marshal(doc)
+	if err != nil {
+		return body
+	}
+	return out
+}
+
+// debugTransport logs each HTTP round trip to w, if set, and records its
+// duration in timings, if set. Bodies are logged only when logBodies is set,
+// are truncated, and always pass through redact.String; multipart uploads
+// are never buffered for logging.
+type debugTransport struct {
+	base      http.RoundTripper
+	w         io.Writer
+	logBodies bool
+	timings   *Timings
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.w == nil {
+		start := time.Now()
+		resp, err := t.base.RoundTrip(req)
+		t.record(req, time.Since(start))
+		return resp, err
+	}
+
+	fmt.Fprintf(t.w, "[debug] → %s %s\n", req.Method, redact.String(req.URL.Redacted()))
+	for _, name := range []string{"Authorization", "Content-Type", "User-Agent"} {
+		if v := req.Header.Get(name); v != "" {
+			fmt.Fprintf(t.w, "[debug]   %s: %s\n", name, redact.String(v))
+		}
+	}
+
+	if t.logBodies && req.Body != nil && req.Body != http.NoBody {
+		if isMultipart(req.Header.Get("Content-Type")) {
+			fmt.Fprintf(t.w, "[debug]   body: <multipart upload, %d bytes>\n", req.ContentLength)
+		} else {
+			body, err := io.ReadAll(req.Body)
+			req.Body.Close()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+			t.logBody("body", body)
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.base.RoundTrip(req)
+	t.record(req, time.Since(start))
+	elapsed := time.Since(start).Round(time.Millisecond)
+	if err != nil {
+		fmt.Fprintf(t.w, "[debug] ← %s %s failed after %s: %s\n", req.Method, redact.String(req.URL.Redacted()), elapsed, redact.String(err.Error()))
+		return nil, err
+	}
+
+	fmt.Fprintf(t.w, "[debug] ← %s (%s)\n", resp.Status, elapsed)
+	if t.logBodies && resp.Body != nil {
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		if decoded, err := decodeBody(resp.Header.Get("Content-Encoding"), body); err == nil {
+			body = decoded
+		}
+		t.logBody("response", body)
+	}
+
+	return resp, nil
+}
+
+// record adds a round trip to timings, if set
+func (t *debugTransport) record(req *http.Request, elapsed time.Duration) {
+	if t.timings != nil {
+		t.timings.record(req, elapsed)
+	}
+}
+
+// logBody writes a truncated, redacted body to the debug log. Secret env
+// vars are masked before truncation so a cut cannot expose a partial value.
+func (t *debugTransport) logBody(label string, body []byte) {
+	if len(body) == 0 {
+		return
+	}
+	body = redactSecretEnvVars(body)
+	s := string(body)
+	suffix := ""
+	if len(s) > maxDebugBodyBytes {
+		s = s[:maxDebugBodyBytes]
+		suffix = fmt.Sprintf(" … (%d bytes total)", len(body))
+	}
+	fmt.Fprintf(t.w, "[debug]   %s: %s%s\n", label, redact.String(s), suffix)
+}
+
+func isMultipart(contentType string) bool {
+	return strings.HasPrefix(strings.ToLower(contentType), "multipart/")
+}
+
+// EnableDebug logs every request made by this client to w, including the
+// multipart upload path. When logBodies is set, request and response bodies
+// are logged as well (secrets redacted).
+func (c *Client) EnableDebug(w io.Writer, logBodies bool) {
+	t := c.debugTransport()
+	t.w = w
+	t.logBodies = logBodies
+}
+
+// EnableTimings records the count and duration of every request made by
+// this client, including uploads, in timings
+func (c *Client) EnableTimings(timings *Timings) {
+	c.debugTransport().timings = timings
+}
+
+// debugTransport returns the client's debugTransport, wrapping the current
+// transport in one first if needed
+func (c *Client) debugTransport() *debugTransport {
+	if t, ok := c.httpClient.Transport.(*debugTransport); ok {
+		return t
+	}
+	base := c.httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &debugTransport{base: base}
+	c.httpClient.Transport = t
+	return t
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_EnableDebug(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token":"plaintext-pat","id":"tok-1"}`))
+	}))
+	defer srv.Close()
+
+	var log bytes.Buffer
+	client := NewClient(srv.URL, "secret-access-token")
+	client.EnableDebug(&log, true)
+
+	var resp map[string]string
+	if err := client.Post(context.Background(), "/api/tokens", map[string]string{"client_secret": "cs-1", "name": "ci"}, &resp); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if resp["token"] != "plaintext-pat" {
+		t.Errorf("response body was not passed through: %v", resp)
+	}
+
+	out := log.String()
+	for _, want := range []string{"→ POST " + srv.URL + "/api/tokens", "← 200 OK", `"name":"ci"`, `"id":"tok-1"`, "Bearer [REDACTED]"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("debug log missing %q:\n%s", want, out)
+		}
+	}
+	for _, secret := range []string{"secret-access-token", "cs-1", "plaintext-pat"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("debug log leaked %q:\n%s", secret, out)
+		}
+	}
+}
+
+func TestClient_EnableDebug_RedactsSecretEnvVars(t *testing.T) {
+	var received CreateAppRequest
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"app_id":"app-1"}`))
+	}))
+	defer srv.Close()
+
+	var log bytes.Buffer
+	client := NewClient(srv.URL, "tok")
+	client.EnableDebug(&log, true)
+
+	_, err := client.CreateApp(context.Background(), &CreateAppRequest{
+		AppName:    "web",
+		EnvVars:    map[string]string{"LOG_LEVEL": "debug", "DB_PASSWORD": "hunter2"},
+		SecretKeys: []string{"DB_PASSWORD"},
+	})
+	if err != nil {
+		t.Fatalf("CreateApp() error = %v", err)
+	}
+
+	if received.EnvVars["DB_PASSWORD"] != "hunter2" {
+		t.Errorf("server received %v, want the real secret value", received.EnvVars)
+	}
+	out := log.String()
+	if strings.Contains(out, "hunter2") {
+		t.Errorf("debug log leaked a secret env var:\n%s", out)
+	}
+	for _, want := range []string{`"DB_PASSWORD":"[REDACTED]"`, `"LOG_LEVEL":"debug"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("debug log missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestClient_EnableDebug_RedactsURLSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	var log bytes.Buffer
+	client := NewClient(srv.URL, "tok")
+	client.EnableDebug(&log, false)
+
+	if err := client.Get(context.Background(), "/api/callback?code=auth-code-1&state=s1", nil); err == nil {
+		t.Fatal("Get() to a closed server succeeded")
+	}
+
+	out := log.String()
+	if strings.Contains(out, "auth-code-1") {
+		t.Errorf("debug log leaked the authorization code:\n%s", out)
+	}
+	if !strings.Contains(out, "code=[REDACTED]&state=s1") {
+		t.Errorf("debug log missing the redacted query:\n%s", out)
+	}
+}
@@ -0,0 +1,23 @@
+package api
+
+import (
+	"crypto/rand"
+	"fmt"
+)
+
+// idempotencyKeyHeader lets the API recognize a repeated POST as the same
+// logical request, so a retry after a flaky connection cannot create a
+// second resource
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// newIdempotencyKey returns a random (version 4) UUID
+func newIdempotencyKey() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		// crypto/rand does not fail on supported platforms
+		panic(fmt.Sprintf("failed to generate idempotency key: %v", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,172 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// maxPages guards against a server that keeps returning a next page
+const maxPages = 1000
+
+// ErrStopPaging can be returned from a PageFunc to stop fetching further
+// pages without reporting an error
+var ErrStopPaging = errors.New("stop paging")
+
+// ErrNotModified is returned by GetPagesIfNoneMatch when the list still
+// matches the given ETag
+var ErrNotModified = errors.New("not modified")
+
+// PageFunc receives the raw JSON items of one page. hasMore reports whether
+// the server advertised another page after this one.
+type PageFunc func(items json.RawMessage, hasMore bool) error
+
+// pageEnvelope is the paginated list shape. Endpoints that are not
+// paginated return a bare JSON array instead, which is treated as a single
+// page.
+type pageEnvelope struct {
+	Items      json.RawMessage `json:"items"`
+	Data       json.RawMessage `json:"data"`
+	Next       string          `json:"next"`
+	NextCursor string          `json:"next_cursor"`
+}
+
+// linkNextPattern matches the rel="next" entry of an RFC 8288 Link header
+var linkNextPattern = regexp.MustCompile(`<([^>]+)>\s*;[^,]*\brel="?next"?`)
+
+// GetPages fetches a list endpoint page by page, calling fn once per page.
+// The next page is taken from a "next"/"next_cursor" field in the body or a
+// Link rel="next" header. Pages are not accumulated, so callers decide how
+// much to keep.
+func (c *Client) GetPages(ctx context.Context, path string, fn PageFunc) error {
+	_, err := c.GetPagesIfNoneMatch(ctx, path, "", fn)
+	return err
+}
+
+// GetPagesIfNoneMatch is GetPages with a conditional first request. With a
+// non-empty etag, If-None-Match is sent, and if the server answers 304 Not
+// Modified, ErrNotModified is returned without calling fn. It returns the
+// ETag of the list, which is only set when the list fits in one page: the
+// first page's ETag says nothing about the pages after it.
+func (c *Client) GetPagesIfNoneMatch(ctx context.Context, path, etag string, fn PageFunc) (string, error) {
+	var listETag string
+	seen := make(map[string]bool)
+	for page := 0; path != ""; page++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		if page >= maxPages {
+			return "", fmt.Errorf("pagination exceeded %d pages", maxPages)
+		}
+		seen[path] = true
+
+		var reqHeader http.Header
+		if page == 0 && etag != "" {
+			reqHeader = http.Header{"If-None-Match": {etag}}
+		}
+
+		var header http.Header
+		var body []byte
+		err := c.withAuthRetry(ctx, func() error {
+			var err error
+			header, body, err = c.send(ctx, http.MethodGet, path, nil, reqHeader)
+			return err
+		})
+		if err != nil {
+			return "", err
+		}
+
+		items, next, err := c.parsePage(path, header, body)
+		if err != nil {
+			return "", err
+		}
+		if seen[next] {
+			return "", fmt.Errorf("pagination loop detected at %s", next)
+		}
+		if page == 0 && next == "" {
+			listETag = header.Get("ETag")
+		}
+
+		if err := fn(items, next != ""); err != nil {
+			if errors.Is(err, ErrStopPaging) {
+				return listETag, nil
+			}
+			return "", err
+		}
+		path = next
+	}
+	return listETag, nil
+}
+
+// parsePage extracts the items and the path of the next page (empty if
+// this is the last page) from a list response
+func (c *Client) parsePage(path string, header http.Header, body []byte) (json.RawMessage, string, error) {
+	items := json.RawMessage("[]")
+	var next string
+
+	trimmed := strings.TrimSpace(string(body))
+	switch {
+	case trimmed == "" || trimmed == "null":
+	case strings.HasPrefix(trimmed, "["):
+		items = json.RawMessage(trimmed)
+	default:
+		var env pageEnvelope
+		if err := json.Unmarshal(body, &env); err != nil {
+			return nil, "", fmt.Errorf("failed to parse response: %w", err)
+		}
+		if env.Items != nil {
+			items = env.Items
+		} else if env.Data != nil {
+			items = env.Data
+		}
+		next = env.Next
+		if next == "" {
+			next = env.NextCursor
+		}
+	}
+
+	if next == "" {
+		if m := linkNextPattern.FindStringSubmatch(header.Get("Link")); m != nil {
+			next = m[1]
+		}
+	}
+	if next == "" {
+		return items, "", nil
+	}
+
+	nextPath, err := c.nextPath(path, next)
+	if err != nil {
+		return nil, "", err
+	}
+	return items, nextPath, nil
+}
+
+// nextPath resolves a next-page reference to a request path. It may be a
+// URL on the API host, an absolute path, or an opaque cursor that is sent
+// back as the "cursor" query parameter of the current path.
+func (c *Client) nextPath(current, next string) (string, error) {
+	if strings.HasPrefix(next, "http://") || strings.HasPrefix(next, "https://") {
+		// Never send the bearer token to another host
+		if !strings.HasPrefix(next, strings.TrimRight(c.baseURL, "/")+"/") {
+			return "", fmt.Errorf("refusing to follow next page on a different host: %s", next)
+		}
+		return strings.TrimPrefix(next, strings.TrimRight(c.baseURL, "/")), nil
+	}
+	if strings.HasPrefix(next, "/") {
+		return next, nil
+	}
+
+	u, err := url.Parse(current)
+	if err != nil {
+		return "", fmt.Errorf("invalid request path %q: %w", current, err)
+	}
+	q := u.Query()
+	q.Set("cursor", next)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
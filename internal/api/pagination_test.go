@@ -0,0 +1,214 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// twoPageHandler serves /api/projects as two pages, linking the second
+// page either via a cursor in the body or a Link header
+func twoPageHandler(t *testing.T, useLink bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		switch {
+		case r.URL.Query().Get("cursor") == "" && !useLink:
+			w.Write([]byte(`{"items":[{"id":"p1"},{"id":"p2"}],"next":"c2"}`))
+		case r.URL.Query().Get("cursor") == "c2" && !useLink:
+			w.Write([]byte(`{"items":[{"id":"p3"}]}`))
+		case r.URL.Query().Get("page") == "" && useLink:
+			w.Header().Set("Link", `</api/projects?page=2>; rel="next", </api/projects?page=2>; rel="last"`)
+			w.Write([]byte(`[{"id":"p1"},{"id":"p2"}]`))
+		case r.URL.Query().Get("page") == "2" && useLink:
+			w.Write([]byte(`[{"id":"p3"}]`))
+		default:
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}
+}
+
+func collectIDs(t *testing.T, c *Client, stopAfter int) ([]string, error) {
+	t.Helper()
+	var ids []string
+	err := c.GetPages(context.Background(), "/api/projects", func(items json.RawMessage, hasMore bool) error {
+		var page []struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(items, &page); err != nil {
+			return err
+		}
+		for _, p := range page {
+			ids = append(ids, p.ID)
+		}
+		if stopAfter > 0 && len(ids) >= stopAfter {
+			return ErrStopPaging
+		}
+		return nil
+	})
+	return ids, err
+}
+
+func TestClient_GetPages(t *testing.T) {
+	tests := []struct {
+		name      string
+		useLink   bool
+		stopAfter int
+		wantIDs   string
+		wantCalls int
+	}{
+		{name: "cursor in body", wantIDs: "p1,p2,p3", wantCalls: 2},
+		{name: "link header", useLink: true, wantIDs: "p1,p2,p3", wantCalls: 2},
+		{name: "stop after first page", stopAfter: 2, wantIDs: "p1,p2", wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			handler := twoPageHandler(t, tt.useLink)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				handler(w, r)
+			}))
+			defer srv.Close()
+
+			ids, err := collectIDs(t, NewClient(srv.URL, "tok"), tt.stopAfter)
+			if err != nil {
+				t.Fatalf("GetPages() error = %v", err)
+			}
+			if got := strings.Join(ids, ","); got != tt.wantIDs {
+				t.Errorf("ids = %s, want %s", got, tt.wantIDs)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestClient_GetPages_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantErrMsg string
+	}{
+		{
+			name:       "next page on another host",
+			body:       `{"items":[],"next":"https://evil.example.com/api/projects?cursor=x"}`,
+			wantErrMsg: "different host",
+		},
+		{
+			name:       "next page points back at itself",
+			body:       `{"items":[],"next":"/api/projects"}`,
+			wantErrMsg: "pagination loop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			_, err := collectIDs(t, NewClient(srv.URL, "tok"), 0)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+				t.Fatalf("GetPages() error = %v, want containing %q", err, tt.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestClient_GetPages_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"items":[{"id":"p1"}],"next":"c2"}`))
+	}))
+	defer srv.Close()
+
+	pages := 0
+	err := NewClient(srv.URL, "tok").GetPages(ctx, "/api/projects", func(items json.RawMessage, hasMore bool) error {
+		pages++
+		cancel()
+		return nil
+	})
+	if err != context.Canceled {
+		t.Errorf("GetPages() error = %v, want context.Canceled", err)
+	}
+	if pages != 1 {
+		t.Errorf("pages = %d, want 1", pages)
+	}
+}
+
+func TestClient_GetPagesIfNoneMatch(t *testing.T) {
+	var gotIfNoneMatch []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotIfNoneMatch = append(gotIfNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"id":"p1"}]`))
+	}))
+	defer srv.Close()
+	c := NewClient(srv.URL, "")
+	ctx := context.Background()
+
+	pages := 0
+	countPages := func(items json.RawMessage, hasMore bool) error {
+		pages++
+		return nil
+	}
+
+	etag, err := c.GetPagesIfNoneMatch(ctx, "/api/projects", "", countPages)
+	if err != nil {
+		t.Fatalf("GetPagesIfNoneMatch() error = %v", err)
+	}
+	if etag != `"v1"` || pages != 1 {
+		t.Errorf("etag = %q after %d page(s), want \"v1\" after 1", etag, pages)
+	}
+
+	etag, err = c.GetPagesIfNoneMatch(ctx, "/api/projects", `"v1"`, countPages)
+	if !errors.Is(err, ErrNotModified) {
+		t.Fatalf("GetPagesIfNoneMatch() error = %v, want ErrNotModified", err)
+	}
+	if pages != 1 {
+		t.Errorf("fn called on a 304 response")
+	}
+	if etag != "" {
+		t.Errorf("etag = %q on a 304 response, want empty", etag)
+	}
+
+	if want := []string{"", `"v1"`}; strings.Join(gotIfNoneMatch, "|") != strings.Join(want, "|") {
+		t.Errorf("If-None-Match headers = %q, want %q", gotIfNoneMatch, want)
+	}
+}
+
+func TestClient_GetPagesIfNoneMatch_MultiplePages(t *testing.T) {
+	handler := twoPageHandler(t, false)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Only the first page's ETag is sent; it doesn't cover the list
+		if r.URL.Query().Get("cursor") == "" {
+			w.Header().Set("ETag", `"page-1"`)
+		}
+		handler(w, r)
+	}))
+	defer srv.Close()
+
+	etag, err := NewClient(srv.URL, "").GetPagesIfNoneMatch(context.Background(), "/api/projects", "", func(items json.RawMessage, hasMore bool) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("GetPagesIfNoneMatch() error = %v", err)
+	}
+	if etag != "" {
+		t.Errorf("etag = %q for a list of several pages, want empty", etag)
+	}
+}
@@ -0,0 +1,75 @@
+package api
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// EndpointTiming is the number of requests made to one endpoint and the
+// time they took in total
+type EndpointTiming struct {
+	Endpoint string // method and path template, e.g. GET /api/apps/{id}
+	Calls    int
+	Total    time.Duration
+}
+
+// Timings accumulates per-endpoint request counts and durations. It is safe
+// for concurrent use and may be shared by several clients.
+type Timings struct {
+	mu        sync.Mutex
+	endpoints map[string]*EndpointTiming
+}
+
+// NewTimings creates an empty Timings
+func NewTimings() *Timings {
+	return &Timings{endpoints: make(map[string]*EndpointTiming)}
+}
+
+// record adds one request to the endpoint of req
+func (t *Timings) record(req *http.Request, elapsed time.Duration) {
+	endpoint := req.Method + " " + endpointPath(req.URL.Path)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	e, ok := t.endpoints[endpoint]
+	if !ok {
+		e = &EndpointTiming{Endpoint: endpoint}
+		t.endpoints[endpoint] = e
+	}
+	e.Calls++
+	e.Total += elapsed
+}
+
+// Summary returns the endpoints requested so far, slowest in total first
+func (t *Timings) Summary() []EndpointTiming {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	summary := make([]EndpointTiming, 0, len(t.endpoints))
+	for _, e := range t.endpoints {
+		summary = append(summary, *e)
+	}
+	sort.Slice(summary, func(i, j int) bool {
+		if summary[i].Total != summary[j].Total {
+			return summary[i].Total > summary[j].Total
+		}
+		return summary[i].Endpoint < summary[j].Endpoint
+	})
+	return summary
+}
+
+// endpointPath replaces the path segments that hold IDs, i.e. those
+// containing a digit, with {id}, so requests for different apps or projects
+// count towards the same endpoint
+func endpointPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if strings.IndexFunc(s, unicode.IsDigit) >= 0 {
+			segments[i] = "{id}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
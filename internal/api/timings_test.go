@@ -0,0 +1,90 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestClient_EnableTimings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name  string
+		debug bool
+	}{
+		{name: "timings only"},
+		{name: "with debug logging", debug: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log bytes.Buffer
+			timings := NewTimings()
+			client := NewClient(srv.URL, "tok")
+			if tt.debug {
+				client.EnableDebug(&log, false)
+			}
+			client.EnableTimings(timings)
+
+			ctx := context.Background()
+			for _, path := range []string{"/api/apps/4f9c2a7e", "/api/apps/8b1d33c0", "/api/apps/4f9c2a7e", "/api/projects", "/api/apps/4f9c2a7e/status?verbose=1"} {
+				if err := client.Get(ctx, path, nil); err != nil {
+					t.Fatalf("Get(%s) error = %v", path, err)
+				}
+			}
+			if err := client.Post(ctx, "/api/apps", map[string]string{"app_name": "web"}, nil); err != nil {
+				t.Fatalf("Post() error = %v", err)
+			}
+
+			calls := map[string]int{}
+			for _, e := range timings.Summary() {
+				calls[e.Endpoint] = e.Calls
+				if e.Total <= 0 {
+					t.Errorf("%s total = %s, want > 0", e.Endpoint, e.Total)
+				}
+			}
+			want := map[string]int{
+				"GET /api/apps/{id}":        3,
+				"GET /api/projects":         1,
+				"GET /api/apps/{id}/status": 1,
+				"POST /api/apps":            1,
+			}
+			if len(calls) != len(want) {
+				t.Errorf("endpoints = %v, want %v", calls, want)
+			}
+			for endpoint, n := range want {
+				if calls[endpoint] != n {
+					t.Errorf("%s calls = %d, want %d", endpoint, calls[endpoint], n)
+				}
+			}
+			if tt.debug && strings.Count(log.String(), "[debug] →") != 6 {
+				t.Errorf("debug logging stopped working:\n%s", log.String())
+			}
+		})
+	}
+}
+
+func TestEndpointPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/api/projects", "/api/projects"},
+		{"/api/apps/4f9c2a7e/deployments", "/api/apps/{id}/deployments"},
+		{"/api/installations/new", "/api/installations/new"},
+		{"/api/repositories/acme/web/branches", "/api/repositories/acme/web/branches"},
+	}
+
+	for _, tt := range tests {
+		if got := endpointPath(tt.path); got != tt.want {
+			t.Errorf("endpointPath(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
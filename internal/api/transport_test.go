@@ -0,0 +1,149 @@
+package api
+
+import (
+	"context"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestNewTransport_RoutesThroughProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute URL of the target
+		proxiedHost = r.URL.Host
+		w.Write([]byte(`{"id":"u1"}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	client := NewClient("http://api.kamui.invalid", "token")
+	client.SetTransport(NewTransport(TransportOptions{Proxy: proxyURL}))
+
+	me, err := client.GetMe(context.Background())
+	if err != nil {
+		t.Fatalf("GetMe() error = %v", err)
+	}
+	if proxiedHost != "api.kamui.invalid" {
+		t.Errorf("proxy saw host %q, want api.kamui.invalid", proxiedHost)
+	}
+	if me.ID != "u1" {
+		t.Errorf("GetMe() = %+v", me)
+	}
+}
+
+func TestNewTransport_CustomRootCAs(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/me":
+			w.Write([]byte(`{"id":"u1"}`))
+		case "/api/static-apps/upload":
+			w.Write([]byte(`{"app_id":"app-1"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw})
+	if err := os.WriteFile(caPath, certPEM, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	zipPath := filepath.Join(dir, "site.zip")
+	if err := os.WriteFile(zipPath, []byte("zip"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	upload := &CreateStaticAppUploadRequest{ProjectID: "p", AppName: "site", Replicas: 1, AppSpecType: "nano", FilePath: zipPath}
+
+	// Without the CA the server is not trusted
+	untrusted := NewClient(srv.URL, "token")
+	if _, err := untrusted.GetMe(context.Background()); err == nil {
+		t.Fatal("GetMe() without the CA succeeded, want a certificate error")
+	}
+
+	pool, err := LoadCertPool(caPath)
+	if err != nil {
+		t.Fatalf("LoadCertPool() error = %v", err)
+	}
+	for name, opts := range map[string]TransportOptions{
+		"custom CA":            {RootCAs: pool},
+		"insecure skip verify": {InsecureSkipVerify: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			client := NewClient(srv.URL, "token")
+			client.SetTransport(NewTransport(opts))
+
+			if _, err := client.GetMe(context.Background()); err != nil {
+				t.Errorf("GetMe() error = %v", err)
+			}
+			if _, err := client.CreateStaticAppUpload(context.Background(), upload); err != nil {
+				t.Errorf("CreateStaticAppUpload() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadCertPool_RejectsNonPEM(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(path, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCertPool(path); err == nil {
+		t.Error("LoadCertPool() error = nil, want error")
+	}
+}
+
+func TestSharedTransport_ReusesConnections(t *testing.T) {
+	var conns int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"u1"}`))
+	}))
+	srv.Config.ConnState = func(c net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&conns, 1)
+		}
+	}
+	srv.Start()
+	defer srv.Close()
+
+	opts := TransportOptions{}
+	if SharedTransport(opts) != SharedTransport(opts) {
+		t.Fatal("SharedTransport() returned different transports for the same options")
+	}
+
+	// A fresh client per request, as the services create, still shares
+	// the pool
+	for i := 0; i < 5; i++ {
+		client := NewClient(srv.URL, "token")
+		client.SetTransport(SharedTransport(opts))
+		if _, err := client.GetMe(context.Background()); err != nil {
+			t.Fatalf("GetMe() error = %v", err)
+		}
+	}
+	if got := atomic.LoadInt32(&conns); got != 1 {
+		t.Errorf("server saw %d connections for 5 sequential requests, want 1", got)
+	}
+}
+
+func BenchmarkSharedTransport(b *testing.B) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"u1"}`))
+	}))
+	defer srv.Close()
+
+	for i := 0; i < b.N; i++ {
+		client := NewClient(srv.URL, "token")
+		client.SetTransport(SharedTransport(TransportOptions{}))
+		if _, err := client.GetMe(context.Background()); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
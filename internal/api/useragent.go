@@ -0,0 +1,28 @@
+package api
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// cliVersionHeader carries the bare CLI version so the API can reject
+// clients it no longer supports
+const cliVersionHeader = "X-Kamui-CLI-Version"
+
+// version is the CLI version reported in the User-Agent header. It is set
+// by the cmd package from its build-time Version variable.
+var version = "dev"
+
+// SetVersion sets the CLI version reported in the User-Agent and
+// X-Kamui-CLI-Version headers
+func SetVersion(v string) {
+	if v != "" {
+		version = v
+	}
+}
+
+// UserAgent returns the User-Agent sent with every API request,
+// e.g. "kamui-cli/1.2.3 (darwin/arm64)"
+func UserAgent() string {
+	return fmt.Sprintf("kamui-cli/%s (%s/%s)", version, runtime.GOOS, runtime.GOARCH)
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestUserAgent_SentOnEveryRequest(t *testing.T) {
+	SetVersion("1.2.3")
+	defer SetVersion("dev")
+
+	var got, gotVersions []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = append(got, r.Header.Get("User-Agent"))
+		gotVersions = append(gotVersions, r.Header.Get(cliVersionHeader))
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"app_id":"app-1"}`))
+	}))
+	defer srv.Close()
+
+	zipPath := filepath.Join(t.TempDir(), "site.zip")
+	if err := os.WriteFile(zipPath, []byte("PK"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	client := NewClient(srv.URL, "token")
+	if err := client.Get(context.Background(), "/api/projects", nil); err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	if _, err := client.CreateStaticAppUpload(context.Background(), &CreateStaticAppUploadRequest{
+		ProjectID: "proj-1",
+		AppName:   "site",
+		FilePath:  zipPath,
+	}); err != nil {
+		t.Fatalf("CreateStaticAppUpload() error = %v", err)
+	}
+
+	want := "kamui-cli/1.2.3 (" + runtime.GOOS + "/" + runtime.GOARCH + ")"
+	if len(got) != 2 {
+		t.Fatalf("got %d requests, want 2", len(got))
+	}
+	for i, ua := range got {
+		if ua != want {
+			t.Errorf("request %d User-Agent = %q, want %q", i, ua, want)
+		}
+		if gotVersions[i] != "1.2.3" {
+			t.Errorf("request %d %s = %q, want %q", i, cliVersionHeader, gotVersions[i], "1.2.3")
+		}
+	}
+}
+
+func TestClient_UpgradeRequired(t *testing.T) {
+	SetVersion("0.9.0")
+	defer SetVersion("dev")
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		outdated bool
+	}{
+		{name: "error code", status: http.StatusBadRequest, body: `{"message":"unknown field spec_v2","code":"client_outdated"}`, outdated: true},
+		{name: "426 without code", status: http.StatusUpgradeRequired, body: `{"message":"upgrade required"}`, outdated: true},
+		{name: "other error", status: http.StatusBadRequest, body: `{"message":"client too old","code":"invalid_request"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer srv.Close()
+
+			err := NewClient(srv.URL, "token").Get(context.Background(), "/api/projects", nil)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Get() error = %v, want *APIError", err)
+			}
+			if apiErr.IsClientOutdated() != tt.outdated {
+				t.Errorf("IsClientOutdated() = %v, want %v", apiErr.IsClientOutdated(), tt.outdated)
+			}
+			upgrade := strings.Contains(err.Error(), "kamui CLI (0.9.0) is out of date; please upgrade")
+			if upgrade != tt.outdated {
+				t.Errorf("Error() = %q, upgrade message shown = %v, want %v", err.Error(), upgrade, tt.outdated)
+			}
+		})
+	}
+}
+
+func TestUserAgent_Format(t *testing.T) {
+	if ua := UserAgent(); !regexp.MustCompile(`^kamui-cli/\S+ \(\w+/\w+\)$`).MatchString(ua) {
+		t.Errorf("UserAgent() = %q, unexpected format", ua)
+	}
+}
@@ -0,0 +1,220 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/redact"
+)
+
+const (
+	// deviceCodeGrantType is the grant_type for polling the token endpoint
+	// during the device authorization grant (RFC 8628 §3.4).
+	deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	// defaultDevicePollInterval is used when the server omits `interval`
+	// (RFC 8628 §3.2 defaults to 5 seconds).
+	defaultDevicePollInterval = 5
+
+	// slowDownIncrement is added to the polling interval on every
+	// `slow_down` response (RFC 8628 §3.5).
+	slowDownIncrement = 5
+)
+
+// devicePollUnit scales the server-provided polling interval. It is a
+// variable so tests can poll without sleeping for whole seconds.
+var devicePollUnit = time.Second
+
+// DeviceAuthorizationResponse represents the response from the device
+// authorization endpoint (RFC 8628 §3.2)
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval,omitempty"`
+}
+
+// tokenErrorResponse represents an OAuth error response from the token endpoint
+type tokenErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// LoginDevice performs the OAuth device authorization grant (RFC 8628).
+// It is meant for headless environments where no browser can reach the
+// local callback server: the user code and verification URL are printed,
+// and the token endpoint is polled until the user approves the request.
+func (o *OAuthFlow) LoginDevice(ctx context.Context) (*OAuthResult, error) {
+	// Same rationale as Login: always mint fresh client credentials.
+	creds, err := o.registerClient(ctx, nil, []string{deviceCodeGrantType, "refresh_token"})
+	if err != nil {
+		return nil, fmt.Errorf("failed to register client: %w", err)
+	}
+	o.clientID = creds.ClientID
+	o.clientSecret = creds.ClientSecret
+
+	deviceAuth, err := o.requestDeviceAuthorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(os.Stderr, "To authenticate, visit:")
+	if deviceAuth.VerificationURIComplete != "" {
+		fmt.Fprintf(os.Stderr, "  %s\n\n", deviceAuth.VerificationURIComplete)
+		fmt.Fprintf(os.Stderr, "or open %s and enter the code:\n", deviceAuth.VerificationURI)
+	} else {
+		fmt.Fprintf(os.Stderr, "  %s\n\nand enter the code:\n", deviceAuth.VerificationURI)
+	}
+	fmt.Fprintf(os.Stderr, "  %s\n\n", deviceAuth.UserCode)
+	fmt.Fprintln(os.Stderr, "Waiting for authentication...")
+
+	return o.pollDeviceToken(ctx, deviceAuth)
+}
+
+// requestDeviceAuthorization requests a device code and user code
+func (o *OAuthFlow) requestDeviceAuthorization(ctx context.Context) (*DeviceAuthorizationResponse, error) {
+	deviceURL := o.apiURL + "/oauth/device_authorization"
+
+	data := url.Values{}
+	data.Set("client_id", o.clientID)
+	data.Set("scope", o.scope)
+	if o.clientSecret != "" {
+		data.Set("client_secret", o.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, deviceURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create device authorization request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
+
+	client := o.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("device authorization request failed: %w", redact.Error(err))
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("device authorization failed with status %d", resp.StatusCode)
+	}
+
+	var deviceAuth DeviceAuthorizationResponse
+	if err := json.NewDecoder(resp.Body).Decode(&deviceAuth); err != nil {
+		return nil, fmt.Errorf("failed to parse device authorization response: %w", err)
+	}
+	if deviceAuth.DeviceCode == "" || deviceAuth.UserCode == "" || deviceAuth.VerificationURI == "" {
+		return nil, fmt.Errorf("device authorization response is missing required fields")
+	}
+
+	return &deviceAuth, nil
+}
+
+// pollDeviceToken polls the token endpoint until the user approves or
+// denies the request, or the device code expires
+func (o *OAuthFlow) pollDeviceToken(ctx context.Context, deviceAuth *DeviceAuthorizationResponse) (*OAuthResult, error) {
+	interval := deviceAuth.Interval
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	expiresIn := time.Duration(deviceAuth.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 5 * time.Minute
+	}
+	deadline := time.After(expiresIn)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline:
+			return nil, fmt.Errorf("authentication timed out")
+		case <-time.After(time.Duration(interval) * devicePollUnit):
+		}
+
+		result, errCode, err := o.requestDeviceToken(ctx, deviceAuth.DeviceCode)
+		if err != nil {
+			return nil, err
+		}
+		switch errCode {
+		case "":
+			return result, nil
+		case "authorization_pending":
+			continue
+		case "slow_down":
+			interval += slowDownIncrement
+			continue
+		case "access_denied":
+			return nil, fmt.Errorf("authentication was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("device code expired. Please run 'kamui login --device' again")
+		default:
+			return nil, fmt.Errorf("OAuth error: %s", redact.String(errCode))
+		}
+	}
+}
+
+// requestDeviceToken makes a single token request for the device code.
+// It returns the OAuth error code for expected polling responses
+// (authorization_pending, slow_down, ...) and a Go error for anything else.
+func (o *OAuthFlow) requestDeviceToken(ctx context.Context, deviceCode string) (*OAuthResult, string, error) {
+	tokenURL := o.apiURL + "/oauth/token"
+
+	data := url.Values{}
+	data.Set("grant_type", deviceCodeGrantType)
+	data.Set("device_code", deviceCode)
+	data.Set("client_id", o.clientID)
+	if o.clientSecret != "" {
+		data.Set("client_secret", o.clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
+
+	client := o.httpClient()
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("token request failed: %w", redact.Error(err))
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read token response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp tokenErrorResponse
+		if err := json.Unmarshal(body, &errResp); err == nil && errResp.Error != "" {
+			return nil, errResp.Error, nil
+		}
+		return nil, "", fmt.Errorf("token request failed with status %d", resp.StatusCode)
+	}
+
+	var tokenResp TokenResponse
+	if err := json.Unmarshal(body, &tokenResp); err != nil {
+		return nil, "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+
+	return &OAuthResult{
+		AccessToken:  tokenResp.AccessToken,
+		RefreshToken: tokenResp.RefreshToken,
+		ExpiresIn:    tokenResp.ExpiresIn,
+		Scope:        tokenResp.Scope,
+	}, "", nil
+}
@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoginDevice_PollsUntilApproved(t *testing.T) {
+	devicePollUnit = time.Millisecond
+	t.Cleanup(func() { devicePollUnit = time.Second })
+
+	var tokenCalls int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/register", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if _, ok := body["redirect_uris"]; ok {
+			t.Errorf("device registration should not send redirect_uris: %v", body)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"client_id":"client-1","client_secret":"secret-1"}`))
+	})
+	mux.HandleFunc("/oauth/device_authorization", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("client_id") != "client-1" {
+			t.Errorf("client_id = %q, want client-1", r.Form.Get("client_id"))
+		}
+		_, _ = w.Write([]byte(`{"device_code":"dev-1","user_code":"ABCD-EFGH","verification_uri":"https://example.test/device","expires_in":60,"interval":1}`))
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.Form.Get("grant_type") != deviceCodeGrantType {
+			t.Errorf("grant_type = %q, want %q", r.Form.Get("grant_type"), deviceCodeGrantType)
+		}
+		if r.Form.Get("device_code") != "dev-1" {
+			t.Errorf("device_code = %q, want dev-1", r.Form.Get("device_code"))
+		}
+		tokenCalls++
+		switch tokenCalls {
+		case 1:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"authorization_pending"}`))
+		case 2:
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":"slow_down"}`))
+		default:
+			_, _ = w.Write([]byte(`{"access_token":"at-1","refresh_token":"rt-1","expires_in":3600,"token_type":"Bearer"}`))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	o := NewOAuthFlow(srv.URL)
+	result, err := o.LoginDevice(context.Background())
+	if err != nil {
+		t.Fatalf("LoginDevice: %v", err)
+	}
+	if result.AccessToken != "at-1" || result.RefreshToken != "rt-1" || result.ExpiresIn != 3600 {
+		t.Errorf("result = %+v, want tokens from final poll", result)
+	}
+	if tokenCalls != 3 {
+		t.Errorf("token endpoint called %d times, want 3", tokenCalls)
+	}
+	if creds := o.GetClientCredentials(); creds == nil || creds.ClientID != "client-1" {
+		t.Errorf("client credentials = %+v, want client-1", creds)
+	}
+}
+
+func TestLoginDevice_AccessDenied(t *testing.T) {
+	devicePollUnit = time.Millisecond
+	t.Cleanup(func() { devicePollUnit = time.Second })
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/register", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"client_id":"client-1"}`))
+	})
+	mux.HandleFunc("/oauth/device_authorization", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"device_code":"dev-1","user_code":"ABCD","verification_uri":"https://example.test/device","expires_in":60,"interval":1}`))
+	})
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":"access_denied"}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	_, err := NewOAuthFlow(srv.URL).LoginDevice(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "denied") {
+		t.Fatalf("LoginDevice error = %v, want access denied", err)
+	}
+}
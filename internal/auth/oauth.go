@@ -5,6 +5,8 @@ import (
 	"bytes"
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
@@ -12,9 +14,11 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/kamui-project/kamui-cli/internal/redact"
 	"github.com/pkg/browser"
 )
 
@@ -29,9 +33,16 @@ const (
 	// DefaultClientName is the default name for dynamic client registration
 	DefaultClientName = "Kamui CLI"
 
+	// DefaultScope is the OAuth scope requested unless SetScope overrides it
+	DefaultScope = "full"
+
 	// kamuiClientTypeHeader identifies requests originating from the CLI.
 	kamuiClientTypeHeader = "X-Kamui-Client-Type"
 	kamuiClientTypeCLI    = "cli"
+
+	// callbackShutdownTimeout bounds how long Login waits for the callback
+	// server to finish answering the browser before it returns
+	callbackShutdownTimeout = 5 * time.Second
 )
 
 // OAuthResult contains the result of an OAuth flow
@@ -69,6 +80,18 @@ type OAuthFlow struct {
 	clientID     string
 	clientSecret string
 	callbackPort int
+	// callbackPortFixed disables the upward port scan when the user asked
+	// for a specific callback port
+	callbackPortFixed bool
+	// noBrowser prints the authorization URL instead of opening a browser
+	noBrowser bool
+	// scope is the space-separated scope requested at registration and
+	// authorization
+	scope string
+	// transport carries requests to the API; nil uses http.DefaultTransport
+	transport http.RoundTripper
+	// client is built on first use and reused by every request of the flow
+	client *http.Client
 }
 
 // NewOAuthFlow creates a new OAuth flow handler
@@ -78,6 +101,7 @@ func NewOAuthFlow(apiURL string) *OAuthFlow {
 		clientID:     "",
 		clientSecret: "",
 		callbackPort: DefaultCallbackPort,
+		scope:        DefaultScope,
 	}
 }
 
@@ -87,16 +111,82 @@ func (o *OAuthFlow) SetClientCredentials(clientID, clientSecret string) {
 	o.clientSecret = clientSecret
 }
 
+// SetTransport sets the transport used for requests to the API, e.g. one
+// that routes through a proxy. nil restores http.DefaultTransport.
+func (o *OAuthFlow) SetTransport(rt http.RoundTripper) {
+	o.transport = rt
+	o.client = nil
+}
+
+// httpClient returns the client used for requests to the API
+func (o *OAuthFlow) httpClient() *http.Client {
+	if o.client == nil {
+		o.client = &http.Client{Timeout: 30 * time.Second, Transport: o.transport}
+	}
+	return o.client
+}
+
+// SetCallbackPort sets the port for the local OAuth callback server.
+// The port is used as-is (no upward scan); 0 lets the OS pick a free
+// ephemeral port.
+func (o *OAuthFlow) SetCallbackPort(port int) {
+	o.callbackPort = port
+	o.callbackPortFixed = true
+}
+
+// SetNoBrowser controls whether Login opens the authorization URL in a
+// browser. When true the URL is only printed (e.g. for SSH sessions); the
+// local callback server still runs and waits for the redirect.
+func (o *OAuthFlow) SetNoBrowser(noBrowser bool) {
+	o.noBrowser = noBrowser
+}
+
+// SetScope sets the OAuth scope to request. An empty scope keeps
+// DefaultScope.
+func (o *OAuthFlow) SetScope(scope string) {
+	if scope == "" {
+		scope = DefaultScope
+	}
+	o.scope = scope
+}
+
+// ValidateScope checks that scope is a non-empty, space-separated list of
+// scope tokens as defined in RFC 6749 §3.3
+func ValidateScope(scope string) error {
+	tokens := strings.Fields(scope)
+	if len(tokens) == 0 {
+		return errors.New("scope must not be empty")
+	}
+	for _, token := range tokens {
+		for _, r := range token {
+			// scope-token = 1*( %x21 / %x23-5B / %x5D-7E )
+			if r < 0x21 || r > 0x7e || r == '"' || r == '\\' {
+				return fmt.Errorf("invalid character %q in scope %q", r, token)
+			}
+		}
+	}
+	return nil
+}
+
 // RegisterClient performs OAuth Dynamic Client Registration (RFC 7591)
 // This should be called before Login if no client credentials are stored
 func (o *OAuthFlow) RegisterClient(ctx context.Context, redirectURI string) (*ClientCredentials, error) {
+	return o.registerClient(ctx, []string{redirectURI}, []string{"authorization_code", "refresh_token"})
+}
+
+// registerClient sends the dynamic client registration request for the
+// given redirect URIs and grant types. redirectURIs may be empty for
+// grants that don't use a redirect (e.g. the device authorization grant).
+func (o *OAuthFlow) registerClient(ctx context.Context, redirectURIs, grantTypes []string) (*ClientCredentials, error) {
 	registerURL := o.apiURL + "/oauth/register"
 
 	reqBody := map[string]interface{}{
-		"client_name":   DefaultClientName,
-		"redirect_uris": []string{redirectURI},
-		"grant_types":   []string{"authorization_code", "refresh_token"},
-		"scope":         "full",
+		"client_name": DefaultClientName,
+		"grant_types": grantTypes,
+		"scope":       o.scope,
+	}
+	if len(redirectURIs) > 0 {
+		reqBody["redirect_uris"] = redirectURIs
 	}
 
 	jsonBody, err := json.Marshal(reqBody)
@@ -111,10 +201,10 @@ func (o *OAuthFlow) RegisterClient(ctx context.Context, redirectURI string) (*Cl
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := o.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("registration request failed: %w", err)
+		return nil, fmt.Errorf("registration request failed: %w", redact.Error(err))
 	}
 	defer resp.Body.Close()
 
@@ -137,11 +227,14 @@ func (o *OAuthFlow) RegisterClient(ctx context.Context, redirectURI string) (*Cl
 // It starts a local server, opens the browser for authentication,
 // and waits for the callback with the authorization code
 func (o *OAuthFlow) Login(ctx context.Context) (*OAuthResult, error) {
-	// Find an available port first (needed for redirect URI)
-	port, err := o.findAvailablePort()
+	// Bind the callback port first (needed for redirect URI). The listener
+	// is kept and served from, so the port can't be taken in between.
+	listener, err := o.findAvailablePort()
 	if err != nil {
 		return nil, fmt.Errorf("failed to find available port: %w", err)
 	}
+	defer listener.Close()
+	port := listener.Addr().(*net.TCPAddr).Port
 
 	redirectURI := fmt.Sprintf("http://localhost:%d/callback", port)
 
@@ -164,32 +257,56 @@ func (o *OAuthFlow) Login(ctx context.Context) (*OAuthResult, error) {
 		return nil, fmt.Errorf("failed to generate state: %w", err)
 	}
 
+	// PKCE (RFC 7636): the verifier never leaves this process until the
+	// token exchange, so an intercepted authorization code is useless.
+	codeVerifier, err := generateCodeVerifier()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PKCE code verifier: %w", err)
+	}
+
 	// Channel to receive the authorization code
 	codeChan := make(chan string, 1)
 	errChan := make(chan error, 1)
 
-	// Start local server
-	server := o.startCallbackServer(port, state, codeChan, errChan)
-	defer server.Shutdown(context.Background())
+	// Start local server. It is closed as soon as ctx is cancelled, and
+	// shut down gracefully when Login returns for any other reason.
+	server := o.startCallbackServer(ctx, listener, state, codeChan, errChan)
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), callbackShutdownTimeout)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
 
 	// Build authorization URL
-	authURL := o.buildAuthURL(redirectURI, state)
-
-	// Open browser
-	fmt.Println("Opening browser for authentication...")
-	fmt.Printf("If the browser doesn't open, please visit:\n%s\n\n", authURL)
-
-	if err := browser.OpenURL(authURL); err != nil {
-		fmt.Printf("Failed to open browser automatically: %v\n", err)
-	}
-
-	fmt.Println("Waiting for authentication...")
+	authURL := o.buildAuthURL(redirectURI, state, codeChallengeS256(codeVerifier))
+
+	if o.noBrowser {
+		fmt.Fprintf(os.Stderr, "Open this URL in a browser to authenticate:\n%s\n\n", authURL)
+		fmt.Fprintf(os.Stderr, "The redirect goes to %s, so finish in a browser on this machine or forward the port.\n\n", redirectURI)
+	} else {
+		// Open browser
+		fmt.Fprintln(os.Stderr, "Opening browser for authentication...")
+		fmt.Fprintf(os.Stderr, "If the browser doesn't open, please visit:\n%s\n\n", authURL)
+
+		// OpenURL waits for the opener command, so don't let it hold up a
+		// cancelled login
+		go func() {
+			if ctx.Err() != nil {
+				return
+			}
+			if err := browser.OpenURL(authURL); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to open browser automatically: %v\n", err)
+			}
+		}()
+	}
+
+	fmt.Fprintln(os.Stderr, "Waiting for authentication...")
 
 	// Wait for the callback or timeout
 	select {
 	case code := <-codeChan:
 		// Exchange the code for tokens
-		return o.exchangeCodeForTokens(ctx, code, redirectURI)
+		return o.exchangeCodeForTokens(ctx, code, redirectURI, codeVerifier)
 	case err := <-errChan:
 		return nil, err
 	case <-ctx.Done():
@@ -229,10 +346,10 @@ func (o *OAuthFlow) RefreshTokens(ctx context.Context, refreshToken string) (*OA
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := o.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("token refresh request failed: %w", err)
+		return nil, fmt.Errorf("token refresh request failed: %w", redact.Error(err))
 	}
 	defer resp.Body.Close()
 
@@ -283,10 +400,10 @@ func (o *OAuthFlow) Revoke(ctx context.Context, token, tokenTypeHint string) err
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := o.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("revoke request failed: %w", err)
+		return fmt.Errorf("revoke request failed: %w", redact.Error(err))
 	}
 	defer resp.Body.Close()
 
@@ -298,20 +415,35 @@ func (o *OAuthFlow) Revoke(ctx context.Context, token, tokenTypeHint string) err
 	return nil
 }
 
-// findAvailablePort finds an available port starting from the default
-func (o *OAuthFlow) findAvailablePort() (int, error) {
-	for port := o.callbackPort; port < o.callbackPort+10; port++ {
+// findAvailablePort listens on the first available port starting from the
+// configured callback port and returns the listener for the callback server.
+// Port 0 asks the OS for a free ephemeral port; an explicitly configured port
+// is tried alone.
+func (o *OAuthFlow) findAvailablePort() (net.Listener, error) {
+	if o.callbackPort == 0 {
+		return net.Listen("tcp", ":0")
+	}
+
+	scan := 10
+	if o.callbackPortFixed {
+		scan = 1
+	}
+	for port := o.callbackPort; port < o.callbackPort+scan; port++ {
 		listener, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 		if err == nil {
-			listener.Close()
-			return port, nil
+			return listener, nil
 		}
 	}
-	return 0, fmt.Errorf("no available port found")
+	if o.callbackPortFixed {
+		return nil, fmt.Errorf("port %d is not available", o.callbackPort)
+	}
+	return nil, fmt.Errorf("no available port found")
 }
 
-// startCallbackServer starts the local OAuth callback server
-func (o *OAuthFlow) startCallbackServer(port int, expectedState string, codeChan chan<- string, errChan chan<- error) *http.Server {
+// startCallbackServer serves the local OAuth callback on listener. The
+// server is closed, freeing the port, as soon as ctx is cancelled; an error
+// from serving is sent on errChan.
+func (o *OAuthFlow) startCallbackServer(ctx context.Context, listener net.Listener, expectedState string, codeChan chan<- string, errChan chan<- error) *http.Server {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("/callback", func(w http.ResponseWriter, r *http.Request) {
@@ -326,7 +458,7 @@ func (o *OAuthFlow) startCallbackServer(port int, expectedState string, codeChan
 		// Check for errors
 		if errMsg := r.URL.Query().Get("error"); errMsg != "" {
 			errDesc := r.URL.Query().Get("error_description")
-			errChan <- fmt.Errorf("OAuth error: %s - %s", errMsg, errDesc)
+			errChan <- fmt.Errorf("OAuth error: %s - %s", redact.String(errMsg), redact.String(errDesc))
 			w.Header().Set("Content-Type", "text/html")
 			fmt.Fprintf(w, successHTML("Authentication failed. You can close this window."))
 			return
@@ -349,35 +481,45 @@ func (o *OAuthFlow) startCallbackServer(port int, expectedState string, codeChan
 	})
 
 	server := &http.Server{
-		Addr:    fmt.Sprintf(":%d", port),
 		Handler: mux,
 	}
 
-	go server.ListenAndServe()
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			select {
+			case errChan <- fmt.Errorf("OAuth callback server on %s failed: %w", listener.Addr(), err):
+			default:
+			}
+		}
+	}()
+	context.AfterFunc(ctx, func() { server.Close() })
 
 	return server
 }
 
 // buildAuthURL builds the OAuth authorization URL
-func (o *OAuthFlow) buildAuthURL(redirectURI, state string) string {
+func (o *OAuthFlow) buildAuthURL(redirectURI, state, codeChallenge string) string {
 	params := url.Values{}
 	params.Set("client_id", o.clientID)
 	params.Set("redirect_uri", redirectURI)
 	params.Set("response_type", "code")
-	params.Set("scope", "full")
+	params.Set("scope", o.scope)
 	params.Set("state", state)
+	params.Set("code_challenge", codeChallenge)
+	params.Set("code_challenge_method", "S256")
 
 	return fmt.Sprintf("%s/oauth/authorize?%s", o.apiURL, params.Encode())
 }
 
 // exchangeCodeForTokens exchanges the authorization code for tokens
-func (o *OAuthFlow) exchangeCodeForTokens(ctx context.Context, code, redirectURI string) (*OAuthResult, error) {
+func (o *OAuthFlow) exchangeCodeForTokens(ctx context.Context, code, redirectURI, codeVerifier string) (*OAuthResult, error) {
 	tokenURL := o.apiURL + "/oauth/token"
 
 	data := url.Values{}
 	data.Set("grant_type", "authorization_code")
 	data.Set("code", code)
 	data.Set("redirect_uri", redirectURI)
+	data.Set("code_verifier", codeVerifier)
 	data.Set("client_id", o.clientID)
 	if o.clientSecret != "" {
 		data.Set("client_secret", o.clientSecret)
@@ -390,10 +532,10 @@ func (o *OAuthFlow) exchangeCodeForTokens(ctx context.Context, code, redirectURI
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set(kamuiClientTypeHeader, kamuiClientTypeCLI)
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := o.httpClient()
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("token exchange request failed: %w", err)
+		return nil, fmt.Errorf("token exchange request failed: %w", redact.Error(err))
 	}
 	defer resp.Body.Close()
 
@@ -423,6 +565,23 @@ func generateRandomState() (string, error) {
 	return hex.EncodeToString(bytes), nil
 }
 
+// generateCodeVerifier generates a PKCE code verifier (RFC 7636 §4.1):
+// 32 random bytes, base64url-encoded without padding (43 characters).
+func generateCodeVerifier() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// codeChallengeS256 derives the S256 code challenge for a verifier
+// (RFC 7636 §4.2): BASE64URL(SHA256(ASCII(code_verifier))).
+func codeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
 // successHTML returns the HTML page shown after successful authentication
 func successHTML(message string) string {
 	return fmt.Sprintf(`<!DOCTYPE html>
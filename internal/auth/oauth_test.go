@@ -0,0 +1,313 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCodeChallengeS256(t *testing.T) {
+	// Appendix B of RFC 7636.
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	if got := codeChallengeS256(verifier); got != want {
+		t.Errorf("codeChallengeS256(%q) = %q, want %q", verifier, got, want)
+	}
+}
+
+func TestGenerateCodeVerifier(t *testing.T) {
+	v1, err := generateCodeVerifier()
+	if err != nil {
+		t.Fatalf("generateCodeVerifier: %v", err)
+	}
+	v2, err := generateCodeVerifier()
+	if err != nil {
+		t.Fatalf("generateCodeVerifier: %v", err)
+	}
+	if v1 == v2 {
+		t.Error("two verifiers are identical, want random values")
+	}
+	// RFC 7636 §4.1: 43-128 characters from the unreserved set.
+	if len(v1) < 43 || len(v1) > 128 {
+		t.Errorf("verifier length = %d, want 43-128", len(v1))
+	}
+	if strings.ContainsAny(v1, "+/=") {
+		t.Errorf("verifier %q is not base64url without padding", v1)
+	}
+
+	sum := sha256.Sum256([]byte(v1))
+	if got, want := codeChallengeS256(v1), base64.RawURLEncoding.EncodeToString(sum[:]); got != want {
+		t.Errorf("codeChallengeS256 = %q, want %q", got, want)
+	}
+}
+
+func TestBuildAuthURL_IncludesPKCE(t *testing.T) {
+	o := NewOAuthFlow("https://api.test")
+	o.SetClientCredentials("client-1", "")
+
+	raw := o.buildAuthURL("http://localhost:9876/callback", "state-1", "challenge-1")
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("parse auth URL: %v", err)
+	}
+	q := u.Query()
+	if q.Get("code_challenge") != "challenge-1" {
+		t.Errorf("code_challenge = %q, want %q", q.Get("code_challenge"), "challenge-1")
+	}
+	if q.Get("code_challenge_method") != "S256" {
+		t.Errorf("code_challenge_method = %q, want S256", q.Get("code_challenge_method"))
+	}
+	if q.Get("state") != "state-1" {
+		t.Errorf("state = %q, want %q", q.Get("state"), "state-1")
+	}
+}
+
+func TestBuildAuthURL_Scope(t *testing.T) {
+	o := NewOAuthFlow("https://api.test")
+	if got := scopeParam(t, o); got != DefaultScope {
+		t.Errorf("default scope = %q, want %q", got, DefaultScope)
+	}
+
+	o.SetScope("projects:read apps:read")
+	if got := scopeParam(t, o); got != "projects:read apps:read" {
+		t.Errorf("scope = %q, want override", got)
+	}
+
+	o.SetScope("")
+	if got := scopeParam(t, o); got != DefaultScope {
+		t.Errorf("empty SetScope gave %q, want %q", got, DefaultScope)
+	}
+}
+
+func scopeParam(t *testing.T, o *OAuthFlow) string {
+	t.Helper()
+	u, err := url.Parse(o.buildAuthURL("http://localhost:9876/callback", "s", "c"))
+	if err != nil {
+		t.Fatalf("parse auth URL: %v", err)
+	}
+	return u.Query().Get("scope")
+}
+
+func TestValidateScope(t *testing.T) {
+	for _, scope := range []string{"full", "read", "projects:read apps:write"} {
+		if err := ValidateScope(scope); err != nil {
+			t.Errorf("ValidateScope(%q) = %v, want nil", scope, err)
+		}
+	}
+	for _, scope := range []string{"", "   ", `read"`, "read\\write", "lectureé"} {
+		if err := ValidateScope(scope); err == nil {
+			t.Errorf("ValidateScope(%q) = nil, want error", scope)
+		}
+	}
+}
+
+func TestFindAvailablePort_ExplicitPortUsedWhenFree(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	want := l.Addr().(*net.TCPAddr).Port
+	l.Close()
+
+	o := NewOAuthFlow("https://api.test")
+	o.SetCallbackPort(want)
+	listener, err := o.findAvailablePort()
+	if err != nil {
+		t.Fatalf("findAvailablePort: %v", err)
+	}
+	defer listener.Close()
+	if got := listener.Addr().(*net.TCPAddr).Port; got != want {
+		t.Errorf("findAvailablePort = %d, want %d", got, want)
+	}
+}
+
+func TestFindAvailablePort_ExplicitPortInUse(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer l.Close()
+
+	o := NewOAuthFlow("https://api.test")
+	o.SetCallbackPort(l.Addr().(*net.TCPAddr).Port)
+	if _, err := o.findAvailablePort(); err == nil {
+		t.Error("findAvailablePort = nil error, want error for busy explicit port")
+	}
+}
+
+func TestFindAvailablePort_ZeroPicksEphemeralPort(t *testing.T) {
+	o := NewOAuthFlow("https://api.test")
+	o.SetCallbackPort(0)
+	listener, err := o.findAvailablePort()
+	if err != nil {
+		t.Fatalf("findAvailablePort: %v", err)
+	}
+	defer listener.Close()
+	if listener.Addr().(*net.TCPAddr).Port == 0 {
+		t.Error("findAvailablePort = 0, want an OS-assigned port")
+	}
+}
+
+func TestOAuthFlow_SetTransportRoutesThroughProxy(t *testing.T) {
+	var proxied string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = r.URL.String()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"at","refresh_token":"rt","expires_in":3600}`))
+	}))
+	defer proxy.Close()
+
+	proxyURL, _ := url.Parse(proxy.URL)
+	flow := NewOAuthFlow("http://api.kamui.invalid")
+	flow.SetTransport(&http.Transport{Proxy: http.ProxyURL(proxyURL)})
+
+	result, err := flow.RefreshTokens(context.Background(), "old")
+	if err != nil {
+		t.Fatalf("RefreshTokens() error = %v", err)
+	}
+	if proxied != "http://api.kamui.invalid/oauth/token" {
+		t.Errorf("proxy saw %q, want the token endpoint", proxied)
+	}
+	if result.AccessToken != "at" {
+		t.Errorf("AccessToken = %q, want at", result.AccessToken)
+	}
+}
+
+func TestLogin_CancelReleasesCallbackPort(t *testing.T) {
+	ports := make(chan int, 1)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			RedirectURIs []string `json:"redirect_uris"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		if u, err := url.Parse(body.RedirectURIs[0]); err == nil {
+			var port int
+			fmt.Sscan(u.Port(), &port)
+			ports <- port
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"client_id":"cid"}`))
+	}))
+	defer api.Close()
+
+	flow := NewOAuthFlow(api.URL)
+	flow.SetCallbackPort(0)
+	flow.SetNoBrowser(true)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := flow.Login(ctx)
+		done <- err
+	}()
+
+	var port int
+	select {
+	case port = <-ports:
+	case err := <-done:
+		t.Fatalf("Login() returned before registering: %v", err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Login() did not register a client")
+	}
+
+	// Wait for the callback server to come up before cancelling
+	addr := fmt.Sprintf("127.0.0.1:%d", port)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		conn, err := net.Dial("tcp", addr)
+		if err == nil {
+			conn.Close()
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("callback server never listened on %s: %v", addr, err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	cancel()
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Login() error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Login() did not return after the context was cancelled")
+	}
+
+	l, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		t.Fatalf("callback port %d still in use after cancel: %v", port, err)
+	}
+	l.Close()
+}
+
+func TestStartCallbackServer_ServeErrorSentOnErrChan(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Close()
+
+	errChan := make(chan error, 1)
+	o := NewOAuthFlow("https://api.test")
+	o.startCallbackServer(context.Background(), l, "state", make(chan string, 1), errChan)
+
+	select {
+	case err := <-errChan:
+		if !strings.Contains(err.Error(), "OAuth callback server") {
+			t.Errorf("error = %v, want a serve error", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no error on errChan for a closed listener")
+	}
+}
+
+func TestLogin_HoldsCallbackPortUntilServing(t *testing.T) {
+	// The registration endpoint tries to bind the callback port, as another
+	// process could between picking the port and the server starting
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			RedirectURIs []string `json:"redirect_uris"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		u, _ := url.Parse(body.RedirectURIs[0])
+		if l, err := net.Listen("tcp", ":"+u.Port()); err == nil {
+			l.Close()
+			t.Errorf("callback port %s was free during registration", u.Port())
+		}
+		cancel()
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"client_id":"cid"}`))
+	}))
+	defer api.Close()
+
+	flow := NewOAuthFlow(api.URL)
+	flow.SetCallbackPort(0)
+	flow.SetNoBrowser(true)
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := flow.Login(ctx)
+		done <- err
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Login() did not return after the context was cancelled")
+	}
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// canSelectApp reports whether an omitted app argument may be picked from a
+// list. The prompt reads stdin and renders on stderr, so both must be
+// terminals; it is a variable so tests can run either way.
+var canSelectApp = func() bool {
+	return isStdinTTY() && isStderrTTY()
+}
+
+// appArg validates the positional arguments of commands that take one app
+// name or ID. The argument may be omitted when it can be selected
+// interactively instead.
+func appArg(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 && canSelectApp() {
+		return nil
+	}
+	return cobra.ExactArgs(1)(cmd, args)
+}
+
+// resolveAppArg resolves the app named by args, or lets the user select
+// one from projects when args is empty
+func resolveAppArg(ctx context.Context, projects []iface.Project, appService iface.AppService, args []string) (*appMatch, error) {
+	if len(args) > 0 {
+		return resolveApp(ctx, projects, appService, args[0])
+	}
+	return selectApp(projects)
+}
+
+// selectApp asks the user to pick one app of projects. Options are labeled
+// with their project and ID, and the answer maps back by position, so apps
+// sharing a name are never confused.
+func selectApp(projects []iface.Project) (*appMatch, error) {
+	matches := matchApps(projects, func(string) bool { return true })
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: there are no apps to select\n\nCreate one with: kamui apps create", errAppNotFound)
+	}
+
+	options := make([]string, len(matches))
+	for i, m := range matches {
+		options[i] = fmt.Sprintf("%s / %s (%s)", m.ProjectName, m.AppName, m.AppID)
+	}
+
+	var selected int
+	if err := ask(&survey.Select{
+		Message: "Select app:",
+		Options: options,
+	}, &selected); err != nil {
+		return nil, err
+	}
+	return &matches[selected], nil
+}
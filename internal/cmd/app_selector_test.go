@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+func TestAppCommands_RequireArgumentWithoutTTY(t *testing.T) {
+	oldCanSelectApp := canSelectApp
+	canSelectApp = func() bool { return false }
+	t.Cleanup(func() { canSelectApp = oldCanSelectApp })
+
+	commands := [][]string{
+		{"apps", "get"},
+		{"apps", "redeploy"},
+		{"apps", "rollback"},
+		{"apps", "deployments"},
+		{"apps", "open"},
+		{"apps", "watch"},
+		{"apps", "delete"},
+		{"apps", "deploy-static", "--from-dir", "dist"},
+		{"apps", "transfer", "--to", "other"},
+		{"apps", "domain", "show"},
+		{"apps", "domain", "remove"},
+	}
+
+	for _, args := range commands {
+		t.Run(strings.Join(args[:2], " "), func(t *testing.T) {
+			listed := false
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					listed = true
+					return nil, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, &MockAppService{}))
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if err == nil || !strings.Contains(err.Error(), "accepts 1 arg(s), received 0") {
+				t.Fatalf("Execute() error = %v, want the missing argument reported", err)
+			}
+			if listed {
+				t.Error("projects were listed although the argument is missing")
+			}
+		})
+	}
+}
+
+func TestAppArg(t *testing.T) {
+	oldCanSelectApp := canSelectApp
+	t.Cleanup(func() { canSelectApp = oldCanSelectApp })
+
+	tests := []struct {
+		name        string
+		interactive bool
+		args        []string
+		wantErr     bool
+	}{
+		{name: "argument given", args: []string{"web"}},
+		{name: "omitted in a terminal", interactive: true},
+		{name: "omitted without a terminal", wantErr: true},
+		{name: "too many arguments", interactive: true, args: []string{"web", "api"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			canSelectApp = func() bool { return tt.interactive }
+			err := appArg(&cobra.Command{}, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("appArg() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelectApp_NoApps(t *testing.T) {
+	_, err := selectApp([]iface.Project{{ID: "proj-1", Name: "empty"}})
+	if !errors.Is(err, errAppNotFound) {
+		t.Errorf("selectApp() error = %v, want errAppNotFound", err)
+	}
+}
@@ -0,0 +1,456 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+// Outcomes of applying a single resource
+const (
+	applyCreated   = "created"
+	applyUpdated   = "updated"
+	applyUnchanged = "unchanged"
+	applyFailed    = "failed"
+	applySkipped   = "skipped"
+)
+
+// defaultApplyParallelism is how many apps apply works on at once unless
+// --parallelism is given
+const defaultApplyParallelism = 4
+
+// ApplyCommand represents the apply command
+type ApplyCommand struct {
+	root *RootCommand
+	cmd  *cobra.Command
+
+	file            string
+	parallelism     int
+	continueOnError bool
+}
+
+// NewApplyCommand creates a new apply command
+func NewApplyCommand(root *RootCommand) *ApplyCommand {
+	a := &ApplyCommand{
+		root: root,
+	}
+
+	a.cmd = &cobra.Command{
+		Use:   "apply",
+		Short: "Create or update a project and its apps from a manifest",
+		Long: `Reconcile a project and its apps with a YAML or JSON manifest.
+
+The project is matched by name and created if it does not exist; a changed
+description is updated in place. Apps are matched by name within the
+project and created if missing. An existing app whose replicas, app_spec,
+env, or secrets differ from the manifest is updated to match; env and
+secrets are replaced as a whole, so variables missing from the manifest are
+removed. Re-running an unchanged manifest reports every resource as
+unchanged.
+
+An app's language, repository, branch, and session affinity cannot be
+changed after it is created, so an app where they differ from the manifest
+is reported as failed. The same applies to a project whose plan differs.
+The command exits non-zero if any resource failed.
+
+The project is applied first, since every app depends on it; if it fails,
+no app is applied. Apps are then applied up to --parallelism at a time.
+After the first app fails, apps not yet started are skipped, unless
+--continue-on-error is given to apply every app and report all failures.
+
+Manifest format (apps use the same keys as 'kamui apps create --file'):
+
+  project:
+    name: my-project
+    description: Demo project
+    plan_type: free
+  apps:
+    - name: web
+      language: go
+      deploy_type: docker_hub
+      start_command: ./server
+      env:
+        LOG_LEVEL: debug
+
+Examples:
+  kamui apply -f kamui.yaml
+  kamui apply -f kamui.yaml --parallelism 8 --continue-on-error
+  cat kamui.yaml | kamui apply -f - -o json`,
+		Args: cobra.NoArgs,
+		RunE: a.Run,
+	}
+
+	a.cmd.Flags().StringVarP(&a.file, "file", "f", "", `Manifest file to apply ("-" reads stdin)`)
+	a.cmd.MarkFlagRequired("file")
+	a.cmd.Flags().IntVar(&a.parallelism, "parallelism", defaultApplyParallelism, "Number of apps to apply at once")
+	a.cmd.Flags().BoolVar(&a.continueOnError, "continue-on-error", false, "Keep applying apps after one fails")
+
+	return a
+}
+
+// Command returns the underlying cobra command
+func (a *ApplyCommand) Command() *cobra.Command {
+	return a.cmd
+}
+
+// applyResult is the outcome of applying one resource
+type applyResult struct {
+	Kind   string `json:"kind"`
+	Name   string `json:"name"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// applySummary counts apply results by status
+type applySummary struct {
+	Created   int `json:"created"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+	Failed    int `json:"failed"`
+	Skipped   int `json:"skipped"`
+}
+
+// applyReport is the JSON output of apply
+type applyReport struct {
+	Resources []applyResult `json:"resources"`
+	Summary   applySummary  `json:"summary"`
+}
+
+// Run executes the apply command
+func (a *ApplyCommand) Run(cmd *cobra.Command, args []string) error {
+	if a.parallelism < 1 {
+		return &usageError{err: fmt.Errorf("--parallelism must be at least 1, got %d", a.parallelism)}
+	}
+
+	ctx := cmd.Context()
+
+	data, err := readFileOrStdin(a.file, cmd.InOrStdin())
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+	m, err := parseManifest(data)
+	if err != nil {
+		return fmt.Errorf("invalid manifest %s: %w", sourceName(a.file), err)
+	}
+
+	projectService := a.root.Container().ProjectService()
+	appService := a.root.Container().AppService()
+
+	plans := availablePlans(ctx, projectService)
+	regions := availableRegions(ctx, projectService)
+	if err := m.Project.validateOffered(plans, regions); err != nil {
+		return fmt.Errorf("invalid manifest %s: %w", sourceName(a.file), err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Applying %s...\n", sourceName(a.file))
+
+	var report applyReport
+	project, projectResult := applyProject(ctx, projectService, &m.Project, plans, regions)
+	report.Resources = append(report.Resources, projectResult)
+
+	if project == nil {
+		for _, spec := range m.Apps {
+			report.Resources = append(report.Resources, applyResult{
+				Kind:   "app",
+				Name:   spec.Name,
+				Status: applySkipped,
+				Detail: "the project could not be applied",
+			})
+		}
+	} else {
+		report.Resources = append(report.Resources, a.applyApps(ctx, appService, project, m.Apps)...)
+	}
+
+	for _, r := range report.Resources {
+		switch r.Status {
+		case applyCreated:
+			report.Summary.Created++
+		case applyUpdated:
+			report.Summary.Updated++
+		case applyUnchanged:
+			report.Summary.Unchanged++
+		case applyFailed:
+			report.Summary.Failed++
+		case applySkipped:
+			report.Summary.Skipped++
+		}
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, len(report.Resources))
+		for i, r := range report.Resources {
+			rows[i] = []string{r.Kind, r.Name, r.Status, r.Detail}
+		}
+		printTable(os.Stdout, "", []string{"KIND", "NAME", "STATUS", "DETAIL"}, rows)
+		s := report.Summary
+		fmt.Printf("\n%d created, %d updated, %d unchanged, %d failed, %d skipped\n", s.Created, s.Updated, s.Unchanged, s.Failed, s.Skipped)
+	}
+
+	if report.Summary.Failed > 0 {
+		return fmt.Errorf("%d of %d resources failed to apply", report.Summary.Failed, len(report.Resources))
+	}
+	return nil
+}
+
+// applyApps applies specs to project with a bounded worker pool. The
+// returned results are index-aligned with specs. Unless --continue-on-error
+// is set, apps that have not started when one fails are skipped; apps
+// already in flight are left to finish.
+func (a *ApplyCommand) applyApps(ctx context.Context, appService iface.AppService, project *iface.Project, specs []appSpec) []applyResult {
+	results := make([]applyResult, len(specs))
+
+	var failed atomic.Bool
+	var g errgroup.Group
+	g.SetLimit(a.parallelism)
+	for i := range specs {
+		i := i
+		g.Go(func() error {
+			spec := &specs[i]
+			if failed.Load() && !a.continueOnError {
+				results[i] = applyResult{
+					Kind:   "app",
+					Name:   spec.Name,
+					Status: applySkipped,
+					Detail: "an earlier app failed (use --continue-on-error to apply the rest)",
+				}
+				return nil
+			}
+			results[i] = applyApp(ctx, appService, project, spec)
+			if results[i].Status == applyFailed {
+				failed.Store(true)
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return results
+}
+
+// applyProject finds the manifest's project by name, creating it when
+// missing and updating its description when it changed. It returns the
+// project, or nil when it could not be created or found. A new project
+// without a plan or region gets the same defaults as projects create.
+func applyProject(ctx context.Context, projectService iface.ProjectService, want *manifestProject, plans []iface.Plan, regions []iface.Region) (*iface.Project, applyResult) {
+	result := applyResult{Kind: "project", Name: want.Name, Status: applyFailed}
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		result.Detail = fmt.Sprintf("failed to fetch projects: %v", err)
+		return nil, result
+	}
+	project, err := findProjectByName(projects, want.Name)
+	if err != nil {
+		result.Detail = err.Error()
+		return nil, result
+	}
+
+	if project == nil {
+		input := &iface.CreateProjectInput{
+			Name:        want.Name,
+			Description: want.Description,
+			PlanType:    want.PlanType,
+			Region:      want.Region,
+		}
+		if input.PlanType == "" {
+			input.PlanType = defaultPlan(plans)
+		}
+		if input.Region == "" {
+			input.Region = defaultRegion(regions)
+		}
+		if err := projectService.CreateProject(ctx, input); err != nil {
+			result.Detail = err.Error()
+			return nil, result
+		}
+
+		// The create endpoint does not return the project, so look it up
+		projects, err := projectService.ListProjects(ctx)
+		if err != nil {
+			result.Detail = fmt.Sprintf("created, but failed to fetch it: %v", err)
+			return nil, result
+		}
+		if project, err = findProjectByName(projects, want.Name); err != nil || project == nil {
+			result.Detail = "created, but it was not found afterwards"
+			return nil, result
+		}
+		result.ID = project.ID
+		result.Status = applyCreated
+		return project, result
+	}
+
+	result.ID = project.ID
+	if want.PlanType != "" && project.PlanType != "" && project.PlanType != want.PlanType {
+		result.Detail = fmt.Sprintf("plan_type is %q, manifest wants %q; apply cannot change the plan", project.PlanType, want.PlanType)
+		return project, result
+	}
+	if want.Description != "" && project.Description != want.Description {
+		description := want.Description
+		if _, err := projectService.UpdateProject(ctx, project.ID, &iface.UpdateProjectInput{Description: &description}); err != nil {
+			result.Detail = err.Error()
+			return project, result
+		}
+		project.Description = description
+		result.Status = applyUpdated
+		result.Detail = "description"
+		return project, result
+	}
+
+	result.Status = applyUnchanged
+	return project, result
+}
+
+// findProjectByName returns the project with exactly the given name, nil if
+// there is none, or an error if the name is ambiguous
+func findProjectByName(projects []iface.Project, name string) (*iface.Project, error) {
+	var found *iface.Project
+	for i := range projects {
+		if projects[i].Name != name {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("project name %q is ambiguous; rename one of the projects", name)
+		}
+		found = &projects[i]
+	}
+	return found, nil
+}
+
+// applyApp creates the app described by spec in project if it does not
+// exist yet; an existing app is compared against the spec and updated
+// where it differs
+func applyApp(ctx context.Context, appService iface.AppService, project *iface.Project, spec *appSpec) applyResult {
+	result := applyResult{Kind: "app", Name: spec.Name, Status: applyFailed}
+	input := spec.input(*project)
+
+	for _, app := range project.Apps {
+		if app.Name != spec.Name {
+			continue
+		}
+		result.ID = app.ID
+		detail, err := appService.GetApp(ctx, app.ID)
+		if err != nil {
+			result.Detail = fmt.Sprintf("failed to fetch app: %v", err)
+			return result
+		}
+		if diffs := appDrift(input, detail); len(diffs) > 0 {
+			result.Detail = "differs from manifest (" + strings.Join(diffs, ", ") + "); these settings cannot be changed after the app is created"
+			return result
+		}
+		env, err := appService.GetAppEnv(ctx, app.ID)
+		if err != nil {
+			result.Detail = err.Error()
+			return result
+		}
+
+		update, changes := appUpdate(input, detail, env)
+		if len(changes) == 0 {
+			result.Status = applyUnchanged
+			return result
+		}
+		if _, err := appService.UpdateApp(ctx, app.ID, update); err != nil {
+			result.Detail = err.Error()
+			return result
+		}
+		result.Status = applyUpdated
+		result.Detail = strings.Join(changes, "; ")
+		return result
+	}
+
+	created, err := appService.CreateApp(ctx, input)
+	if err != nil {
+		result.Detail = err.Error()
+		return result
+	}
+	result.ID = created.ID
+	result.Status = applyCreated
+	return result
+}
+
+// appDrift lists the settings of an existing app that differ from input
+// and that cannot be updated. Only settings the API reports back are
+// compared; empty values reported by the API are treated as unknown.
+func appDrift(input *iface.CreateAppInput, detail *iface.AppDetail) []string {
+	var diffs []string
+	if detail.LanguageType != "" && detail.LanguageType != input.Language {
+		diffs = append(diffs, fmt.Sprintf("language: %s → %s", detail.LanguageType, input.Language))
+	}
+	if input.DeployType == "github" {
+		repo := input.Owner + "/" + input.Repository
+		if detail.GithubOrgRepo != "" && detail.GithubOrgRepo != repo {
+			diffs = append(diffs, fmt.Sprintf("repo: %s → %s", detail.GithubOrgRepo, repo))
+		}
+		if detail.GithubBranch != "" && detail.GithubBranch != input.Branch {
+			diffs = append(diffs, fmt.Sprintf("branch: %s → %s", detail.GithubBranch, input.Branch))
+		}
+	}
+	if detail.SessionAffinity != input.SessionAffinity {
+		diffs = append(diffs, fmt.Sprintf("session_affinity: %t → %t", detail.SessionAffinity, input.SessionAffinity))
+	}
+	return diffs
+}
+
+// appUpdate builds the update that brings an existing app in line with
+// input and describes each change. Replicas and app_spec the API does not
+// report are left alone; env is compared by key, value and secrecy.
+func appUpdate(input *iface.CreateAppInput, detail *iface.AppDetail, env *iface.AppEnv) (*iface.UpdateAppInput, []string) {
+	update := &iface.UpdateAppInput{}
+	var changes []string
+	if detail.Replicas != 0 && detail.Replicas != input.Replicas {
+		replicas := input.Replicas
+		update.Replicas = &replicas
+		changes = append(changes, fmt.Sprintf("replicas: %d → %d", detail.Replicas, replicas))
+	}
+	if detail.AppSpec != "" && detail.AppSpec != input.AppSpecType {
+		appSpec := input.AppSpecType
+		update.AppSpecType = &appSpec
+		changes = append(changes, fmt.Sprintf("app_spec: %s → %s", detail.AppSpec, appSpec))
+	}
+	if keys := envChanges(env, input.EnvVars, input.SecretKeys); len(keys) > 0 {
+		envVars := input.EnvVars
+		if envVars == nil {
+			envVars = map[string]string{}
+		}
+		secretKeys := input.SecretKeys
+		update.EnvVars = &envVars
+		update.SecretKeys = &secretKeys
+		changes = append(changes, "env: "+strings.Join(keys, ", "))
+	}
+	return update, changes
+}
+
+// envChanges lists the variables that differ between env and want as
+// +KEY (added), -KEY (removed) or ~KEY (value or secrecy changed). Values
+// are never included, since they may be secrets.
+func envChanges(env *iface.AppEnv, want map[string]string, wantSecrets []string) []string {
+	var changes []string
+	for _, key := range sortedEnvKeys(want) {
+		have, ok := env.EnvVars[key]
+		switch {
+		case !ok:
+			changes = append(changes, "+"+key)
+		case have != want[key] || env.IsSecret(key) != slices.Contains(wantSecrets, key):
+			changes = append(changes, "~"+key)
+		}
+	}
+	for _, key := range sortedEnvKeys(env.EnvVars) {
+		if _, ok := want[key]; !ok {
+			changes = append(changes, "-"+key)
+		}
+	}
+	return changes
+}
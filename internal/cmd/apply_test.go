@@ -0,0 +1,541 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+const testManifest = `project:
+  name: my-project
+  description: Demo
+apps:
+  - name: web
+    language: go
+    owner: my-org
+    owner_type: Organization
+    repo: web
+    start_command: ./server
+  - name: worker
+    language: python
+    deploy_type: docker_hub
+    start_command: python worker.py
+`
+
+func TestApplyCommand_Run(t *testing.T) {
+	existing := iface.Project{
+		ID:          "proj-1",
+		Name:        "my-project",
+		Description: "Demo",
+		PlanType:    "free",
+		Apps:        []iface.App{{ID: "app-1", Name: "web"}, {ID: "app-2", Name: "worker"}},
+	}
+	webDetail := iface.AppDetail{ID: "app-1", LanguageType: "go", GithubOrgRepo: "my-org/web", GithubBranch: "main"}
+
+	tests := []struct {
+		name        string
+		args        []string
+		projects    []iface.Project
+		webDetail   iface.AppDetail
+		webEnv      *iface.AppEnv
+		wantStatus  map[string]string
+		wantDetail  string
+		wantCreated []string
+		wantUpdate  bool
+		wantErrMsg  string
+	}{
+		{
+			name:        "creates missing project and apps",
+			wantStatus:  map[string]string{"my-project": applyCreated, "web": applyCreated, "worker": applyCreated},
+			wantCreated: []string{"web", "worker"},
+		},
+		{
+			name:       "unchanged on re-run",
+			projects:   []iface.Project{existing},
+			webDetail:  webDetail,
+			wantStatus: map[string]string{"my-project": applyUnchanged, "web": applyUnchanged, "worker": applyUnchanged},
+		},
+		{
+			name: "updates description and creates new app",
+			projects: []iface.Project{{
+				ID: "proj-1", Name: "my-project", Description: "Old",
+				Apps: []iface.App{{ID: "app-1", Name: "web"}},
+			}},
+			webDetail:   webDetail,
+			wantStatus:  map[string]string{"my-project": applyUpdated, "web": applyUnchanged, "worker": applyCreated},
+			wantCreated: []string{"worker"},
+			wantUpdate:  true,
+		},
+		{
+			name:     "updates replicas, app_spec and env of an existing app",
+			projects: []iface.Project{existing},
+			webDetail: iface.AppDetail{
+				ID: "app-1", LanguageType: "go", GithubOrgRepo: "my-org/web", GithubBranch: "main",
+				Replicas: 3, AppSpec: "small",
+			},
+			webEnv: &iface.AppEnv{
+				EnvVars:    map[string]string{"OLD": "x", "DB_PASSWORD": "hunter2"},
+				SecretKeys: []string{"DB_PASSWORD"},
+			},
+			wantStatus: map[string]string{"my-project": applyUnchanged, "web": applyUpdated, "worker": applyUnchanged},
+			wantDetail: "replicas: 3 → 1; app_spec: small → nano; env: -DB_PASSWORD, -OLD",
+		},
+		{
+			name:       "drifted app fails",
+			args:       []string{"--continue-on-error"},
+			projects:   []iface.Project{existing},
+			webDetail:  iface.AppDetail{ID: "app-1", LanguageType: "go", GithubOrgRepo: "my-org/web", GithubBranch: "dev"},
+			wantStatus: map[string]string{"my-project": applyUnchanged, "web": applyFailed, "worker": applyUnchanged},
+			wantErrMsg: "1 of 3 resources failed to apply",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projects := append([]iface.Project(nil), tt.projects...)
+			var mu sync.Mutex
+			var created []string
+			updated := false
+			var appUpdate *iface.UpdateAppInput
+
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return projects, nil
+				},
+				CreateProjectFunc: func(ctx context.Context, input *iface.CreateProjectInput) error {
+					if input.PlanType != "free" || input.Region != "tokyo" {
+						t.Errorf("CreateProject input = %+v, want free/tokyo defaults", input)
+					}
+					projects = append(projects, iface.Project{ID: "proj-new", Name: input.Name})
+					return nil
+				},
+				UpdateProjectFunc: func(ctx context.Context, id string, input *iface.UpdateProjectInput) (*iface.Project, error) {
+					updated = true
+					return &iface.Project{ID: id}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					if appID == "app-1" {
+						detail := tt.webDetail
+						return &detail, nil
+					}
+					return &iface.AppDetail{ID: appID, LanguageType: "python"}, nil
+				},
+				GetAppEnvFunc: func(ctx context.Context, appID string) (*iface.AppEnv, error) {
+					if appID == "app-1" && tt.webEnv != nil {
+						return tt.webEnv, nil
+					}
+					return &iface.AppEnv{EnvVars: map[string]string{}}, nil
+				},
+				UpdateAppFunc: func(ctx context.Context, appID string, input *iface.UpdateAppInput) (*iface.AppDetail, error) {
+					if appID != "app-1" {
+						t.Errorf("UpdateApp called for %s", appID)
+					}
+					appUpdate = input
+					return &iface.AppDetail{ID: appID}, nil
+				},
+				CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+					mu.Lock()
+					created = append(created, input.AppName)
+					mu.Unlock()
+					return &iface.CreateAppOutput{ID: "new-" + input.AppName, Name: input.AppName}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetIn(strings.NewReader(testManifest))
+			root.Command().SetArgs(append([]string{"apply", "-f", "-", "-o", "json"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Errorf("error = %v, want %q", err, tt.wantErrMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			var report applyReport
+			if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+				t.Fatalf("stdout is not valid JSON: %v\n%s", err, buf.String())
+			}
+			if len(report.Resources) != len(tt.wantStatus) {
+				t.Fatalf("got %d resources, want %d: %+v", len(report.Resources), len(tt.wantStatus), report.Resources)
+			}
+			for _, res := range report.Resources {
+				if want := tt.wantStatus[res.Name]; res.Status != want {
+					t.Errorf("%s %q status = %q (%s), want %q", res.Kind, res.Name, res.Status, res.Detail, want)
+				}
+				if res.Name == "web" && tt.wantDetail != "" && res.Detail != tt.wantDetail {
+					t.Errorf("web detail = %q, want %q", res.Detail, tt.wantDetail)
+				}
+			}
+			if (appUpdate != nil) != (tt.wantDetail != "") {
+				t.Fatalf("UpdateApp input = %+v, want update %v", appUpdate, tt.wantDetail != "")
+			}
+			if appUpdate != nil {
+				if appUpdate.Replicas == nil || *appUpdate.Replicas != 1 || appUpdate.AppSpecType == nil || *appUpdate.AppSpecType != "nano" {
+					t.Errorf("UpdateApp replicas/app_spec = %v/%v, want 1/nano", appUpdate.Replicas, appUpdate.AppSpecType)
+				}
+				if appUpdate.EnvVars == nil || len(*appUpdate.EnvVars) != 0 {
+					t.Errorf("UpdateApp env = %v, want the manifest's empty env", appUpdate.EnvVars)
+				}
+			}
+			sort.Strings(created)
+			if strings.Join(created, ",") != strings.Join(tt.wantCreated, ",") {
+				t.Errorf("created apps = %v, want %v", created, tt.wantCreated)
+			}
+			if updated != tt.wantUpdate {
+				t.Errorf("UpdateProject called = %v, want %v", updated, tt.wantUpdate)
+			}
+		})
+	}
+}
+
+func TestApplyCommand_Failures(t *testing.T) {
+	var manifest strings.Builder
+	manifest.WriteString("project:\n  name: my-project\napps:\n")
+	for _, name := range []string{"a", "b", "c", "d", "e", "f"} {
+		fmt.Fprintf(&manifest, "  - {name: %s, language: go, deploy_type: docker_hub, start_command: ./server}\n", name)
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		failApps      map[string]bool
+		projectErr    error
+		maxInFlight   int
+		wantStatus    map[string]string
+		wantErrMsg    string
+		wantUsageErr  bool
+		wantSummary   applySummary
+		wantNoCreates bool
+	}{
+		{
+			name:        "all succeed in parallel",
+			args:        []string{"--parallelism", "3"},
+			maxInFlight: 3,
+			wantStatus:  map[string]string{"a": applyCreated, "b": applyCreated, "c": applyCreated, "d": applyCreated, "e": applyCreated, "f": applyCreated},
+			wantSummary: applySummary{Unchanged: 1, Created: 6},
+		},
+		{
+			name:        "continue on error reports every failure",
+			args:        []string{"--parallelism", "2", "--continue-on-error"},
+			failApps:    map[string]bool{"b": true, "e": true},
+			maxInFlight: 2,
+			wantStatus:  map[string]string{"a": applyCreated, "b": applyFailed, "c": applyCreated, "d": applyCreated, "e": applyFailed, "f": applyCreated},
+			wantErrMsg:  "2 of 7 resources failed to apply",
+			wantSummary: applySummary{Unchanged: 1, Created: 4, Failed: 2},
+		},
+		{
+			name:        "stops after the first failure",
+			args:        []string{"--parallelism", "1"},
+			failApps:    map[string]bool{"b": true, "e": true},
+			maxInFlight: 1,
+			wantStatus:  map[string]string{"a": applyCreated, "b": applyFailed, "c": applySkipped, "d": applySkipped, "e": applySkipped, "f": applySkipped},
+			wantErrMsg:  "1 of 7 resources failed to apply",
+			wantSummary: applySummary{Unchanged: 1, Created: 1, Failed: 1, Skipped: 4},
+		},
+		{
+			name:          "project failure skips every app",
+			projectErr:    errors.New("boom"),
+			wantStatus:    map[string]string{"a": applySkipped, "b": applySkipped, "c": applySkipped, "d": applySkipped, "e": applySkipped, "f": applySkipped},
+			wantErrMsg:    "1 of 7 resources failed to apply",
+			wantSummary:   applySummary{Failed: 1, Skipped: 6},
+			wantNoCreates: true,
+		},
+		{
+			name:          "invalid parallelism",
+			args:          []string{"--parallelism", "0"},
+			wantErrMsg:    "--parallelism must be at least 1",
+			wantUsageErr:  true,
+			wantNoCreates: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			inFlight, maxInFlight, creates := 0, 0, 0
+
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					if tt.projectErr != nil {
+						return nil, tt.projectErr
+					}
+					return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+					mu.Lock()
+					creates++
+					inFlight++
+					maxInFlight = max(maxInFlight, inFlight)
+					mu.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					mu.Lock()
+					inFlight--
+					mu.Unlock()
+					if tt.failApps[input.AppName] {
+						return nil, errors.New("quota exceeded")
+					}
+					return &iface.CreateAppOutput{ID: "new-" + input.AppName, Name: input.AppName}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetIn(strings.NewReader(manifest.String()))
+			root.Command().SetArgs(append([]string{"apply", "-f", "-", "-o", "json"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErrMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if tt.wantNoCreates && creates > 0 {
+				t.Errorf("CreateApp called %d times, want none", creates)
+			}
+			if tt.wantUsageErr {
+				if got := ExitCode(err); got != ExitUsage {
+					t.Errorf("ExitCode() = %d, want %d", got, ExitUsage)
+				}
+				return
+			}
+			if tt.maxInFlight > 0 && maxInFlight != tt.maxInFlight {
+				t.Errorf("max concurrent creates = %d, want %d", maxInFlight, tt.maxInFlight)
+			}
+
+			var report applyReport
+			if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+				t.Fatalf("stdout is not valid JSON: %v\n%s", err, buf.String())
+			}
+			if report.Summary != tt.wantSummary {
+				t.Errorf("summary = %+v, want %+v", report.Summary, tt.wantSummary)
+			}
+			// Results keep the manifest's order whatever order the apps finish in
+			var names []string
+			for _, res := range report.Resources[1:] {
+				names = append(names, res.Name)
+				if want := tt.wantStatus[res.Name]; res.Status != want {
+					t.Errorf("app %q status = %q (%s), want %q", res.Name, res.Status, res.Detail, want)
+				}
+			}
+			if got := strings.Join(names, ","); got != "a,b,c,d,e,f" {
+				t.Errorf("app order = %s, want a,b,c,d,e,f", got)
+			}
+		})
+	}
+}
+
+func TestParseManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		wantErrs []string
+	}{
+		{name: "valid", doc: testManifest},
+		{
+			name:     "missing project name",
+			doc:      "project:\n  description: x\n",
+			wantErrs: []string{`line 1: field "project.name": is required`},
+		},
+		{
+			name: "app errors are labelled",
+			doc:  "project:\n  name: p\napps:\n  - name: web\n    project: other\n    language: ruby\n    deploy_type: docker_hub\n    start_command: x\n",
+			wantErrs: []string{
+				`apps[0] (web): line 5: field "project": is not allowed`,
+				`apps[0] (web): line 6: field "language": must be one of node, go, python`,
+			},
+		},
+		{
+			name:     "duplicate app names",
+			doc:      "project:\n  name: p\napps:\n  - {name: web, language: go, deploy_type: docker_hub, start_command: x}\n  - {name: web, language: go, deploy_type: docker_hub, start_command: x}\n",
+			wantErrs: []string{`apps[1] (web): line 5: field "name": duplicate app name`},
+		},
+		{
+			name:     "unknown field",
+			doc:      "project:\n  name: p\n  owner: me\n",
+			wantErrs: []string{"line 3: field owner not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseManifest([]byte(tt.doc))
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("parseManifest() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("parseManifest() error = nil, want error")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error should contain %q, got: %v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestApplyCommand_OfferedPlansAndRegions(t *testing.T) {
+	tests := []struct {
+		name       string
+		project    string
+		fetchErr   error
+		wantPlan   string
+		wantRegion string
+		wantErrMsg string
+	}{
+		{
+			name:       "server plan and region",
+			project:    "  plan_type: Team\n  region: osaka\n",
+			wantPlan:   "team",
+			wantRegion: "osaka",
+		},
+		{
+			name:       "defaults without free",
+			wantPlan:   "pro",
+			wantRegion: "tokyo",
+		},
+		{
+			name:       "plan the server does not offer",
+			project:    "  plan_type: free\n",
+			wantErrMsg: `line 3: field "project.plan_type": must be one of: pro, team (got "free")`,
+		},
+		{
+			name:       "region the server does not offer",
+			project:    "  region: frankfurt\n",
+			wantErrMsg: `line 3: field "project.region": must be one of: osaka, tokyo`,
+		},
+		{
+			name:       "fallback lists when the endpoints are unavailable",
+			project:    "  plan_type: pro\n",
+			fetchErr:   errors.New("not found"),
+			wantPlan:   "pro",
+			wantRegion: "tokyo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotInput *iface.CreateProjectInput
+			var projects []iface.Project
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return projects, nil
+				},
+				GetPlansFunc: func(ctx context.Context) ([]iface.Plan, error) {
+					if tt.fetchErr != nil {
+						return nil, tt.fetchErr
+					}
+					return []iface.Plan{{ID: "pro", Name: "Pro"}, {ID: "team", Name: "Team"}}, nil
+				},
+				GetRegionsFunc: func(ctx context.Context) ([]iface.Region, error) {
+					if tt.fetchErr != nil {
+						return nil, tt.fetchErr
+					}
+					return []iface.Region{{ID: "osaka", Name: "Osaka"}, {ID: "tokyo", Name: "Tokyo"}}, nil
+				},
+				CreateProjectFunc: func(ctx context.Context, input *iface.CreateProjectInput) error {
+					gotInput = input
+					projects = append(projects, iface.Project{ID: "proj-new", Name: input.Name})
+					return nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, &MockAppService{}))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetIn(strings.NewReader("project:\n  name: my-project\n" + tt.project))
+			root.Command().SetArgs([]string{"apply", "-f", "-", "-o", "json"})
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if gotInput != nil {
+					t.Error("CreateProject called for an invalid manifest")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotInput == nil || gotInput.PlanType != tt.wantPlan || gotInput.Region != tt.wantRegion {
+				t.Errorf("CreateProject input = %+v, want plan %s region %s", gotInput, tt.wantPlan, tt.wantRegion)
+			}
+		})
+	}
+}
+
+func TestEnvChanges(t *testing.T) {
+	env := &iface.AppEnv{
+		EnvVars:    map[string]string{"KEEP": "1", "CHANGE": "old", "DROP": "x", "TOKEN": "t"},
+		SecretKeys: []string{"TOKEN"},
+	}
+	want := map[string]string{"KEEP": "1", "CHANGE": "new", "ADD": "y", "TOKEN": "t"}
+
+	got := envChanges(env, want, nil)
+	if s := strings.Join(got, ","); s != "+ADD,~CHANGE,~TOKEN,-DROP" {
+		t.Errorf("envChanges() = %s, want +ADD,~CHANGE,~TOKEN,-DROP", s)
+	}
+	if got := envChanges(env, env.EnvVars, env.SecretKeys); len(got) != 0 {
+		t.Errorf("envChanges() of identical env = %v, want none", got)
+	}
+}
@@ -2,26 +2,51 @@ package cmd
 
 import (
 	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/mattn/go-runewidth"
+	"github.com/pkg/browser"
 	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
 )
 
+// openURL opens a URL in the user's browser; replaced in tests
+var openURL = browser.OpenURL
+
 // AppsCommand represents the apps command group
 type AppsCommand struct {
 	root *RootCommand
 	cmd  *cobra.Command
 
 	// Subcommands
-	createCmd *AppsCreateCommand
-	listCmd   *AppsListCommand
-	deleteCmd *AppsDeleteCommand
+	createCmd       *AppsCreateCommand
+	createStaticCmd *AppsCreateStaticCommand
+	listCmd         *AppsListCommand
+	getCmd          *AppsGetCommand
+	redeployCmd     *AppsRedeployCommand
+	rollbackCmd     *AppsRollbackCommand
+	deploymentsCmd  *AppsDeploymentsCommand
+	domainCmd       *AppsDomainCommand
+	envCmd          *AppsEnvCommand
+	openCmd         *AppsOpenCommand
+	statusCmd       *AppsStatusCommand
+	watchCmd        *AppsWatchCommand
+	transferCmd     *AppsTransferCommand
+	renameCmd       *AppsRenameCommand
+	deployStaticCmd *AppsDeployStaticCommand
+	deleteCmd       *AppsDeleteCommand
 }
 
 // NewAppsCommand creates a new apps command
@@ -36,17 +61,46 @@ func NewAppsCommand(root *RootCommand) *AppsCommand {
 		Long: `Manage your Kamui applications.
 
 Applications are deployable units within a project. They can be web servers,
-APIs, or any other containerized applications.`,
+APIs, or any other containerized applications.
+
+Commands that act on one app take its name or ID. In a terminal the name can
+be left out to pick the app from a list instead.`,
 	}
 
 	// Initialize subcommands
 	a.createCmd = NewAppsCreateCommand(a)
+	a.createStaticCmd = NewAppsCreateStaticCommand(a)
 	a.listCmd = NewAppsListCommand(a)
+	a.getCmd = NewAppsGetCommand(a)
+	a.redeployCmd = NewAppsRedeployCommand(a)
+	a.rollbackCmd = NewAppsRollbackCommand(a)
+	a.deploymentsCmd = NewAppsDeploymentsCommand(a)
+	a.domainCmd = NewAppsDomainCommand(a)
+	a.envCmd = NewAppsEnvCommand(a)
+	a.openCmd = NewAppsOpenCommand(a)
+	a.statusCmd = NewAppsStatusCommand(a)
+	a.watchCmd = NewAppsWatchCommand(a)
+	a.transferCmd = NewAppsTransferCommand(a)
+	a.renameCmd = NewAppsRenameCommand(a)
+	a.deployStaticCmd = NewAppsDeployStaticCommand(a)
 	a.deleteCmd = NewAppsDeleteCommand(a)
 
 	// Add subcommands
 	a.cmd.AddCommand(a.createCmd.Command())
+	a.cmd.AddCommand(a.createStaticCmd.Command())
 	a.cmd.AddCommand(a.listCmd.Command())
+	a.cmd.AddCommand(a.getCmd.Command())
+	a.cmd.AddCommand(a.redeployCmd.Command())
+	a.cmd.AddCommand(a.rollbackCmd.Command())
+	a.cmd.AddCommand(a.deploymentsCmd.Command())
+	a.cmd.AddCommand(a.domainCmd.Command())
+	a.cmd.AddCommand(a.envCmd.Command())
+	a.cmd.AddCommand(a.openCmd.Command())
+	a.cmd.AddCommand(a.statusCmd.Command())
+	a.cmd.AddCommand(a.watchCmd.Command())
+	a.cmd.AddCommand(a.transferCmd.Command())
+	a.cmd.AddCommand(a.renameCmd.Command())
+	a.cmd.AddCommand(a.deployStaticCmd.Command())
 	a.cmd.AddCommand(a.deleteCmd.Command())
 
 	return a
@@ -76,15 +130,26 @@ type AppsCreateCommand struct {
 	repo                string
 	branch              string
 	directory           string
+	image               string
+	registry            string
+	registryUsername    string
 	startCommand        string
 	setupCommand        string
 	preCommand          string
 	healthCheckEndpoint string
+	healthCheck         healthCheck
 	replicas            int
 	appSpecType         string
 	databaseID          string
 	envVars             []string
+	envFile             string
+	secrets             []string
+	secretFile          string
+	sessionAffinity     bool
 	nonInteractive      bool
+	file                string
+	wait                bool
+	waitTimeout         time.Duration
 }
 
 // NewAppsCreateCommand creates a new apps create command
@@ -104,10 +169,54 @@ up the build and start commands.
 
 You can specify the project by name or ID using the --project flag.
 
+With --image, the app is deployed from a container image instead of a
+GitHub repository. The image may come from Docker Hub or any other registry,
+e.g. ghcr.io/org/app:v1.2.3; --registry names the registry when the image
+reference doesn't. For a private registry, pass --registry-username and set
+the password in $KAMUI_REGISTRY_PASSWORD.
+
+The health check sends GET /health on the app's port unless configured with
+--health-check (the path) and the --health-* flags. Unset settings keep the
+platform defaults.
+
+--secret and --secret-file work like --env and --env-file but mark the
+variables as secrets: the API is asked never to echo their values, and
+they are redacted from --debug logs. Prefer --secret-file so values stay
+out of your shell history.
+
+With --wait, the command keeps running after the app is created, printing
+each deployment status change until the app is running or has failed.
+Polls start 2s apart and back off to 15s. The wait is bounded by
+--wait-timeout (default 5m) rather than --timeout and exits with code 7
+when it runs out; Ctrl-C stops waiting without affecting the deployment.
+
+With --file, the app is created non-interactively from a YAML or JSON spec
+("-" reads it from stdin). The spec names the project and uses snake_case
+keys matching the flags, with env vars and secrets as maps:
+
+  project: my-project
+  name: web
+  language: go
+  owner: my-org
+  owner_type: Organization
+  repo: web
+  start_command: ./server
+  env:
+    LOG_LEVEL: debug
+  secrets:
+    DB_PASSWORD: hunter2
+
 Examples:
   kamui apps create
   kamui apps create --project my-project
-  kamui apps create -p 5f809f2f-0787-40ca-9a43-a3a59edb5400`,
+  kamui apps create -p 5f809f2f-0787-40ca-9a43-a3a59edb5400
+  kamui apps create -p my-project --name web --language go --start-command ./server --repo my-org/web --branch main
+  kamui apps create -p my-project --name web --language go --start-command ./server --image my-org/web:1.4 -o json
+  kamui apps create -p my-project --name web --language go --start-command ./server --image ghcr.io/my-org/web:v1.2.3 --env-file .env
+  KAMUI_REGISTRY_PASSWORD=... kamui apps create -p my-project --name web --language go --start-command ./server --image web:v2 --registry registry.example.com --registry-username ci
+  kamui apps create -f app.yaml
+  kamui apps create -f app.yaml --wait
+  cat app.json | kamui apps create -f -`,
 		RunE: c.Run,
 	}
 
@@ -115,21 +224,32 @@ Examples:
 	c.cmd.Flags().StringVar(&c.name, "name", "", "App name")
 	c.cmd.Flags().StringVar(&c.appType, "type", "", "App type: dynamic")
 	c.cmd.Flags().StringVar(&c.language, "language", "", "Language type: node, go, python")
-	c.cmd.Flags().StringVar(&c.deployType, "deploy-type", "", "Deploy type: github or docker_hub")
+	c.cmd.Flags().StringVar(&c.deployType, "deploy-type", "", "Deploy type: github, docker_hub or container_registry (default github, or from --image)")
 	c.cmd.Flags().StringVar(&c.owner, "owner", "", "GitHub organization/user name")
-	c.cmd.Flags().StringVar(&c.ownerType, "owner-type", "", "GitHub owner type: Organization or User")
-	c.cmd.Flags().StringVar(&c.repo, "repo", "", "GitHub repository name")
+	c.cmd.Flags().StringVar(&c.ownerType, "owner-type", "", "GitHub owner type: Organization or User (looked up if omitted)")
+	c.cmd.Flags().StringVar(&c.repo, "repo", "", "GitHub repository, as name or owner/name")
 	c.cmd.Flags().StringVar(&c.branch, "branch", "", "GitHub repository branch")
 	c.cmd.Flags().StringVar(&c.directory, "directory", "", "Repository subdirectory")
+	c.cmd.Flags().StringVar(&c.image, "image", "", "Container image to deploy, e.g. my-org/web:1.4 or ghcr.io/org/app:v1.2.3")
+	c.cmd.Flags().StringVar(&c.registry, "registry", "", "Registry host of --image when the reference names none (default docker.io)")
+	c.cmd.Flags().StringVar(&c.registryUsername, "registry-username", "", "Username for a private registry; the password is read from $"+envRegistryPassword)
 	c.cmd.Flags().StringVar(&c.startCommand, "start-command", "", "Application start command")
 	c.cmd.Flags().StringVar(&c.setupCommand, "setup-command", "", "Build/setup command")
 	c.cmd.Flags().StringVar(&c.preCommand, "pre-command", "", "Pre-deploy command")
 	c.cmd.Flags().StringVar(&c.healthCheckEndpoint, "health-check", "", "Health check endpoint")
+	c.healthCheck.addFlags(c.cmd)
 	c.cmd.Flags().IntVar(&c.replicas, "replicas", 0, "Replica count")
 	c.cmd.Flags().StringVar(&c.appSpecType, "app-spec", "", "App spec type: nano, small, medium, large")
 	c.cmd.Flags().StringVar(&c.databaseID, "database-id", "", "Database ID to attach")
 	c.cmd.Flags().StringArrayVar(&c.envVars, "env", nil, "Environment variable KEY=VALUE (repeatable)")
+	c.cmd.Flags().StringVar(&c.envFile, "env-file", "", "Read environment variables from a dotenv file (--env takes precedence)")
+	c.cmd.Flags().StringArrayVar(&c.secrets, "secret", nil, "Secret environment variable KEY=VALUE, never echoed back (repeatable)")
+	c.cmd.Flags().StringVar(&c.secretFile, "secret-file", "", "Read secret environment variables from a dotenv file (--secret takes precedence)")
+	c.cmd.Flags().BoolVar(&c.sessionAffinity, "session-affinity", false, "Pin each client to a single replica (sticky sessions)")
 	c.cmd.Flags().BoolVar(&c.nonInteractive, "non-interactive", false, "Fail instead of prompting when required flags are missing")
+	c.cmd.Flags().StringVarP(&c.file, "file", "f", "", `Create the app from a YAML or JSON spec file ("-" reads stdin)`)
+	c.cmd.Flags().BoolVar(&c.wait, "wait", false, "Wait for the deployment to finish and report its status")
+	c.cmd.Flags().DurationVar(&c.waitTimeout, "wait-timeout", defaultWaitTimeout, "Maximum time to wait with --wait")
 
 	return c
 }
@@ -146,6 +266,24 @@ func (c *AppsCreateCommand) Run(cmd *cobra.Command, args []string) error {
 	projectService := c.parent.Root().Container().ProjectService()
 	appService := c.parent.Root().Container().AppService()
 
+	projectFlag, _ := cmd.Flags().GetString("project")
+
+	if c.wait && c.waitTimeout <= 0 {
+		return fmt.Errorf("--wait-timeout must be greater than zero")
+	}
+
+	// Read the spec before calling the API so a bad file fails fast
+	var spec *appSpec
+	if c.file != "" {
+		if c.hasCreateFlags() || projectFlag != "" {
+			return fmt.Errorf("--file cannot be combined with --project or other app flags; set them in the spec instead")
+		}
+		var err error
+		if spec, err = readAppSpec(c.file, cmd.InOrStdin()); err != nil {
+			return err
+		}
+	}
+
 	// Fetch all projects
 	projects, err := projectService.ListProjects(ctx)
 	if err != nil {
@@ -156,68 +294,35 @@ func (c *AppsCreateCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("no projects found. Create a project first with: kamui projects create")
 	}
 
-	// Step 1: Select project (by flag or interactive)
-	var project iface.Project
+	if spec != nil {
+		project, err := findProject(projects, spec.Project)
+		if err != nil {
+			return err
+		}
+		return c.createAppFromSpec(cmd, spec, project, appService)
+	}
 
-	projectFlag, _ := cmd.Flags().GetString("project")
+	// Step 1: Select project (by flag or interactive)
 	if c.hasCreateFlags() || c.nonInteractive {
 		if projectFlag == "" {
 			return fmt.Errorf("--project is required in non-interactive app creation")
 		}
-		// Find project by name or ID
-		var found bool
-		for _, p := range projects {
-			if p.ID == projectFlag || p.Name == projectFlag {
-				project = p
-				found = true
-				break
-			}
-		}
-		if !found {
-			return fmt.Errorf("project not found: %s\n\nUse 'kamui projects list' to see available projects", projectFlag)
+		project, err := findProject(projects, projectFlag)
+		if err != nil {
+			return err
 		}
 		return c.createDynamicAppWithFlags(cmd, project, appService)
 	}
 
-	if projectFlag != "" {
-		// Find project by name or ID
-		var found bool
-		for _, p := range projects {
-			if p.ID == projectFlag || p.Name == projectFlag {
-				project = p
-				found = true
-				break
-			}
-		}
-		if !found {
-			return fmt.Errorf("project not found: %s\n\nUse 'kamui projects list' to see available projects", projectFlag)
-		}
-		fmt.Printf("Using project: %s\n", project.Name)
-	} else {
-		// Interactive selection
-		projectOptions := make([]string, len(projects))
-		projectMap := make(map[string]iface.Project)
-		for i, p := range projects {
-			label := fmt.Sprintf("%s (%s)", p.Name, p.ID[:8])
-			projectOptions[i] = label
-			projectMap[label] = p
-		}
-
-		var selectedProject string
-		if err := survey.AskOne(&survey.Select{
-			Message: "Select project:",
-			Options: projectOptions,
-		}, &selectedProject); err != nil {
-			return err
-		}
-
-		project = projectMap[selectedProject]
+	project, err := selectProject(projects, projectFlag)
+	if err != nil {
+		return err
 	}
 
 	// Step 2: App type selection
 	appTypes := []string{"Dynamic app", "Static app (GitHub)", "Static app (ZIP upload)"}
 	var selectedAppType string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask(&survey.Select{
 		Message: "App type:",
 		Options: appTypes,
 	}, &selectedAppType); err != nil {
@@ -235,6 +340,63 @@ func (c *AppsCreateCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 }
 
+// findProject looks up a project by exact ID or name
+func findProject(projects []iface.Project, nameOrID string) (iface.Project, error) {
+	var byName []iface.Project
+	for _, p := range projects {
+		if p.ID == nameOrID {
+			return p, nil
+		}
+		if p.Name == nameOrID {
+			byName = append(byName, p)
+		}
+	}
+
+	switch len(byName) {
+	case 0:
+		return iface.Project{}, fmt.Errorf("%w: %s\n\nUse 'kamui projects list' to see available projects", errProjectNotFound, nameOrID)
+	case 1:
+		return byName[0], nil
+	default:
+		ids := make([]string, len(byName))
+		for i, p := range byName {
+			ids[i] = p.ID
+		}
+		return iface.Project{}, fmt.Errorf("project name %q is ambiguous (matches %s); use the project ID instead", nameOrID, strings.Join(ids, ", "))
+	}
+}
+
+// selectProject resolves the --project flag if given, otherwise asks the
+// user to pick one of the projects interactively
+func selectProject(projects []iface.Project, projectFlag string) (iface.Project, error) {
+	if projectFlag != "" {
+		project, err := findProject(projects, projectFlag)
+		if err != nil {
+			return iface.Project{}, err
+		}
+		fmt.Fprintf(os.Stderr, "Using project: %s\n", project.Name)
+		return project, nil
+	}
+
+	projectOptions := make([]string, len(projects))
+	projectMap := make(map[string]iface.Project)
+	for i, p := range projects {
+		label := fmt.Sprintf("%s (%s)", p.Name, p.ID[:8])
+		projectOptions[i] = label
+		projectMap[label] = p
+	}
+
+	var selectedProject string
+	if err := ask(&survey.Select{
+		Message: "Select project:",
+		Options: projectOptions,
+	}, &selectedProject); err != nil {
+		return iface.Project{}, err
+	}
+
+	return projectMap[selectedProject], nil
+}
+
 func (c *AppsCreateCommand) hasCreateFlags() bool {
 	return c.name != "" ||
 		c.appType != "" ||
@@ -245,14 +407,22 @@ func (c *AppsCreateCommand) hasCreateFlags() bool {
 		c.repo != "" ||
 		c.branch != "" ||
 		c.directory != "" ||
+		c.image != "" ||
+		c.registry != "" ||
+		c.registryUsername != "" ||
 		c.startCommand != "" ||
 		c.setupCommand != "" ||
 		c.preCommand != "" ||
 		c.healthCheckEndpoint != "" ||
+		c.healthCheck.isSet() ||
 		c.replicas != 0 ||
 		c.appSpecType != "" ||
 		c.databaseID != "" ||
-		len(c.envVars) > 0
+		c.sessionAffinity ||
+		len(c.envVars) > 0 ||
+		c.envFile != "" ||
+		len(c.secrets) > 0 ||
+		c.secretFile != ""
 }
 
 func (c *AppsCreateCommand) createDynamicAppWithFlags(cmd *cobra.Command, project iface.Project, appService iface.AppService) error {
@@ -278,25 +448,31 @@ func (c *AppsCreateCommand) createDynamicAppWithFlags(cmd *cobra.Command, projec
 		return fmt.Errorf("--start-command is required in non-interactive app creation")
 	}
 
-	deployType := c.deployType
-	if deployType == "" {
-		deployType = "github"
-	}
-	if deployType != "github" && deployType != "docker_hub" {
-		return fmt.Errorf("--deploy-type must be github or docker_hub")
+	deployType, img, err := c.resolveDeploySource()
+	if err != nil {
+		return err
 	}
 	if deployType == "github" {
+		if c.owner, c.repo, err = splitRepoFlag(c.owner, c.repo); err != nil {
+			return err
+		}
 		if c.owner == "" {
-			return fmt.Errorf("--owner is required when --deploy-type=github")
+			return fmt.Errorf("--owner is required when --deploy-type=github (or pass --repo owner/name)")
 		}
-		if c.ownerType == "" {
-			return fmt.Errorf("--owner-type is required when --deploy-type=github")
+		if c.repo == "" {
+			return fmt.Errorf("--repo is required when --deploy-type=github")
 		}
-		if c.ownerType != "Organization" && c.ownerType != "User" {
+		if c.ownerType == "" {
+			if c.ownerType, err = lookupOwnerType(ctx, appService, c.owner, c.repo); err != nil {
+				return err
+			}
+		} else if c.ownerType != "Organization" && c.ownerType != "User" {
 			return fmt.Errorf("--owner-type must be Organization or User")
 		}
-		if c.repo == "" {
-			return fmt.Errorf("--repo is required when --deploy-type=github")
+		if c.branch != "" {
+			if err := validateBranch(ctx, appService, c.owner, c.repo, c.branch); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -308,6 +484,9 @@ func (c *AppsCreateCommand) createDynamicAppWithFlags(cmd *cobra.Command, projec
 	if replicas < 1 {
 		replicas = 1
 	}
+	if c.sessionAffinity && replicas == 1 {
+		fmt.Fprintln(os.Stderr, "⚠ --session-affinity has no effect with a single replica; it applies once the app is scaled above 1.")
+	}
 	appSpecType := c.appSpecType
 	if appSpecType == "" {
 		appSpecType = "nano"
@@ -321,14 +500,25 @@ func (c *AppsCreateCommand) createDynamicAppWithFlags(cmd *cobra.Command, projec
 	if healthCheckEndpoint == "" {
 		healthCheckEndpoint = "/health"
 	}
+	if err := c.healthCheck.validate(); err != nil {
+		return err
+	}
 
-	envVars, err := parseEnvVars(c.envVars)
+	envVars, secretKeys, err := c.envVarsFromFlags()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Using project: %s\n", project.Name)
-	fmt.Println("\nCreating application...")
+	projectService := c.parent.Root().Container().ProjectService()
+	if err := checkPlanLimits(ctx, projectService, project, replicas, appSpecType); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Using project: %s\n", project.Name)
+	if img != nil {
+		fmt.Fprintf(os.Stderr, "Using image: %s\n", img)
+	}
+	fmt.Fprintln(os.Stderr, "\nCreating application...")
 
 	input := &iface.CreateAppInput{
 		ProjectID:       project.ID,
@@ -347,28 +537,197 @@ func (c *AppsCreateCommand) createDynamicAppWithFlags(cmd *cobra.Command, projec
 		Replicas:        replicas,
 		AppSpecType:     appSpecType,
 		EnvVars:         envVars,
+		SecretKeys:      secretKeys,
 		DatabaseID:      c.databaseID,
+		SessionAffinity: c.sessionAffinity,
+	}
+	c.healthCheck.apply(input)
+	if img != nil {
+		setImage(input, *img, c.registryUsername, os.Getenv(envRegistryPassword))
 	}
 
+	sp := startSpinner()
 	result, err := appService.CreateApp(ctx, input)
+	sp.Stop()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\n✓ App \"%s\" created successfully!\n", result.Name)
+	return c.reportCreatedApp(cmd, appService, "App", result, project, "")
+}
+
+// createAppFromSpec creates a dynamic app from a validated spec file
+func (c *AppsCreateCommand) createAppFromSpec(cmd *cobra.Command, spec *appSpec, project iface.Project, appService iface.AppService) error {
+	input := spec.input(project)
+	if input.SessionAffinity && input.Replicas == 1 {
+		fmt.Fprintln(os.Stderr, "⚠ session_affinity has no effect with a single replica; it applies once the app is scaled above 1.")
+	}
+
+	projectService := c.parent.Root().Container().ProjectService()
+	if err := checkPlanLimits(cmd.Context(), projectService, project, input.Replicas, input.AppSpecType); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(os.Stderr, "Using project: %s\n", project.Name)
+	fmt.Fprintln(os.Stderr, "\nCreating application...")
+
+	sp := startSpinner()
+	result, err := appService.CreateApp(cmd.Context(), input)
+	sp.Stop()
+	if err != nil {
+		return err
+	}
+
+	return c.reportCreatedApp(cmd, appService, "App", result, project, "")
+}
+
+// reportCreatedApp prints the created app and, with --wait, waits for its
+// deployment to finish
+func (c *AppsCreateCommand) reportCreatedApp(cmd *cobra.Command, appService iface.AppService, kind string, result *iface.CreateAppOutput, project iface.Project, url string) error {
+	if err := printCreatedApp(cmd, kind, result, project, url, c.wait); err != nil {
+		return err
+	}
+	if !c.wait {
+		return nil
+	}
+	return waitForDeploy(cmd.Context(), appService, result.ID, c.waitTimeout, os.Stderr)
+}
+
+// createdApp is the JSON output of the app create commands
+type createdApp struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	ProjectID string `json:"project_id"`
+	URL       string `json:"url,omitempty"`
+}
+
+// printCreatedApp reports a newly created app, as JSON when --output json
+// is set. kind names the app in the text output, e.g. "Static app". The
+// hint to check the deployment status is omitted when waiting is set.
+func printCreatedApp(cmd *cobra.Command, kind string, result *iface.CreateAppOutput, project iface.Project, url string, waiting bool) error {
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(createdApp{
+			ID:        result.ID,
+			Name:      result.Name,
+			ProjectID: project.ID,
+			URL:       url,
+		})
+	}
+
+	fmt.Printf("\n✓ %s \"%s\" created successfully!\n", kind, result.Name)
 	fmt.Printf("  ID: %s\n", result.ID)
-	fmt.Println("\n  Note: Deployment is in progress. Check status with:")
-	fmt.Printf("  kamui apps list -p %s\n", project.ID)
+	if url != "" {
+		fmt.Printf("  URL: %s\n", url)
+	}
+	if !waiting {
+		fmt.Println("\n  Note: Deployment is in progress. Check status with:")
+		fmt.Printf("  kamui apps list -p %s\n", project.ID)
+	}
 
 	return nil
 }
 
-func parseEnvVars(values []string) (map[string]string, error) {
+// resolveDeploySource returns the deploy type and, for the docker deploy
+// types, the parsed --image. Without --deploy-type, the deploy type is
+// github, or follows the registry of --image.
+func (c *AppsCreateCommand) resolveDeploySource() (string, *imageRef, error) {
+	deployType := c.deployType
+	switch deployType {
+	case "", "github", deployTypeDockerHub, deployTypeRegistry:
+	default:
+		return "", nil, fmt.Errorf("--deploy-type must be github, %s or %s", deployTypeDockerHub, deployTypeRegistry)
+	}
+
+	if c.image == "" {
+		if c.registry != "" || c.registryUsername != "" {
+			return "", nil, fmt.Errorf("--registry and --registry-username require --image")
+		}
+		if deployType == deployTypeRegistry {
+			return "", nil, fmt.Errorf("--image is required when --deploy-type=%s", deployTypeRegistry)
+		}
+		if deployType == "" {
+			deployType = "github"
+		}
+		return deployType, nil, nil
+	}
+
+	if deployType == "github" {
+		return "", nil, fmt.Errorf("--image cannot be used with --deploy-type=github")
+	}
+	img, err := parseImageRef(c.image)
+	if err != nil {
+		return "", nil, err
+	}
+	if img, err = img.withRegistry(c.registry, c.image); err != nil {
+		return "", nil, err
+	}
+	if deployType != "" && deployType != img.deployType() {
+		return "", nil, fmt.Errorf("--deploy-type=%s does not match the registry of --image (%s); use --deploy-type=%s or omit it", deployType, img.Registry, img.deployType())
+	}
+	if c.registryUsername != "" && os.Getenv(envRegistryPassword) == "" {
+		return "", nil, fmt.Errorf("--registry-username requires the registry password in $%s", envRegistryPassword)
+	}
+	return img.deployType(), &img, nil
+}
+
+// envVarsFromFlags merges --env-file, --env, --secret-file, and --secret
+// into one map of environment variables and returns the sorted names of the
+// secret ones. A name may not be both a plain variable and a secret.
+func (c *AppsCreateCommand) envVarsFromFlags() (map[string]string, []string, error) {
+	envVars, err := mergeEnvVars(c.envFile, c.envVars, "--env")
+	if err != nil {
+		return nil, nil, err
+	}
+	secrets, err := mergeEnvVars(c.secretFile, c.secrets, "--secret")
+	if err != nil {
+		return nil, nil, err
+	}
+	return addSecrets(envVars, secrets)
+}
+
+// mergeEnvVars reads the dotenv file at path, if any, and overlays the
+// KEY=VALUE flag values on it
+func mergeEnvVars(path string, values []string, flag string) (map[string]string, error) {
+	envVars := map[string]string{}
+	if path != "" {
+		var err error
+		if envVars, err = readEnvFile(path); err != nil {
+			return nil, err
+		}
+	}
+	flagVars, err := parseEnvVars(flag, values)
+	if err != nil {
+		return nil, err
+	}
+	for key, val := range flagVars {
+		envVars[key] = val
+	}
+	return envVars, nil
+}
+
+// addSecrets adds secrets to envVars and returns the sorted secret names
+func addSecrets(envVars, secrets map[string]string) (map[string]string, []string, error) {
+	var secretKeys []string
+	for key, val := range secrets {
+		if _, ok := envVars[key]; ok {
+			return nil, nil, fmt.Errorf("%s is set both as an environment variable and as a secret", key)
+		}
+		envVars[key] = val
+		secretKeys = append(secretKeys, key)
+	}
+	sort.Strings(secretKeys)
+	return envVars, secretKeys, nil
+}
+
+// parseEnvVars parses KEY=VALUE values given to flag
+func parseEnvVars(flag string, values []string) (map[string]string, error) {
 	envVars := make(map[string]string)
 	for _, value := range values {
 		key, val, ok := strings.Cut(value, "=")
 		if !ok || key == "" {
-			return nil, fmt.Errorf("--env must be in KEY=VALUE format")
+			return nil, fmt.Errorf("%s must be in KEY=VALUE format", flag)
 		}
 		envVars[key] = val
 	}
@@ -381,7 +740,7 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 
 	// Step 2: App name
 	var appName string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "App name:",
 	}, &appName, survey.WithValidator(survey.Required)); err != nil {
 		return err
@@ -396,7 +755,7 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 	}
 
 	var selectedLanguage string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask(&survey.Select{
 		Message: "Language:",
 		Options: languages,
 	}, &selectedLanguage); err != nil {
@@ -406,14 +765,14 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 	language := languageMap[selectedLanguage]
 
 	// Step 4: Deploy type
-	deployTypes := []string{"GitHub repository", "Docker Hub"}
+	deployTypes := []string{"GitHub repository", "Container image"}
 	deployTypeMap := map[string]string{
 		"GitHub repository": "github",
-		"Docker Hub":        "docker_hub",
+		"Container image":   deployTypeDockerHub, // follows the image's registry
 	}
 
 	var selectedDeployType string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask(&survey.Select{
 		Message: "Deploy from:",
 		Options: deployTypes,
 	}, &selectedDeployType); err != nil {
@@ -423,17 +782,22 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 	deployType := deployTypeMap[selectedDeployType]
 
 	var owner, ownerType, repo, branch string
+	var img imageRef
+	var registryUsername, registryPassword string
 
-	if deployType == "github" {
-		// Fetch GitHub installations
-		fmt.Println("\nFetching GitHub repositories...")
-		installations, err := appService.GetInstallations(ctx)
-		if err != nil {
-			return fmt.Errorf("failed to fetch GitHub repositories: %w", err)
+	if deployType == deployTypeDockerHub {
+		var err error
+		if img, registryUsername, registryPassword, err = promptImage(); err != nil {
+			return err
 		}
+	}
 
-		if len(installations) == 0 {
-			return fmt.Errorf("no GitHub repositories found. Please connect your GitHub account first")
+	if deployType == "github" {
+		// Fetch GitHub installations, offering to install the GitHub App
+		// when there are none
+		installations, err := ensureInstallations(ctx, appService, isStdinTTY(), os.Stderr)
+		if err != nil {
+			return err
 		}
 
 		// Build repository options
@@ -446,7 +810,7 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 		}
 
 		var selectedRepo string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask(&survey.Select{
 			Message: "Select repository:",
 			Options: repoOptions,
 		}, &selectedRepo); err != nil {
@@ -458,45 +822,16 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 		ownerType = installation.OwnerType
 		repo = installation.Repository
 
-		// Fetch branches
-		fmt.Println("\nFetching branches...")
-		branches, err := appService.GetBranches(ctx, owner, repo)
+		branch, err = promptBranch(ctx, appService, owner, repo)
 		if err != nil {
-			return fmt.Errorf("failed to fetch branches: %w", err)
-		}
-
-		if len(branches) == 0 {
-			// Default to main if no branches found
-			branch = "main"
-		} else {
-			branchOptions := make([]string, len(branches))
-			for i, b := range branches {
-				branchOptions[i] = b.Name
-			}
-
-			// Try to default to main or master
-			defaultBranch := ""
-			for _, b := range branchOptions {
-				if b == "main" || b == "master" {
-					defaultBranch = b
-					break
-				}
-			}
-
-			if err := survey.AskOne(&survey.Select{
-				Message: "Select branch:",
-				Options: branchOptions,
-				Default: defaultBranch,
-			}, &branch); err != nil {
-				return err
-			}
+			return err
 		}
 	}
 
 	// Step 5: Directory (for monorepos)
 	var directory string
 	if deployType == "github" {
-		if err := survey.AskOne(&survey.Input{
+		if err := ask(&survey.Input{
 			Message: "Directory (for monorepos, leave empty for root):",
 			Default: "",
 		}, &directory); err != nil {
@@ -506,21 +841,21 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 
 	// Step 6: Commands
 	var startCommand string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Start command:",
 	}, &startCommand, survey.WithValidator(survey.Required)); err != nil {
 		return err
 	}
 
 	var setupCommand string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Setup command:",
 	}, &setupCommand); err != nil {
 		return err
 	}
 
 	var preCommand string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Pre-deploy command:",
 	}, &preCommand); err != nil {
 		return err
@@ -528,16 +863,20 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 
 	// Step 7: Health check endpoint
 	var healthCheckPath string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Health check endpoint:",
 		Default: "/health",
 	}, &healthCheckPath); err != nil {
 		return err
 	}
+	healthCheck, err := promptHealthCheck()
+	if err != nil {
+		return err
+	}
 
 	// Step 8: Replicas
 	var replicasStr string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Replicas:",
 		Default: "1",
 	}, &replicasStr); err != nil {
@@ -550,10 +889,21 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 		replicas = 1
 	}
 
+	// Session affinity only matters when there is more than one replica
+	var sessionAffinity bool
+	if replicas > 1 {
+		if err := ask(&survey.Confirm{
+			Message: "Enable session affinity (sticky sessions)?",
+			Default: false,
+		}, &sessionAffinity); err != nil {
+			return err
+		}
+	}
+
 	// Step 9: Environment variables
 	envVars := make(map[string]string)
 	var addEnvVars bool
-	if err := survey.AskOne(&survey.Confirm{
+	if err := ask(&survey.Confirm{
 		Message: "Add environment variables?",
 		Default: false,
 	}, &addEnvVars); err != nil {
@@ -563,7 +913,7 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 	if addEnvVars {
 		for {
 			var envKey string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask(&survey.Input{
 				Message: "Environment variable name (empty to finish):",
 			}, &envKey); err != nil {
 				return err
@@ -574,7 +924,7 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 			}
 
 			var envValue string
-			if err := survey.AskOne(&survey.Input{
+			if err := ask(&survey.Input{
 				Message: fmt.Sprintf("Value for %s:", envKey),
 			}, &envValue); err != nil {
 				return err
@@ -588,7 +938,7 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 	var databaseID string
 	if len(project.Databases) > 0 {
 		var useDatabase bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask(&survey.Confirm{
 			Message: "Connect to a database?",
 			Default: false,
 		}, &useDatabase); err != nil {
@@ -609,7 +959,7 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 			}
 
 			var selectedDB string
-			if err := survey.AskOne(&survey.Select{
+			if err := ask(&survey.Select{
 				Message: "Select database:",
 				Options: dbOptions,
 			}, &selectedDB); err != nil {
@@ -622,8 +972,12 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 		}
 	}
 
+	if err := checkPlanLimits(ctx, c.parent.Root().Container().ProjectService(), project, replicas, ""); err != nil {
+		return err
+	}
+
 	// Create the app
-	fmt.Println("\nCreating application...")
+	fmt.Fprintln(os.Stderr, "\nCreating application...")
 
 	input := &iface.CreateAppInput{
 		ProjectID:       project.ID,
@@ -642,19 +996,21 @@ func (c *AppsCreateCommand) createDynamicApp(cmd *cobra.Command, project iface.P
 		Replicas:        replicas,
 		EnvVars:         envVars,
 		DatabaseID:      databaseID,
+		SessionAffinity: sessionAffinity,
+	}
+	healthCheck.apply(input)
+	if deployType == deployTypeDockerHub {
+		setImage(input, img, registryUsername, registryPassword)
 	}
 
+	sp := startSpinner()
 	result, err := appService.CreateApp(ctx, input)
+	sp.Stop()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\n✓ App \"%s\" created successfully!\n", result.Name)
-	fmt.Printf("  ID: %s\n", result.ID)
-	fmt.Println("\n  Note: Deployment is in progress. Check status with:")
-	fmt.Printf("  kamui apps list -p %s\n", project.ID)
-
-	return nil
+	return c.reportCreatedApp(cmd, appService, "App", result, project, "")
 }
 
 // createStaticAppGitHub handles the creation of a static app from GitHub
@@ -663,21 +1019,17 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 
 	// App name
 	var appName string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "App name:",
 	}, &appName, survey.WithValidator(survey.Required)); err != nil {
 		return err
 	}
 
-	// Fetch GitHub installations
-	fmt.Println("\nFetching GitHub repositories...")
-	installations, err := appService.GetInstallations(ctx)
+	// Fetch GitHub installations, offering to install the GitHub App when
+	// there are none
+	installations, err := ensureInstallations(ctx, appService, isStdinTTY(), os.Stderr)
 	if err != nil {
-		return fmt.Errorf("failed to fetch GitHub repositories: %w", err)
-	}
-
-	if len(installations) == 0 {
-		return fmt.Errorf("no GitHub repositories found. Please connect your GitHub account first")
+		return err
 	}
 
 	// Build repository options
@@ -690,7 +1042,7 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 	}
 
 	var selectedRepo string
-	if err := survey.AskOne(&survey.Select{
+	if err := ask(&survey.Select{
 		Message: "Select repository:",
 		Options: repoOptions,
 	}, &selectedRepo); err != nil {
@@ -702,42 +1054,14 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 	ownerType := installation.OwnerType
 	repo := installation.Repository
 
-	// Fetch branches
-	fmt.Println("\nFetching branches...")
-	branches, err := appService.GetBranches(ctx, owner, repo)
+	branch, err := promptBranch(ctx, appService, owner, repo)
 	if err != nil {
-		return fmt.Errorf("failed to fetch branches: %w", err)
-	}
-
-	var branch string
-	if len(branches) == 0 {
-		branch = "main"
-	} else {
-		branchOptions := make([]string, len(branches))
-		for i, b := range branches {
-			branchOptions[i] = b.Name
-		}
-
-		defaultBranch := ""
-		for _, b := range branchOptions {
-			if b == "main" || b == "master" {
-				defaultBranch = b
-				break
-			}
-		}
-
-		if err := survey.AskOne(&survey.Select{
-			Message: "Select branch:",
-			Options: branchOptions,
-			Default: defaultBranch,
-		}, &branch); err != nil {
-			return err
-		}
+		return err
 	}
 
 	// Directory (for monorepos)
 	var directory string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Directory (for monorepos, leave empty for root):",
 		Default: "",
 	}, &directory); err != nil {
@@ -748,7 +1072,7 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 	var appSpecType string
 	if project.PlanType == "free" {
 		appSpecType = "nano"
-		fmt.Println("App spec: nano (Free plan)")
+		fmt.Fprintln(os.Stderr, "App spec: nano (Free plan)")
 	} else {
 		specTypes := []string{"Nano", "Small", "Medium", "Large"}
 		specTypeMap := map[string]string{
@@ -759,7 +1083,7 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 		}
 
 		var selectedSpecType string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask(&survey.Select{
 			Message: "App spec (resource size):",
 			Options: specTypes,
 			Default: "Nano",
@@ -771,7 +1095,7 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 
 	// Replicas
 	var replicasStr string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Replicas:",
 		Default: "1",
 	}, &replicasStr); err != nil {
@@ -784,8 +1108,12 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 		replicas = 1
 	}
 
+	if err := checkPlanLimits(ctx, c.parent.Root().Container().ProjectService(), project, replicas, appSpecType); err != nil {
+		return err
+	}
+
 	// Create the static app
-	fmt.Println("\nCreating static application...")
+	fmt.Fprintln(os.Stderr, "\nCreating static application...")
 
 	input := &iface.CreateStaticAppInput{
 		ProjectID:        project.ID,
@@ -800,17 +1128,14 @@ func (c *AppsCreateCommand) createStaticAppGitHub(cmd *cobra.Command, project if
 		Directory:        directory,
 	}
 
+	sp := startSpinner()
 	result, err := appService.CreateStaticApp(ctx, input)
+	sp.Stop()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("\n✓ Static app \"%s\" created successfully!\n", result.Name)
-	fmt.Printf("  ID: %s\n", result.ID)
-	fmt.Println("\n  Note: Deployment is in progress. Check status with:")
-	fmt.Printf("  kamui apps list -p %s\n", project.ID)
-
-	return nil
+	return c.reportCreatedApp(cmd, appService, "Static app", result, project, "")
 }
 
 // createStaticAppUpload handles the creation of a static app via file upload
@@ -819,7 +1144,7 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 
 	// App name
 	var appName string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "App name:",
 	}, &appName, survey.WithValidator(survey.Required)); err != nil {
 		return err
@@ -827,7 +1152,7 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 
 	// Directory or ZIP file path
 	var inputPath string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Path to directory or ZIP file:",
 	}, &inputPath, survey.WithValidator(func(ans interface{}) error {
 		path := ans.(string)
@@ -848,22 +1173,15 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 		}
 
 		if info.IsDir() {
-			// Check if directory contains index.html
-			indexPath := filepath.Join(path, "index.html")
-			if _, err := os.Stat(indexPath); os.IsNotExist(err) {
-				return fmt.Errorf("directory must contain index.html")
-			}
-		} else {
-			// Check if it's a ZIP file
-			if !strings.HasSuffix(strings.ToLower(path), ".zip") {
-				return fmt.Errorf("file must be a ZIP archive or a directory")
-			}
-			// Check if ZIP contains index.html
-			if err := validateZipContainsIndexHTML(path); err != nil {
-				return err
-			}
+			_, err := checkStaticDir(path)
+			return err
 		}
-		return nil
+		// Check if it's a ZIP file
+		if !strings.HasSuffix(strings.ToLower(path), ".zip") {
+			return fmt.Errorf("file must be a ZIP archive or a directory")
+		}
+		// Check if ZIP contains index.html
+		return validateZipContainsIndexHTML(path)
 	})); err != nil {
 		return err
 	}
@@ -879,8 +1197,14 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 	var tempZipCreated bool
 	info, _ := os.Stat(inputPath)
 	if info.IsDir() {
+		size, err := checkStaticDir(inputPath)
+		if err != nil {
+			return err
+		}
+		warnLargeStaticDir(os.Stderr, inputPath, size)
+
 		// Create temporary ZIP from directory
-		fmt.Println("Creating ZIP from directory...")
+		fmt.Fprintln(os.Stderr, "Creating ZIP from directory...")
 		tempZip, err := createZipFromDirectory(inputPath)
 		if err != nil {
 			return fmt.Errorf("failed to create ZIP: %w", err)
@@ -900,7 +1224,7 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 	var appSpecType string
 	if project.PlanType == "free" {
 		appSpecType = "nano"
-		fmt.Println("App spec: nano (Free plan)")
+		fmt.Fprintln(os.Stderr, "App spec: nano (Free plan)")
 	} else {
 		specTypes := []string{"Nano", "Small", "Medium", "Large"}
 		specTypeMap := map[string]string{
@@ -911,7 +1235,7 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 		}
 
 		var selectedSpecType string
-		if err := survey.AskOne(&survey.Select{
+		if err := ask(&survey.Select{
 			Message: "App spec (resource size):",
 			Options: specTypes,
 			Default: "Nano",
@@ -923,7 +1247,7 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 
 	// Replicas
 	var replicasStr string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Replicas:",
 		Default: "1",
 	}, &replicasStr); err != nil {
@@ -936,8 +1260,12 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 		replicas = 1
 	}
 
+	if err := checkPlanLimits(ctx, c.parent.Root().Container().ProjectService(), project, replicas, appSpecType); err != nil {
+		return err
+	}
+
 	// Create the static app via file upload
-	fmt.Println("\nUploading and creating static application...")
+	fmt.Fprintln(os.Stderr, "\nUploading and creating static application...")
 
 	input := &iface.CreateStaticAppUploadInput{
 		ProjectID:   project.ID,
@@ -945,6 +1273,7 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 		Replicas:    replicas,
 		AppSpecType: appSpecType,
 		FilePath:    filePath,
+		Progress:    newUploadProgress(os.Stderr, isStderrTTY()).Update,
 	}
 
 	result, err := appService.CreateStaticAppUpload(ctx, input)
@@ -952,262 +1281,1570 @@ func (c *AppsCreateCommand) createStaticAppUpload(cmd *cobra.Command, project if
 		return err
 	}
 
-	fmt.Printf("\n✓ Static app \"%s\" created successfully!\n", result.Name)
-	fmt.Printf("  ID: %s\n", result.ID)
-	fmt.Println("\n  Note: Deployment is in progress. Check status with:")
-	fmt.Printf("  kamui apps list -p %s\n", project.ID)
-
-	return nil
+	return c.reportCreatedApp(cmd, appService, "Static app", result, project, "")
 }
 
-// AppsListCommand represents the apps list command
-type AppsListCommand struct {
+// AppsCreateStaticCommand represents the apps create-static command
+type AppsCreateStaticCommand struct {
 	parent *AppsCommand
 	cmd    *cobra.Command
+
+	name        string
+	fromGitHub  bool
+	fromDir     string
+	owner       string
+	ownerType   string
+	repo        string
+	branch      string
+	directory   string
+	replicas    int
+	appSpecType string
 }
 
-// NewAppsListCommand creates a new apps list command
-func NewAppsListCommand(parent *AppsCommand) *AppsListCommand {
-	l := &AppsListCommand{
+// NewAppsCreateStaticCommand creates a new apps create-static command
+func NewAppsCreateStaticCommand(parent *AppsCommand) *AppsCreateStaticCommand {
+	c := &AppsCreateStaticCommand{
 		parent: parent,
 	}
 
-	l.cmd = &cobra.Command{
-		Use:   "list",
-		Short: "List all applications in a project",
-		Long: `List all applications in a project.
+	c.cmd = &cobra.Command{
+		Use:   "create-static",
+		Short: "Create a new static site",
+		Long: `Create a new static site from a GitHub repository or a local directory.
 
-You can specify the project by name or ID using the --project flag.
+With --from-github the site is built from the given repository. With
+--from-dir the directory is checked for an index.html at its root, then
+zipped and uploaded; hidden files are left out. A warning is printed when it
+holds more than 50MB.
+
+You can specify the project by name or ID using the --project flag; without
+it you are prompted to pick one.
 
 Examples:
-  kamui apps list --project my-project
-  kamui apps list -p my-project`,
-		RunE: l.Run,
+  kamui apps create-static -p my-project --name docs --from-dir ./public
+  kamui apps create-static -p my-project --name site --from-github --repo my-org/site --branch main
+  kamui apps create-static -p my-project --name site --from-github --owner my-org --repo monorepo --directory web/dist`,
+		Args: cobra.NoArgs,
+		RunE: c.Run,
 	}
 
-	l.cmd.Flags().StringP("project", "p", "", "Project name or ID (required)")
-	l.cmd.MarkFlagRequired("project")
+	c.cmd.Flags().StringP("project", "p", "", "Project name or ID")
+	c.cmd.Flags().StringVar(&c.name, "name", "", "App name")
+	c.cmd.Flags().BoolVar(&c.fromGitHub, "from-github", false, "Deploy from a GitHub repository")
+	c.cmd.Flags().StringVar(&c.fromDir, "from-dir", "", "Upload a local directory containing index.html")
+	c.cmd.Flags().StringVar(&c.owner, "owner", "", "GitHub organization/user name")
+	c.cmd.Flags().StringVar(&c.ownerType, "owner-type", "", "GitHub owner type: Organization or User (looked up if omitted)")
+	c.cmd.Flags().StringVar(&c.repo, "repo", "", "GitHub repository, as name or owner/name")
+	c.cmd.Flags().StringVar(&c.branch, "branch", "", "GitHub repository branch (default main)")
+	c.cmd.Flags().StringVar(&c.directory, "directory", "", "Repository subdirectory")
+	c.cmd.Flags().IntVar(&c.replicas, "replicas", 0, "Replica count")
+	c.cmd.Flags().StringVar(&c.appSpecType, "app-spec", "", "App spec type: nano, small, medium, large")
+	c.cmd.MarkFlagsMutuallyExclusive("from-github", "from-dir")
 
-	return l
+	return c
 }
 
 // Command returns the underlying cobra command
-func (l *AppsListCommand) Command() *cobra.Command {
-	return l.cmd
+func (c *AppsCreateStaticCommand) Command() *cobra.Command {
+	return c.cmd
 }
 
-// Run executes the apps list command
-func (l *AppsListCommand) Run(cmd *cobra.Command, args []string) error {
-	nameOrID, _ := cmd.Flags().GetString("project")
+// Run executes the apps create-static command
+func (c *AppsCreateStaticCommand) Run(cmd *cobra.Command, args []string) error {
 	ctx := cmd.Context()
 
-	projectService := l.parent.Root().Container().ProjectService()
-
-	// Fetch all projects to find by name or ID
-	projects, err := projectService.ListProjects(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to fetch projects: %w", err)
+	if !c.fromGitHub && c.fromDir == "" {
+		return fmt.Errorf("one of --from-github or --from-dir is required")
 	}
-
-	// Find matching project
-	var project *iface.Project
-	for i := range projects {
-		p := &projects[i]
+	if c.fromGitHub {
+		var err error
+		if c.owner, c.repo, err = splitRepoFlag(c.owner, c.repo); err != nil {
+			return err
+		}
+		if c.owner == "" {
+			return fmt.Errorf("--owner is required with --from-github (or pass --repo owner/name)")
+		}
+		if c.repo == "" {
+			return fmt.Errorf("--repo is required with --from-github")
+		}
+		if c.ownerType != "" && c.ownerType != "Organization" && c.ownerType != "User" {
+			return fmt.Errorf("--owner-type must be Organization or User")
+		}
+	}
+
+	replicas := c.replicas
+	if replicas < 1 {
+		replicas = 1
+	}
+	appSpecType := c.appSpecType
+	if appSpecType == "" {
+		appSpecType = "nano"
+	}
+	switch appSpecType {
+	case "nano", "small", "medium", "large":
+	default:
+		return fmt.Errorf("--app-spec must be nano, small, medium, or large")
+	}
+
+	projectService := c.parent.Root().Container().ProjectService()
+	appService := c.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	if len(projects) == 0 {
+		return fmt.Errorf("no projects found. Create a project first with: kamui projects create")
+	}
+
+	projectFlag, _ := cmd.Flags().GetString("project")
+	if projectFlag == "" && !isStdinTTY() {
+		return fmt.Errorf("--project is required when not running interactively")
+	}
+	project, err := selectProject(projects, projectFlag)
+	if err != nil {
+		return err
+	}
+	if project.PlanType == "free" && appSpecType != "nano" {
+		return fmt.Errorf("--app-spec %s is not available on the free plan", appSpecType)
+	}
+
+	appName := c.name
+	if appName == "" {
+		if !isStdinTTY() {
+			return fmt.Errorf("--name is required when not running interactively")
+		}
+		if err := ask(&survey.Input{
+			Message: "App name:",
+		}, &appName, survey.WithValidator(survey.Required)); err != nil {
+			return err
+		}
+	}
+
+	if err := checkPlanLimits(ctx, projectService, project, replicas, appSpecType); err != nil {
+		return err
+	}
+
+	var result *iface.CreateAppOutput
+	if c.fromGitHub {
+		result, err = c.createFromGitHub(ctx, appService, project, appName, replicas, appSpecType)
+	} else {
+		result, err = c.createFromDir(ctx, appService, project, appName, replicas, appSpecType)
+	}
+	if err != nil {
+		return err
+	}
+
+	var url string
+	if detail, err := appService.GetApp(ctx, result.ID); err == nil {
+		url = detail.URL
+	}
+	return printCreatedApp(cmd, "Static app", result, project, url, false)
+}
+
+// createFromGitHub creates the static app from a GitHub repository
+func (c *AppsCreateStaticCommand) createFromGitHub(ctx context.Context, appService iface.AppService, project iface.Project, appName string, replicas int, appSpecType string) (*iface.CreateAppOutput, error) {
+	ownerType := c.ownerType
+	if ownerType == "" {
+		var err error
+		if ownerType, err = lookupOwnerType(ctx, appService, c.owner, c.repo); err != nil {
+			return nil, err
+		}
+	}
+
+	branch := c.branch
+	if branch == "" {
+		branch = "main"
+	} else if err := validateBranch(ctx, appService, c.owner, c.repo, branch); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(os.Stderr, "\nCreating static application...")
+
+	return appService.CreateStaticApp(ctx, &iface.CreateStaticAppInput{
+		ProjectID:        project.ID,
+		AppName:          appName,
+		Replicas:         replicas,
+		AppSpecType:      appSpecType,
+		DeployType:       "github",
+		OrganizationName: c.owner,
+		OwnerType:        ownerType,
+		RepositoryName:   c.repo,
+		RepositoryBranch: branch,
+		Directory:        c.directory,
+	})
+}
+
+// createFromDir zips a local directory and uploads it as a static app
+func (c *AppsCreateStaticCommand) createFromDir(ctx context.Context, appService iface.AppService, project iface.Project, appName string, replicas int, appSpecType string) (*iface.CreateAppOutput, error) {
+	zipPath, err := zipStaticDir(c.fromDir)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(zipPath)
+
+	fmt.Fprintln(os.Stderr, "\nUploading and creating static application...")
+
+	return appService.CreateStaticAppUpload(ctx, &iface.CreateStaticAppUploadInput{
+		ProjectID:   project.ID,
+		AppName:     appName,
+		Replicas:    replicas,
+		AppSpecType: appSpecType,
+		FilePath:    zipPath,
+		Progress:    newUploadProgress(os.Stderr, isStderrTTY()).Update,
+	})
+}
+
+// zipStaticDir zips the --from-dir directory of a static site into a
+// temporary file, after checking that it has an index.html at the root.
+// The caller removes the file.
+func zipStaticDir(fromDir string) (string, error) {
+	dirPath := fromDir
+	if strings.HasPrefix(dirPath, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			dirPath = home + dirPath[1:]
+		}
+	}
+
+	size, err := checkStaticDir(dirPath)
+	if err != nil {
+		return "", fmt.Errorf("--from-dir: %w", err)
+	}
+	warnLargeStaticDir(os.Stderr, dirPath, size)
+
+	fmt.Fprintln(os.Stderr, "Creating ZIP from directory...")
+	zipPath, err := createZipFromDirectory(dirPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create ZIP: %w", err)
+	}
+	return zipPath, nil
+}
+
+// staticDirWarnSize is the size of a static site directory above which a
+// warning is printed before it is uploaded
+const staticDirWarnSize = 50 << 20
+
+// checkStaticDir checks, before anything is zipped or uploaded, that dir is
+// a directory with an index.html at its root. It returns the total size of
+// the files createZipFromDirectory would include.
+func checkStaticDir(dir string) (int64, error) {
+	info, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, fmt.Errorf("directory not found: %s", dir)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if !info.IsDir() {
+		return 0, fmt.Errorf("not a directory: %s", dir)
+	}
+
+	index, err := os.Stat(filepath.Join(dir, "index.html"))
+	if err != nil || !index.Mode().IsRegular() {
+		return 0, fmt.Errorf("%s has no index.html at its root; point to the directory holding the built site", dir)
+	}
+
+	var size int64
+	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// Hidden files and directories are left out of the ZIP
+		if path != dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	return size, nil
+}
+
+// warnLargeStaticDir warns on w when a static site directory of the given
+// size is larger than staticDirWarnSize
+func warnLargeStaticDir(w io.Writer, dir string, size int64) {
+	if size > staticDirWarnSize {
+		fmt.Fprintf(w, "⚠ %s holds %s of files; uploading it may take a while. Check that it contains only the built site.\n", dir, formatBytes(size))
+	}
+}
+
+// AppsListCommand represents the apps list command
+type AppsListCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	all    bool
+	fields string
+}
+
+// NewAppsListCommand creates a new apps list command
+func NewAppsListCommand(parent *AppsCommand) *AppsListCommand {
+	l := &AppsListCommand{
+		parent: parent,
+	}
+
+	l.cmd = &cobra.Command{
+		Use:   "list",
+		Short: "List all applications in a project",
+		Long: `List all applications in a project, or in every project with --all.
+
+You can specify the project by name or ID using the --project flag.
+With -o json, apps are printed as a flat array annotated with their project;
+-o jsonl prints one app per line instead, and with --all starts printing
+once the first page of projects has been fetched.
+--fields prints a table of the chosen columns instead, in the order given:
+` + appFieldNames() + `.
+-o csv and -o tsv print delimited text with a header row, of the --fields
+columns or of PROJECT, ID, NAME, TYPE, STATUS and URL.
+
+Examples:
+  kamui apps list --project my-project
+  kamui apps list -p my-project
+  kamui apps list --all -o json
+  kamui apps list --all --fields project,name,status,url
+  kamui apps list --all -o csv > apps.csv`,
+		RunE: l.Run,
+	}
+
+	l.cmd.Flags().StringP("project", "p", "", "Project name or ID")
+	l.cmd.Flags().BoolVar(&l.all, "all", false, "List apps across all projects")
+	l.cmd.Flags().StringVar(&l.fields, "fields", "", "Comma-separated table columns to show, in order")
+	l.cmd.MarkFlagsOneRequired("project", "all")
+	l.cmd.MarkFlagsMutuallyExclusive("project", "all")
+
+	return l
+}
+
+// Command returns the underlying cobra command
+func (l *AppsListCommand) Command() *cobra.Command {
+	return l.cmd
+}
+
+// Run executes the apps list command
+func (l *AppsListCommand) Run(cmd *cobra.Command, args []string) error {
+	nameOrID, _ := cmd.Flags().GetString("project")
+	ctx := cmd.Context()
+
+	var columns []tableColumn[appListEntry]
+	comma, delimited := delimitedFormats[resolveOutputFormat(cmd)]
+	if delimited {
+		var err error
+		if columns, err = selectColumns(l.fields, appListColumns(false), defaultAppDelimitedFields); err != nil {
+			return err
+		}
+	} else if l.fields != "" {
+		var err error
+		if columns, err = selectColumns(l.fields, appListColumns(colorEnabled(cmd)), nil); err != nil {
+			return err
+		}
+	}
+
+	projectService := l.parent.Root().Container().ProjectService()
+	if l.all && resolveOutputFormat(cmd) == "jsonl" {
+		return l.streamAll(cmd)
+	}
+
+	// Fetch all projects to find by name or ID
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	if l.all {
+		return l.runAll(cmd, projects, columns)
+	}
+
+	// Find matching project
+	var project *iface.Project
+	for i := range projects {
+		p := &projects[i]
 		if p.ID == nameOrID || p.Name == nameOrID {
 			project = p
 			break
 		}
 	}
 
-	if project == nil {
-		return fmt.Errorf("project not found: %s\n\nUse 'kamui projects list' to see available projects", nameOrID)
+	if project == nil {
+		return fmt.Errorf("%w: %s\n\nUse 'kamui projects list' to see available projects", errProjectNotFound, nameOrID)
+	}
+
+	apps := project.Apps
+	jsonOutput := resolveOutputFormat(cmd) == "json"
+	jsonLines := resolveOutputFormat(cmd) == "jsonl"
+
+	if len(apps) == 0 {
+		if jsonLines {
+			return nil
+		}
+		if jsonOutput {
+			return encodeAppListEntries([]appListEntry{})
+		}
+		if delimited {
+			return writeDelimited(os.Stdout, comma, columns, nil)
+		}
+		fmt.Printf("No apps found in project \"%s\".\n", project.Name)
+		fmt.Println("\nCreate a new app with: kamui apps create")
+		return nil
+	}
+
+	appService := l.parent.Root().Container().AppService()
+
+	// Fetch app details concurrently; results are index-aligned with apps
+	// so the output order matches the project listing.
+	details := fetchAppDetails(ctx, appService, apps)
+
+	entries := make([]appListEntry, len(apps))
+	for i, app := range apps {
+		entries[i] = newAppListEntry(*project, app, details[i])
+	}
+	if jsonLines {
+		return writeJSONLines(os.Stdout, entries)
+	}
+	if jsonOutput {
+		return encodeAppListEntries(entries)
+	}
+	if delimited {
+		return writeDelimited(os.Stdout, comma, columns, entries)
+	}
+	if columns != nil {
+		printColumns(os.Stdout, "", columns, entries)
+		return nil
+	}
+
+	// Print apps
+	fmt.Printf("Apps in project \"%s\" (%s):\n\n", project.Name, project.ID)
+	printAppListEntries(entries, colorEnabled(cmd))
+
+	return nil
+}
+
+// streamAll prints the apps of every project as JSON Lines, a page of
+// projects at a time, so output starts before every page is fetched
+func (l *AppsListCommand) streamAll(cmd *cobra.Command) error {
+	ctx := cmd.Context()
+	projectService := l.parent.Root().Container().ProjectService()
+	appService := l.parent.Root().Container().AppService()
+
+	_, err := projectService.ListProjectsWithOptions(ctx, &iface.ListProjectsOptions{
+		OnPage: func(page []iface.Project) error {
+			return writeJSONLines(os.Stdout, projectAppEntries(ctx, appService, page))
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	return nil
+}
+
+// projectAppEntries fetches the details of every app in projects and
+// returns their list entries, in project order
+func projectAppEntries(ctx context.Context, appService iface.AppService, projects []iface.Project) []appListEntry {
+	// Flatten so one bounded pool serves every project
+	var apps []iface.App
+	var owners []iface.Project
+	for _, p := range projects {
+		for _, app := range p.Apps {
+			apps = append(apps, app)
+			owners = append(owners, p)
+		}
+	}
+	details := fetchAppDetails(ctx, appService, apps)
+
+	entries := make([]appListEntry, len(apps))
+	for i, app := range apps {
+		entries[i] = newAppListEntry(owners[i], app, details[i])
+	}
+	return entries
+}
+
+// runAll lists the apps of every project, grouped by project, or as one
+// table of columns when --fields is given
+func (l *AppsListCommand) runAll(cmd *cobra.Command, projects []iface.Project, columns []tableColumn[appListEntry]) error {
+	appService := l.parent.Root().Container().AppService()
+	entries := projectAppEntries(cmd.Context(), appService, projects)
+
+	format := resolveOutputFormat(cmd)
+	if format == "json" {
+		return encodeAppListEntries(entries)
+	}
+	if comma, ok := delimitedFormats[format]; ok {
+		return writeDelimited(os.Stdout, comma, columns, entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No apps found in any project.")
+		fmt.Println("\nCreate a new app with: kamui apps create")
+		return nil
+	}
+	if columns != nil {
+		printColumns(os.Stdout, "", columns, entries)
+		return nil
+	}
+
+	color := colorEnabled(cmd)
+	for start := 0; start < len(entries); {
+		end := start
+		for end < len(entries) && entries[end].ProjectID == entries[start].ProjectID {
+			end++
+		}
+		fmt.Printf("Apps in project \"%s\" (%s):\n\n", entries[start].ProjectName, entries[start].ProjectID)
+		printAppListEntries(entries[start:end], color)
+		start = end
+	}
+
+	return nil
+}
+
+// appListEntry is one row of `apps list`, annotated with its project
+type appListEntry struct {
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	AppType     string `json:"app_type,omitempty"`
+	Status      string `json:"status"`
+	URL         string `json:"url,omitempty"`
+}
+
+// newAppListEntry merges an app with its detail, which may be nil when the
+// detail fetch failed
+func newAppListEntry(project iface.Project, app iface.App, detail *iface.AppDetail) appListEntry {
+	entry := appListEntry{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		ID:          app.ID,
+		Name:        app.Name,
+		AppType:     app.AppType,
+		Status:      app.Status.String(),
+	}
+
+	// Use app detail for display name, URL and status when available
+	if detail != nil && detail.DisplayName != "" {
+		entry.Name = detail.DisplayName
+		entry.URL = detail.URL
+		if detail.AppType != "" {
+			entry.AppType = detail.AppType
+		}
+		// Update status from detail if available
+		if detailStatus := detail.Status.String(); detailStatus != iface.AppStatusUnknown {
+			entry.Status = detailStatus
+		}
+	}
+	return entry
+}
+
+// appListColumns are the columns `apps list --fields` can select. The
+// status is colored when color is set.
+func appListColumns(color bool) []tableColumn[appListEntry] {
+	return []tableColumn[appListEntry]{
+		{"PROJECT", func(e appListEntry) string { return e.ProjectName }},
+		{"PROJECT_ID", func(e appListEntry) string { return e.ProjectID }},
+		{"ID", func(e appListEntry) string { return e.ID }},
+		{"NAME", func(e appListEntry) string { return e.Name }},
+		{"TYPE", func(e appListEntry) string { return e.AppType }},
+		{"STATUS", func(e appListEntry) string { return colorStatus(e.Status, color) }},
+		{"URL", func(e appListEntry) string { return e.URL }},
+	}
+}
+
+// defaultAppDelimitedFields are the columns of `apps list -o csv` without
+// --fields
+var defaultAppDelimitedFields = []string{"PROJECT", "ID", "NAME", "TYPE", "STATUS", "URL"}
+
+// appFieldNames lists the selectable app columns for help text
+func appFieldNames() string {
+	columns := appListColumns(false)
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = c.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// printAppListEntries prints apps in the bulleted text format, coloring
+// the status when color is set
+func printAppListEntries(entries []appListEntry, color bool) {
+	for _, e := range entries {
+		name := e.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+
+		fmt.Printf("  • %s\n", name)
+		fmt.Printf("    ID: %s\n", e.ID)
+		fmt.Printf("    Status: %s\n", colorStatus(e.Status, color))
+		if e.URL != "" {
+			fmt.Printf("    URL: %s\n", e.URL)
+		}
+		fmt.Println()
+	}
+}
+
+// encodeAppListEntries prints apps as a JSON array
+func encodeAppListEntries(entries []appListEntry) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(entries)
+}
+
+// appDetailConcurrency bounds the number of in-flight GetApp requests when
+// listing apps, so large projects don't open dozens of connections at once.
+const appDetailConcurrency = 5
+
+// fetchAppDetails fetches details for each app using a bounded worker pool.
+// The returned slice is index-aligned with apps; entries are nil when the
+// fetch failed, so callers can fall back to the raw app fields.
+func fetchAppDetails(ctx context.Context, appService iface.AppService, apps []iface.App) []*iface.AppDetail {
+	details := make([]*iface.AppDetail, len(apps))
+
+	var g errgroup.Group
+	g.SetLimit(appDetailConcurrency)
+	for i := range apps {
+		i := i
+		g.Go(func() error {
+			detail, err := appService.GetApp(ctx, apps[i].ID)
+			if err == nil {
+				details[i] = detail
+			}
+			// A single failure must not abort the whole listing
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return details
+}
+
+// AppsGetCommand represents the apps get command
+type AppsGetCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+}
+
+// NewAppsGetCommand creates a new apps get command
+func NewAppsGetCommand(parent *AppsCommand) *AppsGetCommand {
+	g := &AppsGetCommand{
+		parent: parent,
+	}
+
+	g.cmd = &cobra.Command{
+		Use:   "get [app-name-or-id]",
+		Short: "Get application details",
+		Long: `Get detailed information about a specific application.
+
+You can specify the app by name or ID. The command will search for
+a matching app across all your projects.
+
+Examples:
+  kamui apps get my-api
+  kamui apps get 5f809f2f-0787-40ca-9a43-a3a59edb5400 -o json`,
+		Args: appArg,
+		RunE: g.Run,
+	}
+
+	return g
+}
+
+// Command returns the underlying cobra command
+func (g *AppsGetCommand) Command() *cobra.Command {
+	return g.cmd
+}
+
+// Run executes the apps get command
+func (g *AppsGetCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	projectService := g.parent.Root().Container().ProjectService()
+	appService := g.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+
+	appDetail, err := appService.GetApp(ctx, match.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch app details: %w", err)
+	}
+
+	switch resolveOutputFormat(cmd) {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(appDetail)
+	default:
+		return g.outputDetail(appDetail, match)
+	}
+}
+
+// outputDetail outputs app details in human-readable format
+func (g *AppsGetCommand) outputDetail(app *iface.AppDetail, match *appMatch) error {
+	name := app.DisplayName
+	if name == "" {
+		name = match.AppName
+	}
+
+	fmt.Printf("App:      %s\n", name)
+	fmt.Printf("ID:       %s\n", app.ID)
+	fmt.Printf("Project:  %s\n", match.ProjectName)
+	fmt.Printf("Type:     %s\n", app.AppType)
+	if app.LanguageType != "" {
+		fmt.Printf("Language: %s\n", app.LanguageType)
+	}
+	fmt.Printf("Status:   %s\n", app.Status.String())
+	if app.URL != "" {
+		fmt.Printf("URL:      %s\n", app.URL)
+	}
+	if app.CustomDomain != "" {
+		fmt.Printf("Domain:   %s\n", app.CustomDomain)
+	}
+	if app.GithubOrgRepo != "" {
+		fmt.Printf("Repo:     %s\n", app.GithubOrgRepo)
+		if app.GithubBranch != "" {
+			fmt.Printf("Branch:   %s\n", app.GithubBranch)
+		}
+	}
+	affinity := "disabled"
+	if app.SessionAffinity {
+		affinity = "enabled"
+	}
+	fmt.Printf("Session affinity: %s\n", affinity)
+
+	return nil
+}
+
+// truncateString shortens s to at most maxLen terminal columns, ending in
+// "..." when cut
+func truncateString(s string, maxLen int) string {
+	if runewidth.StringWidth(s) <= maxLen {
+		return s
+	}
+	return runewidth.Truncate(s, maxLen, "...")
+}
+
+// Helper to check if a string contains a substring (case-insensitive)
+func containsIgnoreCase(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// validateZipContainsIndexHTML checks if a ZIP file contains index.html at the root level
+func validateZipContainsIndexHTML(zipPath string) error {
+	reader, err := zip.OpenReader(zipPath)
+	if err != nil {
+		return fmt.Errorf("failed to open ZIP file: %w", err)
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		// Get the file name without directory path
+		name := filepath.Base(file.Name)
+		// Check if it's index.html at root level (no directory prefix or first level)
+		dir := filepath.Dir(file.Name)
+		if name == "index.html" && (dir == "." || dir == "") {
+			return nil // Found index.html at root
+		}
+	}
+
+	return fmt.Errorf("ZIP file must contain index.html at the root level")
+}
+
+// createZipFromDirectory creates a temporary ZIP file from a directory
+func createZipFromDirectory(dirPath string) (string, error) {
+	// Create temporary file
+	tempFile, err := os.CreateTemp("", "kamui-static-*.zip")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+
+	// Create ZIP writer
+	zipWriter := zip.NewWriter(tempFile)
+
+	// Walk the directory and add files to ZIP
+	err = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		// Skip the root directory itself
+		if path == dirPath {
+			return nil
+		}
+
+		// Get relative path
+		relPath, err := filepath.Rel(dirPath, path)
+		if err != nil {
+			return err
+		}
+
+		// Skip hidden files and directories
+		if strings.HasPrefix(filepath.Base(path), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Create ZIP header
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = relPath
+
+		if info.IsDir() {
+			header.Name += "/"
+		} else {
+			header.Method = zip.Deflate
+		}
+
+		// Create writer for this file
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		// If it's a directory, we're done
+		if info.IsDir() {
+			return nil
+		}
+
+		// Copy file contents
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(writer, file)
+		return err
+	})
+
+	if err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+		return "", fmt.Errorf("failed to create ZIP: %w", err)
+	}
+
+	// Close ZIP writer and temp file
+	if err := zipWriter.Close(); err != nil {
+		tempFile.Close()
+		os.Remove(tempPath)
+		return "", fmt.Errorf("failed to finalize ZIP: %w", err)
+	}
+	tempFile.Close()
+
+	return tempPath, nil
+}
+
+// AppsRedeployCommand represents the apps redeploy command
+type AppsRedeployCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	branch string
+}
+
+// NewAppsRedeployCommand creates a new apps redeploy command
+func NewAppsRedeployCommand(parent *AppsCommand) *AppsRedeployCommand {
+	r := &AppsRedeployCommand{
+		parent: parent,
+	}
+
+	r.cmd = &cobra.Command{
+		Use:   "redeploy [app-name-or-id]",
+		Short: "Trigger a fresh deployment of an application",
+		Long: `Trigger a fresh deployment of an application without changing its
+configuration, e.g. after pushing new commits.
+
+For GitHub-deployed apps, --branch redeploys from a different branch.
+
+Examples:
+  kamui apps redeploy my-api
+  kamui apps redeploy my-api --branch release
+  kamui apps redeploy 5f809f2f-0787-40ca-9a43-a3a59edb5400 -o json`,
+		Args: appArg,
+		RunE: r.Run,
+	}
+
+	r.cmd.Flags().StringVar(&r.branch, "branch", "", "Redeploy from this branch (GitHub-deployed apps only)")
+
+	return r
+}
+
+// Command returns the underlying cobra command
+func (r *AppsRedeployCommand) Command() *cobra.Command {
+	return r.cmd
+}
+
+// Run executes the apps redeploy command
+func (r *AppsRedeployCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	projectService := r.parent.Root().Container().ProjectService()
+	appService := r.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+
+	if r.branch != "" {
+		appDetail, err := appService.GetApp(ctx, match.AppID)
+		if err != nil {
+			return fmt.Errorf("failed to fetch app details: %w", err)
+		}
+		if appDetail.GithubOrgRepo == "" {
+			return fmt.Errorf("--branch is only supported for GitHub-deployed apps")
+		}
+	}
+
+	result, err := appService.Redeploy(ctx, &iface.RedeployAppInput{
+		AppID:  match.AppID,
+		Branch: r.branch,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	name := match.DisplayName
+	if name == "" {
+		name = match.AppName
+	}
+	fmt.Printf("✓ Redeploy of \"%s\" triggered\n", name)
+	if result.DeploymentID != "" {
+		fmt.Printf("  Deployment ID: %s\n", result.DeploymentID)
+	}
+	if r.branch != "" {
+		fmt.Printf("  Branch: %s\n", r.branch)
+	}
+	fmt.Println("\n  Check status with:")
+	fmt.Printf("  kamui apps get %s\n", match.AppID)
+
+	return nil
+}
+
+// AppsRollbackCommand represents the apps rollback command
+type AppsRollbackCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	to   string
+	list bool
+}
+
+// NewAppsRollbackCommand creates a new apps rollback command
+func NewAppsRollbackCommand(parent *AppsCommand) *AppsRollbackCommand {
+	r := &AppsRollbackCommand{
+		parent: parent,
+	}
+
+	r.cmd = &cobra.Command{
+		Use:   "rollback [app-name-or-id]",
+		Short: "Roll an application back to a previous deployment",
+		Long: `Redeploy an application from one of its previous deployments.
+
+By default the app is rolled back to the last deployment before the current
+one that reached the running state. Use --to to pick a specific deployment; --list (or
+'kamui apps deployments') shows the recent deployments first. The target deployment is shown and must be
+confirmed unless --yes is given.
+
+Examples:
+  kamui apps rollback my-api
+  kamui apps rollback my-api --list
+  kamui apps rollback my-api --to 9b2e4c1a --yes
+  kamui apps rollback my-api --list -o json | jq -r '.[1].id'`,
+		Args: appArg,
+		RunE: r.Run,
+	}
+
+	r.cmd.Flags().StringVar(&r.to, "to", "", "ID of the deployment to roll back to (default: the previous deployment)")
+	r.cmd.Flags().BoolVar(&r.list, "list", false, "List recent deployments instead of rolling back")
+	r.cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+
+	return r
+}
+
+// Command returns the underlying cobra command
+func (r *AppsRollbackCommand) Command() *cobra.Command {
+	return r.cmd
+}
+
+// Run executes the apps rollback command
+func (r *AppsRollbackCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if r.list && r.to != "" {
+		return fmt.Errorf("--list cannot be combined with --to")
+	}
+
+	projectService := r.parent.Root().Container().ProjectService()
+	appService := r.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+	name := match.DisplayName
+	if name == "" {
+		name = match.AppName
+	}
+
+	deployments, err := appService.ListDeployments(ctx, match.AppID)
+	if err != nil {
+		return err
+	}
+
+	if r.list {
+		return printDeployments(cmd, deployments)
+	}
+
+	target, err := rollbackTarget(deployments, r.to)
+	if err != nil {
+		return err
+	}
+
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	if !skipConfirm {
+		fmt.Fprintf(os.Stderr, "\nRolling back \"%s\" to deployment:\n\n", name)
+		fmt.Fprintf(os.Stderr, "  ID:       %s\n", target.ID)
+		if target.CommitSHA != "" {
+			fmt.Fprintf(os.Stderr, "  Commit:   %s\n", deploymentCommit(target))
+		}
+		if target.Branch != "" {
+			fmt.Fprintf(os.Stderr, "  Branch:   %s\n", target.Branch)
+		}
+		fmt.Fprintf(os.Stderr, "  Deployed: %s\n\n", target.CreatedAt.Format("2006-01-02 15:04:05"))
+
+		var confirm bool
+		if err := ask(&survey.Confirm{
+			Message: fmt.Sprintf("Roll back app \"%s\"?", name),
+			Default: false,
+		}, &confirm); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	result, err := appService.Rollback(ctx, &iface.RollbackAppInput{
+		AppID:        match.AppID,
+		DeploymentID: target.ID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	fmt.Printf("✓ Rollback of \"%s\" to deployment %s triggered\n", name, target.ID)
+	if result.DeploymentID != "" {
+		fmt.Printf("  Deployment ID: %s\n", result.DeploymentID)
+	}
+	fmt.Println("\n  Check status with:")
+	fmt.Printf("  kamui apps get %s\n", match.AppID)
+
+	return nil
+}
+
+// rollbackTarget picks the deployment to roll back to: the one with ID to,
+// or else the newest running deployment older than the current one; failed
+// and unfinished deployments are skipped.
+// deployments are ordered newest first.
+func rollbackTarget(deployments []iface.Deployment, to string) (*iface.Deployment, error) {
+	current := 0
+	for i := range deployments {
+		if deployments[i].Current {
+			current = i
+			break
+		}
+	}
+
+	if to != "" {
+		for i := range deployments {
+			if deployments[i].ID != to {
+				continue
+			}
+			if i == current {
+				return nil, fmt.Errorf("deployment %s is already the current deployment", to)
+			}
+			return &deployments[i], nil
+		}
+		return nil, fmt.Errorf("deployment %s not found among the recent deployments; use --list to see them", to)
+	}
+
+	for i := current + 1; i < len(deployments); i++ {
+		if deployments[i].Status == iface.DeployStatusRunning {
+			return &deployments[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no previous deployment to roll back to")
+}
+
+// deploymentCommit formats a deployment's short commit SHA and message
+func deploymentCommit(d *iface.Deployment) string {
+	sha := d.CommitSHA
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	if d.CommitMessage == "" {
+		return sha
+	}
+	// Only the subject line of the commit message
+	subject, _, _ := strings.Cut(d.CommitMessage, "\n")
+	return sha + " " + subject
+}
+
+// printDeployments lists deployments as a table, or as JSON when
+// --output json is set. The current deployment is marked with "*".
+func printDeployments(cmd *cobra.Command, deployments []iface.Deployment) error {
+	if resolveOutputFormat(cmd) == "json" {
+		if deployments == nil {
+			deployments = []iface.Deployment{}
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(deployments)
+	}
+
+	if len(deployments) == 0 {
+		fmt.Println("No deployments found.")
+		return nil
+	}
+
+	rows := make([][]string, len(deployments))
+	for i := range deployments {
+		d := &deployments[i]
+		marker := ""
+		if d.Current {
+			marker = "*"
+		}
+		rows[i] = []string{marker, d.ID, d.Status, deploymentCommit(d), d.Branch, d.CreatedAt.Format("2006-01-02 15:04:05")}
+	}
+	printTable(os.Stdout, "", []string{"", "ID", "STATUS", "COMMIT", "BRANCH", "DEPLOYED"}, rows)
+	return nil
+}
+
+// AppsDeploymentsCommand represents the apps deployments command
+type AppsDeploymentsCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	limit int
+	since string
+	until string
+}
+
+// defaultDeploymentsLimit caps `apps deployments` unless --limit is given
+const defaultDeploymentsLimit = 10
+
+// NewAppsDeploymentsCommand creates a new apps deployments command
+func NewAppsDeploymentsCommand(parent *AppsCommand) *AppsDeploymentsCommand {
+	d := &AppsDeploymentsCommand{
+		parent: parent,
+	}
+
+	d.cmd = &cobra.Command{
+		Use:   "deployments [app-name-or-id]",
+		Short: "Show the deployment history of an application",
+		Long: `Show the recent deployments of an application, newest first, with
+their commit, branch, status, and time. The current deployment is marked
+with "*". At most 10 deployments are shown unless --limit is given.
+
+--since and --until restrict the list to deployments created in a window.
+They take a duration back from now, such as 24h or 7d, or an RFC 3339
+timestamp. --since is inclusive and --until exclusive; --limit applies to
+what is left.
+
+You can specify the app by name or ID. The command will search for
+a matching app across all your projects.
+
+Examples:
+  kamui apps deployments my-api
+  kamui apps deployments my-api --limit 25
+  kamui apps deployments my-api -o json
+  kamui apps deployments my-api --since 24h
+  kamui apps deployments my-api --since 2026-01-01T00:00:00Z --until 2026-02-01T00:00:00Z`,
+		Args: appArg,
+		RunE: d.Run,
+	}
+
+	d.cmd.Flags().IntVar(&d.limit, "limit", defaultDeploymentsLimit, "Maximum number of deployments to list")
+	d.cmd.Flags().StringVar(&d.since, "since", "", "Only list deployments created at or after this time (e.g. 24h, 7d, or RFC 3339)")
+	d.cmd.Flags().StringVar(&d.until, "until", "", "Only list deployments created before this time (e.g. 1h, or RFC 3339)")
+
+	return d
+}
+
+// Command returns the underlying cobra command
+func (d *AppsDeploymentsCommand) Command() *cobra.Command {
+	return d.cmd
+}
+
+// Run executes the apps deployments command
+func (d *AppsDeploymentsCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if d.limit < 1 {
+		return fmt.Errorf("--limit must be at least 1 (got %d)", d.limit)
+	}
+	now := time.Now()
+	since, err := parseTimeBound("since", d.since, now)
+	if err != nil {
+		return err
+	}
+	until, err := parseTimeBound("until", d.until, now)
+	if err != nil {
+		return err
+	}
+	if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+		return &usageError{err: fmt.Errorf("--since (%s) must be before --until (%s)", since.Format(time.RFC3339), until.Format(time.RFC3339))}
+	}
+
+	projectService := d.parent.Root().Container().ProjectService()
+	appService := d.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+
+	deployments, err := appService.ListDeployments(ctx, match.AppID)
+	if err != nil {
+		return err
+	}
+	deployments = filterDeployments(deployments, since, until)
+	if len(deployments) > d.limit {
+		deployments = deployments[:d.limit]
+		fmt.Fprintf(os.Stderr, "⚠ showing the %d most recent deployments; use --limit to see more\n", d.limit)
+	}
+
+	return printDeployments(cmd, deployments)
+}
+
+// parseTimeBound parses a --since/--until value: a duration back from now,
+// such as 90m, 24h or 7d, or an RFC 3339 timestamp. An empty value is the
+// zero time, meaning no bound.
+func parseTimeBound(flag, value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.Add(-time.Duration(n) * 24 * time.Hour), nil
+		}
+	} else if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	return time.Time{}, &usageError{err: fmt.Errorf("invalid --%s %q: use a duration such as 30m, 24h or 7d, or an RFC 3339 timestamp such as 2026-01-02T15:04:05Z", flag, value)}
+}
+
+// filterDeployments keeps the deployments created in [since, until). A zero
+// bound is open.
+func filterDeployments(deployments []iface.Deployment, since, until time.Time) []iface.Deployment {
+	if since.IsZero() && until.IsZero() {
+		return deployments
+	}
+	result := make([]iface.Deployment, 0, len(deployments))
+	for _, dep := range deployments {
+		if !since.IsZero() && dep.CreatedAt.Before(since) {
+			continue
+		}
+		if !until.IsZero() && !dep.CreatedAt.Before(until) {
+			continue
+		}
+		result = append(result, dep)
+	}
+	return result
+}
+
+// AppsOpenCommand represents the apps open command
+type AppsOpenCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	printOnly bool
+}
+
+// NewAppsOpenCommand creates a new apps open command
+func NewAppsOpenCommand(parent *AppsCommand) *AppsOpenCommand {
+	o := &AppsOpenCommand{
+		parent: parent,
 	}
 
-	apps := project.Apps
+	o.cmd = &cobra.Command{
+		Use:   "open [app-name-or-id]",
+		Short: "Open an application's URL in a browser",
+		Long: `Open an application's URL in your default browser.
 
-	if len(apps) == 0 {
-		fmt.Printf("No apps found in project \"%s\".\n", project.Name)
-		fmt.Println("\nCreate a new app with: kamui apps create")
-		return nil
+The custom domain is used when one is configured. Use --print to write the
+URL to stdout instead, e.g. for piping into another command.
+
+Examples:
+  kamui apps open my-api
+  kamui apps open my-api --print`,
+		Args: appArg,
+		RunE: o.Run,
 	}
 
-	appService := l.parent.Root().Container().AppService()
+	o.cmd.Flags().BoolVar(&o.printOnly, "print", false, "Print the URL instead of opening it")
 
-	// Print apps
-	fmt.Printf("Apps in project \"%s\" (%s):\n\n", project.Name, project.ID)
-	for _, app := range apps {
-		status := "unknown"
-		if app.Status != nil {
-			if app.Status.StatusRunning > 0 {
-				status = "running"
-			} else if app.Status.StatusError > 0 {
-				status = "error"
-			} else if app.Status.StatusStopped > 0 {
-				status = "stopped"
-			}
-		}
+	return o
+}
 
-		// Fetch app detail to get display name
-		name := app.Name
-		var url string
-		appDetail, err := appService.GetApp(ctx, app.ID)
-		if err == nil && appDetail.DisplayName != "" {
-			name = appDetail.DisplayName
-			url = appDetail.URL
-			// Update status from detail if available
-			if appDetail.Status != nil {
-				if appDetail.Status.StatusRunning > 0 {
-					status = "running"
-				} else if appDetail.Status.StatusError > 0 {
-					status = "error"
-				} else if appDetail.Status.StatusStopped > 0 {
-					status = "stopped"
-				}
-			}
-		}
-		if name == "" {
-			name = "(unnamed)"
-		}
+// Command returns the underlying cobra command
+func (o *AppsOpenCommand) Command() *cobra.Command {
+	return o.cmd
+}
 
-		fmt.Printf("  • %s\n", name)
-		fmt.Printf("    ID: %s\n", app.ID)
-		fmt.Printf("    Status: %s\n", status)
-		if url != "" {
-			fmt.Printf("    URL: %s\n", url)
-		}
-		fmt.Println()
+// Run executes the apps open command
+func (o *AppsOpenCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	projectService := o.parent.Root().Container().ProjectService()
+	appService := o.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+
+	appDetail, err := appService.GetApp(ctx, match.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch app details: %w", err)
+	}
+
+	url := appURL(appDetail)
+	if url == "" {
+		return fmt.Errorf("app %s has no URL yet; it may still be deploying. Check status with: kamui apps get %s", match.AppName, match.AppID)
+	}
+
+	if o.printOnly {
+		fmt.Println(url)
+		return nil
 	}
 
+	fmt.Fprintf(os.Stderr, "Opening %s\n", url)
+	if err := openURL(url); err != nil {
+		return fmt.Errorf("failed to open browser: %w", err)
+	}
 	return nil
 }
 
-// truncateString truncates a string to a maximum length
-func truncateString(s string, maxLen int) string {
-	if len(s) <= maxLen {
-		return s
+// appURL returns the public URL of an app, preferring its custom domain
+func appURL(app *iface.AppDetail) string {
+	if app.CustomDomain != "" {
+		if strings.Contains(app.CustomDomain, "://") {
+			return app.CustomDomain
+		}
+		return "https://" + app.CustomDomain
 	}
-	return s[:maxLen] + "..."
+	return app.URL
 }
 
-// Helper to check if a string contains a substring (case-insensitive)
-func containsIgnoreCase(s, substr string) bool {
-	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+// AppsStatusCommand represents the apps status command
+type AppsStatusCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
 }
 
-// validateZipContainsIndexHTML checks if a ZIP file contains index.html at the root level
-func validateZipContainsIndexHTML(zipPath string) error {
-	reader, err := zip.OpenReader(zipPath)
-	if err != nil {
-		return fmt.Errorf("failed to open ZIP file: %w", err)
+// NewAppsStatusCommand creates a new apps status command
+func NewAppsStatusCommand(parent *AppsCommand) *AppsStatusCommand {
+	s := &AppsStatusCommand{
+		parent: parent,
 	}
-	defer reader.Close()
 
-	for _, file := range reader.File {
-		// Get the file name without directory path
-		name := filepath.Base(file.Name)
-		// Check if it's index.html at root level (no directory prefix or first level)
-		dir := filepath.Dir(file.Name)
-		if name == "index.html" && (dir == "." || dir == "") {
-			return nil // Found index.html at root
-		}
+	s.cmd = &cobra.Command{
+		Use:   "status",
+		Short: "Summarize the health of the apps in a project",
+		Long: `Show whether each app in a project is running, stopped, or failing.
+
+Prints one row per app followed by a summary line. Statuses are colored when
+stdout is a terminal; use --no-color or NO_COLOR to turn that off.
+
+Examples:
+  kamui apps status --project my-project
+  kamui apps status -p my-project -o json`,
+		Args: cobra.NoArgs,
+		RunE: s.Run,
 	}
 
-	return fmt.Errorf("ZIP file must contain index.html at the root level")
+	s.cmd.Flags().StringP("project", "p", "", "Project name or ID")
+	s.cmd.MarkFlagRequired("project")
+
+	return s
 }
 
-// createZipFromDirectory creates a temporary ZIP file from a directory
-func createZipFromDirectory(dirPath string) (string, error) {
-	// Create temporary file
-	tempFile, err := os.CreateTemp("", "kamui-static-*.zip")
-	if err != nil {
-		return "", fmt.Errorf("failed to create temp file: %w", err)
-	}
-	tempPath := tempFile.Name()
+// Command returns the underlying cobra command
+func (s *AppsStatusCommand) Command() *cobra.Command {
+	return s.cmd
+}
 
-	// Create ZIP writer
-	zipWriter := zip.NewWriter(tempFile)
+// appStatusEntry is one row of `apps status`
+type appStatusEntry struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+}
 
-	// Walk the directory and add files to ZIP
-	err = filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
+// appStatusSummary counts apps by status
+type appStatusSummary struct {
+	Running int `json:"running"`
+	Error   int `json:"error"`
+	Stopped int `json:"stopped"`
+	Unknown int `json:"unknown"`
+}
 
-		// Skip the root directory itself
-		if path == dirPath {
-			return nil
-		}
+// add counts one app with the given status
+func (s *appStatusSummary) add(status string) {
+	switch status {
+	case iface.AppStatusRunning:
+		s.Running++
+	case iface.AppStatusError:
+		s.Error++
+	case iface.AppStatusStopped:
+		s.Stopped++
+	default:
+		s.Unknown++
+	}
+}
 
-		// Get relative path
-		relPath, err := filepath.Rel(dirPath, path)
-		if err != nil {
-			return err
-		}
+// appStatusReport is the JSON output of `apps status`
+type appStatusReport struct {
+	ProjectID   string           `json:"project_id"`
+	ProjectName string           `json:"project_name"`
+	Apps        []appStatusEntry `json:"apps"`
+	Summary     appStatusSummary `json:"summary"`
+}
 
-		// Skip hidden files and directories
-		if strings.HasPrefix(filepath.Base(path), ".") {
-			if info.IsDir() {
-				return filepath.SkipDir
-			}
-			return nil
-		}
+// Run executes the apps status command
+func (s *AppsStatusCommand) Run(cmd *cobra.Command, args []string) error {
+	nameOrID, _ := cmd.Flags().GetString("project")
+	ctx := cmd.Context()
 
-		// Create ZIP header
-		header, err := zip.FileInfoHeader(info)
-		if err != nil {
-			return err
-		}
-		header.Name = relPath
+	projectService := s.parent.Root().Container().ProjectService()
+	appService := s.parent.Root().Container().AppService()
 
-		if info.IsDir() {
-			header.Name += "/"
-		} else {
-			header.Method = zip.Deflate
-		}
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
 
-		// Create writer for this file
-		writer, err := zipWriter.CreateHeader(header)
-		if err != nil {
-			return err
-		}
+	project, err := findProject(projects, nameOrID)
+	if err != nil {
+		return err
+	}
 
-		// If it's a directory, we're done
-		if info.IsDir() {
-			return nil
-		}
+	details := fetchAppDetails(ctx, appService, project.Apps)
 
-		// Copy file contents
-		file, err := os.Open(path)
-		if err != nil {
-			return err
+	report := appStatusReport{
+		ProjectID:   project.ID,
+		ProjectName: project.Name,
+		Apps:        make([]appStatusEntry, len(project.Apps)),
+	}
+	for i, app := range project.Apps {
+		entry := appStatusEntry{ID: app.ID, Name: app.Name, Status: app.Status.String()}
+		if detail := details[i]; detail != nil {
+			if detail.DisplayName != "" {
+				entry.Name = detail.DisplayName
+			}
+			if status := detail.Status.String(); status != iface.AppStatusUnknown {
+				entry.Status = status
+			}
 		}
-		defer file.Close()
+		report.Apps[i] = entry
+		report.Summary.add(entry.Status)
+	}
 
-		_, err = io.Copy(writer, file)
-		return err
-	})
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(report)
+	}
 
-	if err != nil {
-		tempFile.Close()
-		os.Remove(tempPath)
-		return "", fmt.Errorf("failed to create ZIP: %w", err)
+	if len(report.Apps) == 0 {
+		fmt.Printf("No apps found in project \"%s\".\n", project.Name)
+		return nil
 	}
 
-	// Close ZIP writer and temp file
-	if err := zipWriter.Close(); err != nil {
-		tempFile.Close()
-		os.Remove(tempPath)
-		return "", fmt.Errorf("failed to finalize ZIP: %w", err)
+	color := colorEnabled(cmd)
+	rows := make([][]string, 0, len(report.Apps))
+	for _, e := range report.Apps {
+		name := e.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		rows = append(rows, []string{name, e.ID, colorStatus(e.Status, color)})
 	}
-	tempFile.Close()
+	printTable(os.Stdout, "", []string{"NAME", "ID", "STATUS"}, rows)
 
-	return tempPath, nil
+	summary := fmt.Sprintf("\n%d running, %d error, %d stopped", report.Summary.Running, report.Summary.Error, report.Summary.Stopped)
+	if report.Summary.Unknown > 0 {
+		summary += fmt.Sprintf(", %d unknown", report.Summary.Unknown)
+	}
+	fmt.Println(summary)
+
+	return nil
 }
 
 // AppsDeleteCommand represents the apps delete command
 type AppsDeleteCommand struct {
 	parent *AppsCommand
 	cmd    *cobra.Command
+
+	pattern string
+	all     bool
+	force   bool
 }
 
 // NewAppsDeleteCommand creates a new apps delete command
@@ -1217,24 +2854,45 @@ func NewAppsDeleteCommand(parent *AppsCommand) *AppsDeleteCommand {
 	}
 
 	d.cmd = &cobra.Command{
-		Use:   "delete <app-name-or-id>",
+		Use:   "delete [app-name-or-id]",
 		Short: "Delete an application",
 		Long: `Delete an application and all its resources.
 
 You can specify the app by name or ID. The command will search for
-a matching app across all your projects.
+a matching app across all your projects, or only in --project. The
+default project setting is not used here.
+
+--pattern deletes every app whose name matches a glob such as "pr-*" in
+--project, or in all projects with --all. The matches are listed before
+asking for confirmation and deleted in parallel. A pattern that matches
+every app in scope, such as "*" or "?*", also needs --force.
 
 WARNING: This action is irreversible. The application and all associated
 Kubernetes resources will be permanently deleted.
 
+Use --confirm-name to require typing the app name instead of a yes/no
+answer. --yes skips confirmation entirely.
+
 Examples:
   kamui apps delete my-api
-  kamui apps delete 5f809f2f-0787-40ca-9a43-a3a59edb5400`,
-		Args: cobra.ExactArgs(1),
+  kamui apps delete 5f809f2f-0787-40ca-9a43-a3a59edb5400
+  kamui apps delete my-api --confirm-name
+  kamui apps delete --pattern 'pr-*' -p previews
+  kamui apps delete --pattern 'preview-*' --all --yes`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: d.Run,
+		// The default project must not narrow the search for an app, or
+		// stand in for the --project that --pattern requires
+		Annotations: map[string]string{noDefaultProjectAnnotation: "true"},
 	}
 
 	d.cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	d.cmd.Flags().Bool("confirm-name", false, "Require typing the app name (or --pattern) to confirm")
+	d.cmd.Flags().StringP("project", "p", "", "Only look for apps in this project (name or ID)")
+	d.cmd.Flags().StringVar(&d.pattern, "pattern", "", "Delete every app whose name matches this glob, e.g. 'pr-*'")
+	d.cmd.Flags().BoolVar(&d.all, "all", false, "With --pattern, match apps in all projects")
+	d.cmd.Flags().BoolVar(&d.force, "force", false, "Allow a --pattern that matches every app")
+	d.cmd.MarkFlagsMutuallyExclusive("project", "all")
 
 	return d
 }
@@ -1253,128 +2911,154 @@ type appMatch struct {
 	AppName     string
 }
 
-// Run executes the apps delete command
-func (d *AppsDeleteCommand) Run(cmd *cobra.Command, args []string) error {
-	nameOrID := args[0]
-	ctx := cmd.Context()
-
-	projectService := d.parent.Root().Container().ProjectService()
-	appService := d.parent.Root().Container().AppService()
-
-	// Fetch all projects to find the app by name or ID
-	projects, err := projectService.ListProjects(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to fetch projects: %w", err)
-	}
-
+// resolveApp finds a single app across all projects by ID, app name (exact
+// or prefix), or display name. When several apps match, they are listed and
+// an error asks the user to retry with an ID.
+func resolveApp(ctx context.Context, projects []iface.Project, appService iface.AppService, nameOrID string) (*appMatch, error) {
 	// First, check for exact ID match
-	var exactIDMatch *appMatch
 	for i := range projects {
 		p := &projects[i]
 		for j := range p.Apps {
 			app := &p.Apps[j]
 			if app.ID == nameOrID {
-				exactIDMatch = &appMatch{
+				return &appMatch{
 					AppID:       app.ID,
 					ProjectName: p.Name,
 					ProjectID:   p.ID,
 					AppName:     app.Name,
-				}
-				break
+				}, nil
 			}
 		}
-		if exactIDMatch != nil {
-			break
-		}
 	}
 
-	var foundAppID string
-	var foundProjectName string
-	var appName string
-
-	if exactIDMatch != nil {
-		// Exact ID match - use it
-		foundAppID = exactIDMatch.AppID
-		foundProjectName = exactIDMatch.ProjectName
-	} else {
-		// Search by name - collect all matches
-		var matches []appMatch
+	// Search by name - exact or prefix match - collecting all matches
+	matches := matchApps(projects, func(name string) bool {
+		return strings.HasPrefix(name, nameOrID)
+	})
 
+	// Also check by display_name (need to fetch each app's detail)
+	// Only do this if no matches found by app_name
+	if len(matches) == 0 {
 		for i := range projects {
 			p := &projects[i]
 			for j := range p.Apps {
 				app := &p.Apps[j]
-				// Check by app_name - exact or prefix match
-				if app.Name == nameOrID || strings.HasPrefix(app.Name, nameOrID) {
+				detail, err := appService.GetApp(ctx, app.ID)
+				if err == nil && detail.DisplayName == nameOrID {
 					matches = append(matches, appMatch{
 						AppID:       app.ID,
 						ProjectName: p.Name,
 						ProjectID:   p.ID,
 						AppName:     app.Name,
+						DisplayName: detail.DisplayName,
 					})
 				}
 			}
 		}
+	}
+
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("%w: %s\n\nUse 'kamui apps list -p <project>' to see available apps", errAppNotFound, nameOrID)
+	}
 
-		// Also check by display_name (need to fetch each app's detail)
-		// Only do this if no matches found by app_name
-		if len(matches) == 0 {
-			for i := range projects {
-				p := &projects[i]
-				for j := range p.Apps {
-					app := &p.Apps[j]
-					detail, err := appService.GetApp(ctx, app.ID)
-					if err == nil && detail.DisplayName == nameOrID {
-						matches = append(matches, appMatch{
-							AppID:       app.ID,
-							ProjectName: p.Name,
-							ProjectID:   p.ID,
-							AppName:     app.Name,
-							DisplayName: detail.DisplayName,
-						})
-					}
+	if len(matches) > 1 {
+		// Multiple matches - show them and ask to specify by ID
+		fmt.Fprintf(os.Stderr, "\nMultiple apps found matching \"%s\":\n\n", nameOrID)
+		for _, m := range matches {
+			displayName := m.DisplayName
+			if displayName == "" {
+				// Fetch display name
+				detail, err := appService.GetApp(ctx, m.AppID)
+				if err == nil && detail.DisplayName != "" {
+					displayName = detail.DisplayName
+				} else {
+					displayName = m.AppName
 				}
 			}
+			fmt.Fprintf(os.Stderr, "  • %s\n", displayName)
+			fmt.Fprintf(os.Stderr, "    ID: %s\n", m.AppID)
+			fmt.Fprintf(os.Stderr, "    Project: %s\n", m.ProjectName)
+			fmt.Fprintln(os.Stderr)
 		}
+		return nil, fmt.Errorf("please specify the app by ID to avoid ambiguity")
+	}
 
-		if len(matches) == 0 {
-			return fmt.Errorf("app not found: %s\n\nUse 'kamui apps list -p <project>' to see available apps", nameOrID)
-		}
+	return &matches[0], nil
+}
 
-		if len(matches) > 1 {
-			// Multiple matches - show them and ask to specify by ID
-			fmt.Printf("\nMultiple apps found matching \"%s\":\n\n", nameOrID)
-			for _, m := range matches {
-				displayName := m.DisplayName
-				if displayName == "" {
-					// Fetch display name
-					detail, err := appService.GetApp(ctx, m.AppID)
-					if err == nil && detail.DisplayName != "" {
-						displayName = detail.DisplayName
-					} else {
-						displayName = m.AppName
-					}
-				}
-				fmt.Printf("  • %s\n", displayName)
-				fmt.Printf("    ID: %s\n", m.AppID)
-				fmt.Printf("    Project: %s\n", m.ProjectName)
-				fmt.Println()
+// matchApps returns every app across projects whose name satisfies match
+func matchApps(projects []iface.Project, match func(name string) bool) []appMatch {
+	var matches []appMatch
+	for i := range projects {
+		p := &projects[i]
+		for j := range p.Apps {
+			app := &p.Apps[j]
+			if match(app.Name) {
+				matches = append(matches, appMatch{
+					AppID:       app.ID,
+					ProjectName: p.Name,
+					ProjectID:   p.ID,
+					AppName:     app.Name,
+				})
 			}
-			return fmt.Errorf("please specify the app by ID to avoid ambiguity")
 		}
+	}
+	return matches
+}
+
+// Run executes the apps delete command
+func (d *AppsDeleteCommand) Run(cmd *cobra.Command, args []string) error {
+	switch {
+	case d.pattern != "" && len(args) > 0:
+		return &usageError{err: fmt.Errorf("give either an app name or --pattern, not both")}
+	case d.pattern == "" && d.cmd.Flags().Changed("pattern"):
+		return &usageError{err: fmt.Errorf("--pattern must not be empty")}
+	case d.pattern == "" && len(args) == 0 && !canSelectApp():
+		return &usageError{err: fmt.Errorf("accepts 1 arg(s), received 0")}
+	case d.pattern == "" && d.all:
+		return &usageError{err: fmt.Errorf("--all can only be used with --pattern")}
+	case d.pattern != "":
+		if err := d.validatePattern(cmd); err != nil {
+			return err
+		}
+	}
+
+	ctx := cmd.Context()
+
+	projectService := d.parent.Root().Container().ProjectService()
+	appService := d.parent.Root().Container().AppService()
+
+	// Fetch all projects to find the app by name or ID
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	if projectFlag, _ := cmd.Flags().GetString("project"); projectFlag != "" {
+		project, err := findProject(projects, projectFlag)
+		if err != nil {
+			return err
+		}
+		projects = []iface.Project{project}
+	}
 
-		// Single match
-		foundAppID = matches[0].AppID
-		foundProjectName = matches[0].ProjectName
+	if d.pattern != "" {
+		return d.runPattern(cmd, projects)
 	}
 
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+	foundAppID := match.AppID
+	foundProjectName := match.ProjectName
+
 	// Fetch full app details using the app API
 	appDetail, err := appService.GetApp(ctx, foundAppID)
 	if err != nil {
 		return fmt.Errorf("failed to fetch app details: %w", err)
 	}
 
-	appName = appDetail.DisplayName
+	appName := appDetail.DisplayName
 	if appName == "" {
 		appName = foundAppID
 	}
@@ -1384,32 +3068,29 @@ func (d *AppsDeleteCommand) Run(cmd *cobra.Command, args []string) error {
 
 	if !skipConfirm {
 		// Show warning
-		fmt.Printf("\n⚠️  WARNING: You are about to delete the following app:\n\n")
-		fmt.Printf("  Name:    %s\n", appName)
-		fmt.Printf("  ID:      %s\n", foundAppID)
-		fmt.Printf("  Type:    %s\n", appDetail.AppType)
-		fmt.Printf("  Project: %s\n", foundProjectName)
+		fmt.Fprintf(os.Stderr, "\n⚠️  WARNING: You are about to delete the following app:\n\n")
+		fmt.Fprintf(os.Stderr, "  Name:    %s\n", appName)
+		fmt.Fprintf(os.Stderr, "  ID:      %s\n", foundAppID)
+		fmt.Fprintf(os.Stderr, "  Type:    %s\n", appDetail.AppType)
+		fmt.Fprintf(os.Stderr, "  Project: %s\n", foundProjectName)
 		if appDetail.URL != "" {
-			fmt.Printf("  URL:     %s\n", appDetail.URL)
+			fmt.Fprintf(os.Stderr, "  URL:     %s\n", appDetail.URL)
 		}
-		fmt.Println("\n  This action is IRREVERSIBLE. The app will be permanently deleted.")
+		fmt.Fprintln(os.Stderr, "\n  This action is IRREVERSIBLE. The app will be permanently deleted.")
 
-		// Confirmation prompt
-		var confirm bool
-		if err := survey.AskOne(&survey.Confirm{
-			Message: fmt.Sprintf("Are you sure you want to delete app \"%s\"?", appName),
-			Default: false,
-		}, &confirm); err != nil {
+		typeName, _ := cmd.Flags().GetBool("confirm-name")
+		confirm, err := confirmDeletion("app", appName, typeName)
+		if err != nil {
 			return err
 		}
 
 		if !confirm {
-			fmt.Println("Cancelled.")
+			fmt.Fprintln(os.Stderr, "Cancelled.")
 			return nil
 		}
 	}
 
-	fmt.Println("\nDeleting app...")
+	fmt.Fprintln(os.Stderr, "\nDeleting app...")
 
 	if err := appService.DeleteApp(ctx, foundAppID); err != nil {
 		return err
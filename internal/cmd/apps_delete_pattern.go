@@ -0,0 +1,180 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+)
+
+// appDeleteConcurrency bounds the number of in-flight DeleteApp requests
+// of `apps delete --pattern`
+const appDeleteConcurrency = 5
+
+// appDeletion is the per-app result of `apps delete --pattern`
+type appDeletion struct {
+	AppID       string `json:"app_id"`
+	AppName     string `json:"app_name"`
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+	Deleted     bool   `json:"deleted"`
+	Error       string `json:"error,omitempty"`
+}
+
+// validatePattern checks --pattern before any API call: it must be a valid
+// glob and be scoped with --project or --all. A pattern of only * is
+// refused here without --force; runPattern catches any other pattern that
+// turns out to match every app.
+func (d *AppsDeleteCommand) validatePattern(cmd *cobra.Command) error {
+	if _, err := path.Match(d.pattern, ""); err != nil {
+		return &usageError{err: fmt.Errorf("invalid --pattern %q: %w", d.pattern, err)}
+	}
+	if project, _ := cmd.Flags().GetString("project"); project == "" && !d.all {
+		return &usageError{err: fmt.Errorf("--pattern needs --project or --all")}
+	}
+	if strings.Trim(d.pattern, "*") == "" && !d.force {
+		return &usageError{err: fmt.Errorf("--pattern %q matches every app; pass --force if you really mean to delete them all", d.pattern)}
+	}
+	return nil
+}
+
+// runPattern deletes every app in projects whose name matches --pattern
+func (d *AppsDeleteCommand) runPattern(cmd *cobra.Command, projects []iface.Project) error {
+	matches := matchApps(projects, func(name string) bool {
+		ok, _ := path.Match(d.pattern, name)
+		return ok
+	})
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "No apps match %q.\n", d.pattern)
+		if resolveOutputFormat(cmd) == "json" {
+			return encodeAppDeletions([]appDeletion{})
+		}
+		return nil
+	}
+	if len(matches) == countApps(projects) && !d.force {
+		return &usageError{err: fmt.Errorf("--pattern %q matches every app (%d); pass --force if you really mean to delete them all", d.pattern, len(matches))}
+	}
+
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	if !skipConfirm {
+		fmt.Fprintf(os.Stderr, "\n⚠️  WARNING: You are about to delete %d apps matching \"%s\":\n\n", len(matches), d.pattern)
+		for _, m := range matches {
+			fmt.Fprintf(os.Stderr, "  • %s (%s) in %s\n", m.AppName, m.AppID, m.ProjectName)
+		}
+		fmt.Fprintln(os.Stderr, "\n  This action is IRREVERSIBLE. The apps will be permanently deleted.")
+
+		confirm, err := d.confirmPattern(cmd, len(matches))
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "\nDeleting %d apps...\n", len(matches))
+	results := deleteApps(cmd, d.parent.Root().Container().AppService(), matches)
+
+	failed := 0
+	for _, r := range results {
+		if !r.Deleted {
+			failed++
+		}
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		if err := encodeAppDeletions(results); err != nil {
+			return err
+		}
+	} else {
+		for _, r := range results {
+			if r.Deleted {
+				fmt.Printf("✓ Deleted %s (%s)\n", r.AppName, r.AppID)
+			} else {
+				fmt.Printf("✗ Failed to delete %s (%s): %s\n", r.AppName, r.AppID, r.Error)
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d of %d apps", failed, len(results))
+	}
+	return nil
+}
+
+// confirmPattern asks before a bulk deletion. With --confirm-name the
+// pattern must be typed out.
+func (d *AppsDeleteCommand) confirmPattern(cmd *cobra.Command, count int) (bool, error) {
+	if typeName, _ := cmd.Flags().GetBool("confirm-name"); typeName {
+		typed, err := askInput(fmt.Sprintf("Type the pattern \"%s\" to confirm deletion:", d.pattern))
+		if err != nil {
+			return false, err
+		}
+		if strings.TrimSpace(typed) != d.pattern {
+			return false, fmt.Errorf("typed pattern %q does not match \"%s\"; nothing was deleted", typed, d.pattern)
+		}
+		return true, nil
+	}
+
+	var confirm bool
+	if err := ask(&survey.Confirm{
+		Message: fmt.Sprintf("Are you sure you want to delete these %d apps?", count),
+		Default: false,
+	}, &confirm); err != nil {
+		return false, err
+	}
+	return confirm, nil
+}
+
+// countApps returns the number of apps across projects
+func countApps(projects []iface.Project) int {
+	n := 0
+	for _, p := range projects {
+		n += len(p.Apps)
+	}
+	return n
+}
+
+// deleteApps deletes matches with a bounded worker pool. The returned
+// results are index-aligned with matches; one failure doesn't stop the
+// others.
+func deleteApps(cmd *cobra.Command, appService iface.AppService, matches []appMatch) []appDeletion {
+	results := make([]appDeletion, len(matches))
+
+	var g errgroup.Group
+	g.SetLimit(appDeleteConcurrency)
+	for i, m := range matches {
+		i, m := i, m
+		g.Go(func() error {
+			results[i] = appDeletion{
+				AppID:       m.AppID,
+				AppName:     m.AppName,
+				ProjectID:   m.ProjectID,
+				ProjectName: m.ProjectName,
+			}
+			if err := appService.DeleteApp(cmd.Context(), m.AppID); err != nil {
+				results[i].Error = err.Error()
+			} else {
+				results[i].Deleted = true
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	return results
+}
+
+// encodeAppDeletions prints bulk deletion results as a JSON array
+func encodeAppDeletions(results []appDeletion) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(results)
+}
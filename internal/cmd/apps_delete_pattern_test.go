@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestAppsDeleteCommand_Pattern(t *testing.T) {
+	projects := []iface.Project{
+		{ID: "proj-1", Name: "staging", Apps: []iface.App{
+			{ID: "app-1", Name: "pr-101"},
+			{ID: "app-2", Name: "pr-102"},
+			{ID: "app-3", Name: "web"},
+		}},
+		{ID: "proj-2", Name: "production", Apps: []iface.App{
+			{ID: "app-4", Name: "pr-201"},
+			{ID: "app-5", Name: "api"},
+		}},
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		failApp     string
+		wantDeleted []string
+		wantErrMsg  string
+		wantUsage   bool
+		wantOutput  []string
+	}{
+		{
+			name:        "pattern within a project",
+			args:        []string{"--pattern", "pr-*", "-p", "staging"},
+			wantDeleted: []string{"app-1", "app-2"},
+			wantOutput:  []string{"✓ Deleted pr-101 (app-1)", "✓ Deleted pr-102 (app-2)"},
+		},
+		{
+			name:        "pattern across all projects",
+			args:        []string{"--pattern", "pr-*", "--all"},
+			wantDeleted: []string{"app-1", "app-2", "app-4"},
+		},
+		{
+			name:        "character class",
+			args:        []string{"--pattern", "pr-10[2-9]", "--all"},
+			wantDeleted: []string{"app-2"},
+		},
+		{
+			name: "no match deletes nothing",
+			args: []string{"--pattern", "feature-*", "--all"},
+		},
+		{
+			name:        "one failure does not stop the others",
+			args:        []string{"--pattern", "pr-*", "--all"},
+			failApp:     "app-2",
+			wantDeleted: []string{"app-1", "app-4"},
+			wantErrMsg:  "failed to delete 1 of 3 apps",
+			wantOutput:  []string{"✗ Failed to delete pr-102 (app-2): boom", "✓ Deleted pr-201 (app-4)"},
+		},
+		{
+			name:       "match everything needs --force",
+			args:       []string{"--pattern", "*", "--all"},
+			wantErrMsg: "pass --force",
+			wantUsage:  true,
+		},
+		{
+			name:       "wildcard that matches everything needs --force",
+			args:       []string{"--pattern", "?*", "--all"},
+			wantErrMsg: "pass --force",
+			wantUsage:  true,
+		},
+		{
+			name:       "class that matches every app in the project needs --force",
+			args:       []string{"--pattern", "[^_]*", "-p", "production"},
+			wantErrMsg: "matches every app (2)",
+			wantUsage:  true,
+		},
+		{
+			name:        "class with --force",
+			args:        []string{"--pattern", "[a-z]*", "-p", "production", "--force"},
+			wantDeleted: []string{"app-4", "app-5"},
+		},
+		{
+			name:        "match everything with --force",
+			args:        []string{"--pattern", "*", "-p", "production", "--force"},
+			wantDeleted: []string{"app-4", "app-5"},
+		},
+		{
+			name:       "pattern needs a scope",
+			args:       []string{"--pattern", "pr-*"},
+			wantErrMsg: "--pattern needs --project or --all",
+			wantUsage:  true,
+		},
+		{
+			name:       "argument and pattern conflict",
+			args:       []string{"web", "--pattern", "pr-*", "--all"},
+			wantErrMsg: "not both",
+			wantUsage:  true,
+		},
+		{
+			name:       "invalid glob",
+			args:       []string{"--pattern", "pr-[", "--all"},
+			wantErrMsg: "invalid --pattern",
+			wantUsage:  true,
+		},
+		{
+			name:       "--all without --pattern",
+			args:       []string{"web", "--all"},
+			wantErrMsg: "--all can only be used with --pattern",
+			wantUsage:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var deleted []string
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return projects, nil
+				},
+			}
+			mockApp := &MockAppService{
+				DeleteAppFunc: func(ctx context.Context, appID string) error {
+					if appID == tt.failApp {
+						return errors.New("boom")
+					}
+					mu.Lock()
+					deleted = append(deleted, appID)
+					mu.Unlock()
+					return nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "delete", "--yes"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			out, _ := io.ReadAll(r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				var usage *usageError
+				if errors.As(err, &usage) != tt.wantUsage {
+					t.Errorf("Execute() error is usageError = %v, want %v", !tt.wantUsage, tt.wantUsage)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			slices.Sort(deleted)
+			if !slices.Equal(deleted, tt.wantDeleted) {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(string(out), want) {
+					t.Errorf("output missing %q:\n%s", want, out)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// appTypeStatic is the AppType of static sites
+const appTypeStatic = "static"
+
+// AppsDeployStaticCommand represents the apps deploy-static command
+type AppsDeployStaticCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	fromDir     string
+	waitTimeout time.Duration
+}
+
+// NewAppsDeployStaticCommand creates a new apps deploy-static command
+func NewAppsDeployStaticCommand(parent *AppsCommand) *AppsDeployStaticCommand {
+	d := &AppsDeployStaticCommand{
+		parent: parent,
+	}
+
+	d.cmd = &cobra.Command{
+		Use:   "deploy-static [app-name-or-id] --from-dir <path>",
+		Short: "Upload a new build of a static application",
+		Long: `Upload a new build of an existing static application from a local
+directory, without deleting and recreating the app.
+
+The directory must contain index.html at its root, which is checked before
+anything is uploaded. The command waits until the new version is live, up to
+--wait-timeout.
+
+Examples:
+  kamui apps deploy-static my-site --from-dir ./dist
+  kamui apps deploy-static my-site --from-dir ./build --wait-timeout 5m -o json`,
+		Args: appArg,
+		RunE: d.Run,
+	}
+
+	d.cmd.Flags().StringVar(&d.fromDir, "from-dir", "", "Directory containing the built site (required)")
+	d.cmd.Flags().DurationVar(&d.waitTimeout, "wait-timeout", defaultWaitTimeout, "Maximum time to wait for the new version to go live")
+	_ = d.cmd.MarkFlagRequired("from-dir")
+
+	return d
+}
+
+// Command returns the underlying cobra command
+func (d *AppsDeployStaticCommand) Command() *cobra.Command {
+	return d.cmd
+}
+
+// Run executes the apps deploy-static command
+func (d *AppsDeployStaticCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+
+	if d.waitTimeout <= 0 {
+		return fmt.Errorf("--wait-timeout must be positive")
+	}
+
+	projectService := d.parent.Root().Container().ProjectService()
+	appService := d.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+
+	appDetail, err := appService.GetApp(ctx, match.AppID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch app details: %w", err)
+	}
+	name := match.DisplayName
+	if name == "" {
+		name = match.AppName
+	}
+	if appDetail.AppType != appTypeStatic {
+		return fmt.Errorf("app \"%s\" is a %s app; deploy-static only updates static apps", name, appDetail.AppType)
+	}
+
+	zipPath, err := zipStaticDir(d.fromDir)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(zipPath)
+
+	fmt.Fprintln(os.Stderr, "\nUploading new build...")
+	result, err := appService.UpdateStaticUpload(ctx, &iface.UpdateStaticAppUploadInput{
+		AppID:     match.AppID,
+		ProjectID: match.ProjectID,
+		FilePath:  zipPath,
+		Progress:  newUploadProgress(os.Stderr, isStderrTTY()).Update,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "✓ Uploaded new build of \"%s\"\n", name)
+
+	if err := waitForDeployment(ctx, appService, match.AppID, result.DeploymentID, d.waitTimeout, os.Stderr); err != nil {
+		return err
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	fmt.Printf("\n✓ New version of \"%s\" is live\n", name)
+	if result.DeploymentID != "" {
+		fmt.Printf("  Deployment ID: %s\n", result.DeploymentID)
+	}
+	if appDetail.URL != "" {
+		fmt.Printf("  URL: %s\n", appDetail.URL)
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestAppsDeployStaticCommand_Run(t *testing.T) {
+	oldBackoff := statusPollBackoff
+	statusPollBackoff = fixedBackoff(time.Millisecond)
+	defer func() { statusPollBackoff = oldBackoff }()
+
+	site := t.TempDir()
+	if err := os.WriteFile(filepath.Join(site, "index.html"), []byte("<h1>v2</h1>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	noIndex := t.TempDir()
+	if err := os.WriteFile(filepath.Join(noIndex, "app.js"), []byte("//"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		appType    string
+		polls      [][]iface.Deployment
+		wantUpload bool
+		wantOutput []string
+		wantErrMsg string
+	}{
+		{
+			name:    "uploads and waits until live",
+			args:    []string{"my-site", "--from-dir", site},
+			appType: "static",
+			polls: [][]iface.Deployment{
+				{{ID: "dep-1", Status: iface.DeployStatusRunning, Current: true}},
+				{{ID: "dep-2", Status: "building"}, {ID: "dep-1", Status: iface.DeployStatusRunning, Current: true}},
+				{{ID: "dep-2", Status: iface.DeployStatusRunning}, {ID: "dep-1", Status: iface.DeployStatusRunning, Current: true}},
+				{{ID: "dep-2", Status: iface.DeployStatusRunning, Current: true}, {ID: "dep-1", Status: iface.DeployStatusRunning}},
+			},
+			wantUpload: true,
+			wantOutput: []string{`New version of "my-site" is live`, "Deployment ID: dep-2", "URL: https://my-site.example.com"},
+		},
+		{
+			name:       "dynamic app is refused",
+			args:       []string{"my-site", "--from-dir", site},
+			appType:    "dynamic",
+			wantErrMsg: "is a dynamic app; deploy-static only updates static apps",
+		},
+		{
+			name:       "directory without index.html",
+			args:       []string{"my-site", "--from-dir", noIndex},
+			appType:    "static",
+			wantErrMsg: "index.html",
+		},
+		{
+			name:       "missing directory",
+			args:       []string{"my-site", "--from-dir", filepath.Join(site, "dist")},
+			appType:    "static",
+			wantErrMsg: "directory not found: " + filepath.Join(site, "dist"),
+		},
+		{
+			name:    "failed deployment",
+			args:    []string{"my-site", "--from-dir", site},
+			appType: "static",
+			polls: [][]iface.Deployment{
+				{{ID: "dep-2", Status: iface.DeployStatusError}, {ID: "dep-1", Status: iface.DeployStatusRunning, Current: true}},
+			},
+			wantUpload: true,
+			wantErrMsg: "deployment dep-2 failed",
+		},
+		{
+			name:       "missing --from-dir",
+			args:       []string{"my-site"},
+			wantErrMsg: `"from-dir" not set`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uploaded := false
+			polls := 0
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Name: "web", Apps: []iface.App{{ID: "app-1", Name: "my-site"}}}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					return &iface.AppDetail{ID: appID, AppType: tt.appType, URL: "https://my-site.example.com"}, nil
+				},
+				UpdateStaticUploadFunc: func(ctx context.Context, input *iface.UpdateStaticAppUploadInput) (*iface.UpdateStaticAppUploadOutput, error) {
+					uploaded = true
+					if input.AppID != "app-1" {
+						t.Errorf("AppID = %q, want app-1", input.AppID)
+					}
+					if _, err := os.Stat(input.FilePath); err != nil {
+						t.Errorf("upload file: %v", err)
+					}
+					return &iface.UpdateStaticAppUploadOutput{AppID: input.AppID, DeploymentID: "dep-2"}, nil
+				},
+				ListDeploymentsFunc: func(ctx context.Context, appID string) ([]iface.Deployment, error) {
+					deployments := tt.polls[min(polls, len(tt.polls)-1)]
+					polls++
+					return deployments, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "deploy-static"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if uploaded != tt.wantUpload {
+				t.Errorf("uploaded = %v, want %v", uploaded, tt.wantUpload)
+			}
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if polls != len(tt.polls) {
+				t.Errorf("polled %d times, want %d (until dep-2 is current and running)", polls, len(tt.polls))
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/kamui-project/kamui-cli/internal/api"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// AppsDomainCommand represents the apps domain command group
+type AppsDomainCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	// Subcommands
+	showCmd   *AppsDomainShowCommand
+	setCmd    *AppsDomainSetCommand
+	removeCmd *AppsDomainRemoveCommand
+}
+
+// NewAppsDomainCommand creates a new apps domain command group
+func NewAppsDomainCommand(parent *AppsCommand) *AppsDomainCommand {
+	d := &AppsDomainCommand{
+		parent: parent,
+	}
+
+	d.cmd = &cobra.Command{
+		Use:   "domain",
+		Short: "Manage an application's custom domain",
+		Long: `Show, set, or remove the custom domain of an application.
+
+Setting a domain returns the DNS records to create with your DNS provider;
+the domain starts serving once they resolve.`,
+	}
+
+	d.showCmd = NewAppsDomainShowCommand(d)
+	d.setCmd = NewAppsDomainSetCommand(d)
+	d.removeCmd = NewAppsDomainRemoveCommand(d)
+
+	d.cmd.AddCommand(d.showCmd.Command())
+	d.cmd.AddCommand(d.setCmd.Command())
+	d.cmd.AddCommand(d.removeCmd.Command())
+
+	return d
+}
+
+// Command returns the underlying cobra command
+func (d *AppsDomainCommand) Command() *cobra.Command {
+	return d.cmd
+}
+
+// resolveApp finds the app named by the first of args across all projects,
+// or lets the user select one when args is empty
+func (d *AppsDomainCommand) resolveApp(cmd *cobra.Command, args []string) (*appMatch, iface.AppService, error) {
+	ctx := cmd.Context()
+	projectService := d.parent.Root().Container().ProjectService()
+	appService := d.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if match.DisplayName == "" {
+		match.DisplayName = match.AppName
+	}
+	return match, appService, nil
+}
+
+// AppsDomainShowCommand represents the apps domain show command
+type AppsDomainShowCommand struct {
+	parent *AppsDomainCommand
+	cmd    *cobra.Command
+}
+
+// NewAppsDomainShowCommand creates a new apps domain show command
+func NewAppsDomainShowCommand(parent *AppsDomainCommand) *AppsDomainShowCommand {
+	s := &AppsDomainShowCommand{
+		parent: parent,
+	}
+
+	s.cmd = &cobra.Command{
+		Use:   "show [app-name-or-id]",
+		Short: "Show an application's custom domain and its DNS records",
+		Long: `Show the custom domain of an application, its verification status, and
+the DNS records it needs.
+
+Examples:
+  kamui apps domain show my-api
+  kamui apps domain show my-api -o json`,
+		Args: appArg,
+		RunE: s.Run,
+	}
+
+	return s
+}
+
+// Command returns the underlying cobra command
+func (s *AppsDomainShowCommand) Command() *cobra.Command {
+	return s.cmd
+}
+
+// Run executes the apps domain show command
+func (s *AppsDomainShowCommand) Run(cmd *cobra.Command, args []string) error {
+	match, appService, err := s.parent.resolveApp(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	domain, err := appService.GetDomain(cmd.Context(), match.AppID)
+	if err != nil {
+		// The API answers 404 when no domain is set
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+			return err
+		}
+		domain = &iface.AppDomain{}
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		return encodeAppDomain(domain)
+	}
+
+	if domain.Domain == "" {
+		fmt.Printf("No custom domain set for \"%s\"\n", match.DisplayName)
+		fmt.Printf("\n  Set one with:\n  kamui apps domain set %s <domain>\n", match.AppID)
+		return nil
+	}
+
+	fmt.Printf("Domain: %s\n", domain.Domain)
+	if domain.Status != "" {
+		fmt.Printf("Status: %s\n", domain.Status)
+	}
+	if len(domain.DNSRecords) > 0 {
+		fmt.Println("\nDNS records:")
+		printDNSRecords(domain.DNSRecords)
+	}
+	return nil
+}
+
+// AppsDomainSetCommand represents the apps domain set command
+type AppsDomainSetCommand struct {
+	parent *AppsDomainCommand
+	cmd    *cobra.Command
+}
+
+// NewAppsDomainSetCommand creates a new apps domain set command
+func NewAppsDomainSetCommand(parent *AppsDomainCommand) *AppsDomainSetCommand {
+	s := &AppsDomainSetCommand{
+		parent: parent,
+	}
+
+	s.cmd = &cobra.Command{
+		Use:   "set <app-name-or-id> <domain>",
+		Short: "Set an application's custom domain",
+		Long: `Point a custom domain at an application, replacing any domain it has.
+
+The DNS records to create with your DNS provider are printed; with
+-o json they can be fed to DNS automation.
+
+Examples:
+  kamui apps domain set my-api api.example.com
+  kamui apps domain set my-api api.example.com -o json | jq '.dns_records'`,
+		Args: cobra.ExactArgs(2),
+		RunE: s.Run,
+	}
+
+	return s
+}
+
+// Command returns the underlying cobra command
+func (s *AppsDomainSetCommand) Command() *cobra.Command {
+	return s.cmd
+}
+
+// Run executes the apps domain set command
+func (s *AppsDomainSetCommand) Run(cmd *cobra.Command, args []string) error {
+	domainName, err := normalizeDomain(args[1])
+	if err != nil {
+		return err
+	}
+
+	match, appService, err := s.parent.resolveApp(cmd, args[:1])
+	if err != nil {
+		return err
+	}
+
+	domain, err := appService.SetDomain(cmd.Context(), match.AppID, domainName)
+	if err != nil {
+		return err
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		return encodeAppDomain(domain)
+	}
+
+	fmt.Printf("✓ Custom domain %s set for \"%s\"\n", domain.Domain, match.DisplayName)
+	if len(domain.DNSRecords) > 0 {
+		fmt.Println("\n  Create these DNS records with your DNS provider:")
+		fmt.Println()
+		printDNSRecords(domain.DNSRecords)
+	}
+	if domain.Status != "" {
+		fmt.Printf("\n  Status: %s\n", domain.Status)
+	}
+	fmt.Println("\n  Check progress with:")
+	fmt.Printf("  kamui apps domain show %s\n", match.AppID)
+
+	return nil
+}
+
+// AppsDomainRemoveCommand represents the apps domain remove command
+type AppsDomainRemoveCommand struct {
+	parent *AppsDomainCommand
+	cmd    *cobra.Command
+}
+
+// NewAppsDomainRemoveCommand creates a new apps domain remove command
+func NewAppsDomainRemoveCommand(parent *AppsDomainCommand) *AppsDomainRemoveCommand {
+	r := &AppsDomainRemoveCommand{
+		parent: parent,
+	}
+
+	r.cmd = &cobra.Command{
+		Use:   "remove [app-name-or-id]",
+		Short: "Remove an application's custom domain",
+		Long: `Remove the custom domain of an application. The app stays reachable at
+its default URL. Asks for confirmation unless --yes is given.
+
+Examples:
+  kamui apps domain remove my-api
+  kamui apps domain remove my-api --yes`,
+		Args: appArg,
+		RunE: r.Run,
+	}
+
+	r.cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+
+	return r
+}
+
+// Command returns the underlying cobra command
+func (r *AppsDomainRemoveCommand) Command() *cobra.Command {
+	return r.cmd
+}
+
+// Run executes the apps domain remove command
+func (r *AppsDomainRemoveCommand) Run(cmd *cobra.Command, args []string) error {
+	match, appService, err := r.parent.resolveApp(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	if !skipConfirm {
+		var confirm bool
+		if err := ask(&survey.Confirm{
+			Message: fmt.Sprintf("Remove the custom domain of app \"%s\"?", match.DisplayName),
+			Default: false,
+		}, &confirm); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	if err := appService.RemoveDomain(cmd.Context(), match.AppID); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Custom domain removed from \"%s\"\n", match.DisplayName)
+	return nil
+}
+
+// normalizeDomain lowercases a domain name, drops a trailing dot, and checks
+// that it is a valid hostname with at least two labels
+func normalizeDomain(s string) (string, error) {
+	if strings.ContainsAny(s, "/:") {
+		return "", fmt.Errorf("invalid domain %q: give the host name only, without scheme, port, or path", s)
+	}
+	domain := strings.TrimSuffix(strings.ToLower(s), ".")
+	if domain == "" || len(domain) > 253 {
+		return "", fmt.Errorf("invalid domain %q: must be 1 to 253 characters", s)
+	}
+
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return "", fmt.Errorf("invalid domain %q: must include a top-level domain, e.g. app.example.com", s)
+	}
+	for _, label := range labels {
+		if label == "" || len(label) > 63 {
+			return "", fmt.Errorf("invalid domain %q: each label must be 1 to 63 characters", s)
+		}
+		if label[0] == '-' || label[len(label)-1] == '-' {
+			return "", fmt.Errorf("invalid domain %q: labels cannot start or end with a hyphen", s)
+		}
+		for _, c := range label {
+			if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' {
+				return "", fmt.Errorf("invalid domain %q: only letters, digits, and hyphens are allowed", s)
+			}
+		}
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return "", fmt.Errorf("invalid domain %q: an IP address is not a domain", s)
+	}
+	return domain, nil
+}
+
+// printDNSRecords writes DNS records as an indented table
+func printDNSRecords(records []iface.DNSRecord) {
+	rows := make([][]string, 0, len(records))
+	for _, r := range records {
+		rows = append(rows, []string{r.Type, r.Name, r.Value})
+	}
+	printTable(os.Stdout, "  ", []string{"TYPE", "NAME", "VALUE"}, rows)
+}
+
+// encodeAppDomain writes a domain and its DNS records as JSON
+func encodeAppDomain(domain *iface.AppDomain) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(domain)
+}
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestNormalizeDomain(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr string
+	}{
+		{in: "api.example.com", want: "api.example.com"},
+		{in: "API.Example.COM.", want: "api.example.com"},
+		{in: "my-app.example.co.jp", want: "my-app.example.co.jp"},
+		{in: "https://api.example.com", wantErr: "without scheme"},
+		{in: "api.example.com:8080", wantErr: "without scheme"},
+		{in: "localhost", wantErr: "top-level domain"},
+		{in: "api..example.com", wantErr: "1 to 63 characters"},
+		{in: "-api.example.com", wantErr: "hyphen"},
+		{in: "api_v2.example.com", wantErr: "only letters"},
+		{in: "*.example.com", wantErr: "only letters"},
+		{in: "192.168.0.1", wantErr: "IP address"},
+		{in: strings.Repeat("a", 64) + ".com", wantErr: "1 to 63 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := normalizeDomain(tt.in)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("normalizeDomain(%q) error = %v, want containing %q", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("normalizeDomain(%q) error = %v", tt.in, err)
+			}
+			if got != tt.want {
+				t.Errorf("normalizeDomain(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppsDomainCommand_Run(t *testing.T) {
+	records := []iface.DNSRecord{{Type: "CNAME", Name: "api.example.com", Value: "web-app.kamui.app"}}
+
+	tests := []struct {
+		name       string
+		args       []string
+		getErr     error
+		wantSet    string
+		wantRemove bool
+		wantOutput []string
+		wantErrMsg string
+	}{
+		{
+			name:       "set prints DNS records",
+			args:       []string{"set", "web-app", "API.example.com"},
+			wantSet:    "api.example.com",
+			wantOutput: []string{"Custom domain api.example.com set", "CNAME", "web-app.kamui.app", "Status: pending"},
+		},
+		{
+			name:       "set rejects invalid domain before calling the API",
+			args:       []string{"set", "web-app", "https://api.example.com"},
+			wantErrMsg: "invalid domain",
+		},
+		{
+			name:       "show prints domain and records",
+			args:       []string{"show", "web-app"},
+			wantOutput: []string{"Domain: api.example.com", "Status: pending", "CNAME"},
+		},
+		{
+			name:       "show without a domain",
+			args:       []string{"show", "web-app"},
+			getErr:     &api.APIError{StatusCode: http.StatusNotFound, Message: "not found"},
+			wantOutput: []string{"No custom domain set"},
+		},
+		{
+			name:       "remove",
+			args:       []string{"remove", "web-app", "--yes"},
+			wantRemove: true,
+			wantOutput: []string{"Custom domain removed"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotSet string
+			removed := false
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{
+						ID:   "proj-1",
+						Name: "my-project",
+						Apps: []iface.App{{ID: "app-1", Name: "web-app"}},
+					}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetDomainFunc: func(ctx context.Context, appID string) (*iface.AppDomain, error) {
+					if tt.getErr != nil {
+						return nil, tt.getErr
+					}
+					return &iface.AppDomain{Domain: "api.example.com", Status: "pending", DNSRecords: records}, nil
+				},
+				SetDomainFunc: func(ctx context.Context, appID, domain string) (*iface.AppDomain, error) {
+					gotSet = domain
+					return &iface.AppDomain{Domain: domain, Status: "pending", DNSRecords: records}, nil
+				},
+				RemoveDomainFunc: func(ctx context.Context, appID string) error {
+					removed = true
+					return nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "domain"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if gotSet != "" {
+					t.Errorf("SetDomain called with %q after a validation error", gotSet)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotSet != tt.wantSet {
+				t.Errorf("SetDomain domain = %q, want %q", gotSet, tt.wantSet)
+			}
+			if removed != tt.wantRemove {
+				t.Errorf("RemoveDomain called = %v, want %v", removed, tt.wantRemove)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAppsDomainSetCommand_JSON(t *testing.T) {
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		SetDomainFunc: func(ctx context.Context, appID, domain string) (*iface.AppDomain, error) {
+			return &iface.AppDomain{
+				Domain:     domain,
+				Status:     "pending",
+				DNSRecords: []iface.DNSRecord{{Type: "CNAME", Name: domain, Value: "web-app.kamui.app"}},
+			}, nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{"apps", "domain", "set", "web-app", "api.example.com", "-o", "json"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var got iface.AppDomain
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not a JSON object: %v\n%s", err, buf.String())
+	}
+	if len(got.DNSRecords) != 1 || got.DNSRecords[0].Value != "web-app.kamui.app" {
+		t.Errorf("dns_records = %+v", got.DNSRecords)
+	}
+}
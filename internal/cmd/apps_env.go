@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// AppsEnvCommand represents the apps env command group
+type AppsEnvCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	// Subcommands
+	exportCmd *AppsEnvExportCommand
+}
+
+// NewAppsEnvCommand creates a new apps env command group
+func NewAppsEnvCommand(parent *AppsCommand) *AppsEnvCommand {
+	e := &AppsEnvCommand{
+		parent: parent,
+	}
+
+	e.cmd = &cobra.Command{
+		Use:   "env",
+		Short: "Work with an application's environment variables",
+	}
+
+	e.exportCmd = NewAppsEnvExportCommand(e)
+
+	e.cmd.AddCommand(e.exportCmd.Command())
+
+	return e
+}
+
+// Command returns the underlying cobra command
+func (e *AppsEnvCommand) Command() *cobra.Command {
+	return e.cmd
+}
+
+// envExportFormats maps --format values of apps env export to their
+// formatters
+var envExportFormats = map[string]func(vars map[string]string) ([]byte, error){
+	"dotenv": formatDotenv,
+	"shell":  formatShellExports,
+	"json": func(vars map[string]string) ([]byte, error) {
+		data, err := json.MarshalIndent(vars, "", "  ")
+		return append(data, '\n'), err
+	},
+}
+
+// AppsEnvExportCommand represents the apps env export command
+type AppsEnvExportCommand struct {
+	parent *AppsEnvCommand
+	cmd    *cobra.Command
+
+	format         string
+	includeSecrets bool
+	outputFile     string
+}
+
+// NewAppsEnvExportCommand creates a new apps env export command
+func NewAppsEnvExportCommand(parent *AppsEnvCommand) *AppsEnvExportCommand {
+	x := &AppsEnvExportCommand{
+		parent: parent,
+	}
+
+	x.cmd = &cobra.Command{
+		Use:   "export [app-name-or-id]",
+		Short: "Print an application's environment variables for local use",
+		Long: `Print the environment variables of an application, e.g. to run it locally
+with the same settings.
+
+--format picks the output: dotenv (KEY=value lines, the default), shell
+(export KEY='value' lines to eval or source), or json (one object).
+Variables holding secrets are left out unless --include-secrets is given.
+--output-file writes to a file readable only by you instead of stdout.
+
+Examples:
+  kamui apps env export my-api > .env
+  eval "$(kamui apps env export my-api --format shell)"
+  kamui apps env export my-api --include-secrets --output-file .env.local`,
+		Args: appArg,
+		RunE: x.Run,
+	}
+
+	x.cmd.Flags().StringVar(&x.format, "format", "dotenv", "Output format: dotenv, shell, or json")
+	x.cmd.Flags().BoolVar(&x.includeSecrets, "include-secrets", false, "Also export variables holding secrets")
+	x.cmd.Flags().StringVar(&x.outputFile, "output-file", "", "Write to this file instead of stdout")
+
+	return x
+}
+
+// Command returns the underlying cobra command
+func (x *AppsEnvExportCommand) Command() *cobra.Command {
+	return x.cmd
+}
+
+// Run executes the apps env export command
+func (x *AppsEnvExportCommand) Run(cmd *cobra.Command, args []string) error {
+	format, ok := envExportFormats[x.format]
+	if !ok {
+		return &usageError{err: fmt.Errorf("invalid --format %q (must be one of: dotenv, shell, json)", x.format)}
+	}
+
+	ctx := cmd.Context()
+	projectService := x.parent.parent.Root().Container().ProjectService()
+	appService := x.parent.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+
+	env, err := appService.GetAppEnv(ctx, match.AppID)
+	if err != nil {
+		return err
+	}
+
+	vars := make(map[string]string, len(env.EnvVars))
+	var skipped []string
+	for key, value := range env.EnvVars {
+		if env.IsSecret(key) && !x.includeSecrets {
+			skipped = append(skipped, key)
+			continue
+		}
+		vars[key] = value
+	}
+	if len(skipped) > 0 {
+		sort.Strings(skipped)
+		fmt.Fprintf(os.Stderr, "Skipped %d secret variable(s): %s (use --include-secrets to export them)\n", len(skipped), strings.Join(skipped, ", "))
+	}
+
+	data, err := format(vars)
+	if err != nil {
+		return err
+	}
+
+	if x.outputFile == "" {
+		_, err = os.Stdout.Write(data)
+		return err
+	}
+	if err := atomicWrite0600(x.outputFile, data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", x.outputFile, err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Wrote %d variable(s) to %s\n", len(vars), x.outputFile)
+	return nil
+}
+
+// sortedEnvKeys returns the keys of vars in order
+func sortedEnvKeys(vars map[string]string) []string {
+	keys := make([]string, 0, len(vars))
+	for key := range vars {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// dotenvBarePattern matches values that need no quoting in a dotenv file
+var dotenvBarePattern = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+// formatDotenv writes vars as KEY=value lines that parseDotenv reads back
+// unchanged. Values are left bare when safe, single-quoted (taken
+// literally) when they hold no single quote or line break, and
+// double-quoted with escapes otherwise.
+func formatDotenv(vars map[string]string) ([]byte, error) {
+	var b strings.Builder
+	for _, key := range sortedEnvKeys(vars) {
+		value := vars[key]
+		switch {
+		case dotenvBarePattern.MatchString(value):
+		case !strings.ContainsAny(value, "'\n\r"):
+			value = "'" + value + "'"
+		default:
+			value = `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`).Replace(value) + `"`
+		}
+		fmt.Fprintf(&b, "%s=%s\n", key, value)
+	}
+	return []byte(b.String()), nil
+}
+
+// formatShellExports writes vars as export KEY='value' lines for a POSIX
+// shell. Single quotes keep every character literal, including $, ` and
+// backslashes; a single quote in the value ends the quoting, is escaped
+// with a backslash, and starts it again.
+func formatShellExports(vars map[string]string) ([]byte, error) {
+	var b strings.Builder
+	for _, key := range sortedEnvKeys(vars) {
+		if !envNamePattern.MatchString(key) {
+			return nil, fmt.Errorf("variable name %q cannot be exported by a shell", key)
+		}
+		fmt.Fprintf(&b, "export %s='%s'\n", key, strings.ReplaceAll(vars[key], "'", `'\''`))
+	}
+	return []byte(b.String()), nil
+}
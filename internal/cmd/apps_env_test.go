@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// trickyEnvValues are values that need quoting in a shell or dotenv file
+var trickyEnvValues = map[string]string{
+	"PLAIN":     "postgres://db:5432/app",
+	"SPACES":    "hello  world",
+	"SINGLE":    "it's",
+	"DOUBLE":    `say "hi"`,
+	"DOLLAR":    "$HOME and ${PATH}",
+	"BACKTICK":  "`whoami`",
+	"BACKSLASH": `C:\temp\n`,
+	"MIXED":     `a 'b' "c" $d \e`,
+	"NEWLINE":   "line1\nline2",
+	"EMPTY":     "",
+}
+
+func TestFormatShellExports(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{value: "hello world", want: `export KEY='hello world'`},
+		{value: "it's", want: `export KEY='it'\''s'`},
+		{value: `say "hi"`, want: `export KEY='say "hi"'`},
+		{value: "$HOME", want: `export KEY='$HOME'`},
+		{value: "", want: `export KEY=''`},
+	}
+
+	for _, tt := range tests {
+		got, err := formatShellExports(map[string]string{"KEY": tt.value})
+		if err != nil {
+			t.Fatalf("formatShellExports(%q) error = %v", tt.value, err)
+		}
+		if string(got) != tt.want+"\n" {
+			t.Errorf("formatShellExports(%q) = %q, want %q", tt.value, got, tt.want+"\n")
+		}
+	}
+
+	if _, err := formatShellExports(map[string]string{"NOT-A-NAME": "x"}); err == nil {
+		t.Error("formatShellExports() accepted a name a shell cannot export")
+	}
+}
+
+func TestFormatShellExports_Shell(t *testing.T) {
+	sh, err := exec.LookPath("sh")
+	if err != nil {
+		t.Skip("no sh to run the exports")
+	}
+
+	script, err := formatShellExports(trickyEnvValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for key, want := range trickyEnvValues {
+		out, err := exec.Command(sh, "-c", string(script)+`printf '%s' "$`+key+`"`).Output()
+		if err != nil {
+			t.Fatalf("sh error = %v\n%s", err, script)
+		}
+		if string(out) != want {
+			t.Errorf("%s = %q, want %q", key, out, want)
+		}
+	}
+}
+
+func TestFormatDotenv(t *testing.T) {
+	data, err := formatDotenv(trickyEnvValues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "PLAIN=postgres://db:5432/app\n") {
+		t.Errorf("safe value was quoted:\n%s", data)
+	}
+
+	got, err := parseDotenv(data)
+	if err != nil {
+		t.Fatalf("parseDotenv() error = %v\n%s", err, data)
+	}
+	if !reflect.DeepEqual(got, trickyEnvValues) {
+		t.Errorf("round trip = %q, want %q\n%s", got, trickyEnvValues, data)
+	}
+}
+
+func TestAppsEnvExportCommand_Run(t *testing.T) {
+	env := &iface.AppEnv{
+		EnvVars:    map[string]string{"PORT": "8080", "GREETING": "hello world", "DB_PASSWORD": "s3cret"},
+		SecretKeys: []string{"DB_PASSWORD"},
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		wantOutput    string
+		wantErrMsg    string
+		wantUsageErr  bool
+		wantFileVars  map[string]string
+		wantGetAppEnv bool
+	}{
+		{
+			name:          "dotenv skips secrets",
+			args:          []string{"web-app"},
+			wantOutput:    "GREETING='hello world'\nPORT=8080\n",
+			wantGetAppEnv: true,
+		},
+		{
+			name:          "include secrets",
+			args:          []string{"web-app", "--include-secrets"},
+			wantOutput:    "DB_PASSWORD=s3cret\nGREETING='hello world'\nPORT=8080\n",
+			wantGetAppEnv: true,
+		},
+		{
+			name:          "shell",
+			args:          []string{"web-app", "--format", "shell"},
+			wantOutput:    "export GREETING='hello world'\nexport PORT='8080'\n",
+			wantGetAppEnv: true,
+		},
+		{
+			name:          "json",
+			args:          []string{"web-app", "--format", "json"},
+			wantOutput:    "{\n  \"GREETING\": \"hello world\",\n  \"PORT\": \"8080\"\n}\n",
+			wantGetAppEnv: true,
+		},
+		{
+			name:          "output file",
+			args:          []string{"web-app", "--include-secrets", "--output-file"},
+			wantFileVars:  env.EnvVars,
+			wantGetAppEnv: true,
+		},
+		{
+			name:         "invalid format",
+			args:         []string{"web-app", "--format", "yaml"},
+			wantErrMsg:   "invalid --format",
+			wantUsageErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := tt.args
+			var outputFile string
+			if tt.wantFileVars != nil {
+				outputFile = filepath.Join(t.TempDir(), ".env")
+				args = append(args, outputFile)
+			}
+
+			called := false
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppEnvFunc: func(ctx context.Context, appID string) (*iface.AppEnv, error) {
+					called = true
+					if appID != "app-1" {
+						t.Errorf("GetAppEnv appID = %q, want app-1", appID)
+					}
+					return env, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "env", "export"}, args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if called != tt.wantGetAppEnv {
+				t.Errorf("GetAppEnv called = %v, want %v", called, tt.wantGetAppEnv)
+			}
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if got := ExitCode(err); tt.wantUsageErr && got != ExitUsage {
+					t.Errorf("ExitCode() = %d, want %d", got, ExitUsage)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			if tt.wantFileVars == nil {
+				if buf.String() != tt.wantOutput {
+					t.Errorf("output = %q, want %q", buf.String(), tt.wantOutput)
+				}
+				return
+			}
+
+			if buf.Len() != 0 {
+				t.Errorf("stdout should be empty with --output-file, got: %s", buf.String())
+			}
+			data, err := os.ReadFile(outputFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := parseDotenv(data)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.wantFileVars) {
+				t.Errorf("file vars = %v, want %v", got, tt.wantFileVars)
+			}
+			info, err := os.Stat(outputFile)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if perm := info.Mode().Perm(); perm != 0o600 {
+				t.Errorf("file mode = %o, want 600", perm)
+			}
+		})
+	}
+}
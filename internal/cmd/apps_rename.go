@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// AppsRenameCommand represents the apps rename command
+type AppsRenameCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+}
+
+// NewAppsRenameCommand creates a new apps rename command
+func NewAppsRenameCommand(parent *AppsCommand) *AppsRenameCommand {
+	r := &AppsRenameCommand{
+		parent: parent,
+	}
+
+	r.cmd = &cobra.Command{
+		Use:   "rename <app-name-or-id> <new-display-name>",
+		Short: "Change an application's display name",
+		Long: `Change the display name of an application.
+
+Only the display name changes; the app name, ID, URL, and every other
+setting stay as they are.
+
+Examples:
+  kamui apps rename my-api "Payments API"
+  kamui apps rename 4f9c2a7e "Payments API" -o json`,
+		Args: cobra.ExactArgs(2),
+		RunE: r.Run,
+	}
+
+	return r
+}
+
+// Command returns the underlying cobra command
+func (r *AppsRenameCommand) Command() *cobra.Command {
+	return r.cmd
+}
+
+// appRename is the JSON output of apps rename
+type appRename struct {
+	AppID               string `json:"app_id"`
+	AppName             string `json:"app_name"`
+	PreviousDisplayName string `json:"previous_display_name"`
+	DisplayName         string `json:"display_name"`
+}
+
+// Run executes the apps rename command
+func (r *AppsRenameCommand) Run(cmd *cobra.Command, args []string) error {
+	displayName := strings.TrimSpace(args[1])
+	if displayName == "" {
+		return &usageError{err: fmt.Errorf("the new display name must not be empty")}
+	}
+
+	ctx := cmd.Context()
+	projectService := r.parent.Root().Container().ProjectService()
+	appService := r.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	match, err := resolveApp(ctx, projects, appService, args[0])
+	if err != nil {
+		return err
+	}
+	previous := match.DisplayName
+	if previous == "" {
+		// Matches by name or ID do not carry the display name
+		detail, err := appService.GetApp(ctx, match.AppID)
+		if err != nil {
+			return err
+		}
+		previous = detail.DisplayName
+	}
+	if previous == "" {
+		previous = match.AppName
+	}
+
+	result := appRename{
+		AppID:               match.AppID,
+		AppName:             match.AppName,
+		PreviousDisplayName: previous,
+		DisplayName:         displayName,
+	}
+	if displayName != previous {
+		app, err := appService.UpdateApp(ctx, match.AppID, &iface.UpdateAppInput{DisplayName: &displayName})
+		if err != nil {
+			return err
+		}
+		result.DisplayName = app.DisplayName
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	if result.DisplayName == previous {
+		fmt.Printf("App \"%s\" is already named \"%s\"; nothing to change\n", match.AppName, previous)
+		return nil
+	}
+	fmt.Printf("✓ Renamed app \"%s\" to \"%s\"\n", previous, result.DisplayName)
+	fmt.Printf("  App ID: %s\n", match.AppID)
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestAppsRenameCommand_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		wantUpdate   string
+		wantOutput   []string
+		wantErrMsg   string
+		wantExitCode int
+	}{
+		{
+			name:       "rename by app name",
+			args:       []string{"web-app", "Payments API"},
+			wantUpdate: "Payments API",
+			wantOutput: []string{`Renamed app "Web App" to "Payments API"`, "App ID: app-1"},
+		},
+		{
+			name:       "rename by ID trims the new name",
+			args:       []string{"app-1", "  Payments API  "},
+			wantUpdate: "Payments API",
+		},
+		{
+			name:       "unchanged name",
+			args:       []string{"web-app", "Web App"},
+			wantOutput: []string{"already named"},
+		},
+		{
+			name:         "empty name",
+			args:         []string{"web-app", "   "},
+			wantErrMsg:   "must not be empty",
+			wantExitCode: ExitUsage,
+		},
+		{
+			name:         "unknown app",
+			args:         []string{"nope", "Payments API"},
+			wantErrMsg:   "app not found",
+			wantExitCode: ExitNotFound,
+		},
+		{
+			name:         "missing new name",
+			args:         []string{"web-app"},
+			wantErrMsg:   "accepts 2 arg(s)",
+			wantExitCode: ExitUsage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotUpdate string
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{
+						{ID: "proj-1", Name: "staging", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}},
+					}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					return &iface.AppDetail{ID: appID, DisplayName: "Web App"}, nil
+				},
+				UpdateAppFunc: func(ctx context.Context, appID string, input *iface.UpdateAppInput) (*iface.AppDetail, error) {
+					if input.DisplayName == nil {
+						t.Fatal("UpdateApp called without a display name")
+					}
+					gotUpdate = *input.DisplayName
+					return &iface.AppDetail{ID: appID, DisplayName: *input.DisplayName}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "rename"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if gotUpdate != tt.wantUpdate {
+				t.Errorf("UpdateApp display name = %q, want %q", gotUpdate, tt.wantUpdate)
+			}
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if ExitCode(err) != tt.wantExitCode {
+					t.Errorf("ExitCode() = %d, want %d", ExitCode(err), tt.wantExitCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAppsRenameCommand_JSON(t *testing.T) {
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{
+				{ID: "proj-1", Name: "staging", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}},
+			}, nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, &MockAppService{}))
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{"apps", "rename", "web-app", "Payments API", "-o", "json"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var got appRename
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not a JSON object: %v\n%s", err, buf.String())
+	}
+	want := appRename{AppID: "app-1", AppName: "web-app", PreviousDisplayName: "Test App", DisplayName: "Payments API"}
+	if got != want {
+		t.Errorf("output = %+v, want %+v", got, want)
+	}
+}
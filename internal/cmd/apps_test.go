@@ -4,12 +4,17 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/kamui-project/kamui-cli/internal/di"
 	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
@@ -19,12 +24,24 @@ import (
 type MockAppService struct {
 	GetInstallationsFunc        func(ctx context.Context) ([]iface.Installation, error)
 	GetBranchesFunc             func(ctx context.Context, owner, repo string) ([]iface.Branch, error)
+	GetGithubInstallURLFunc     func(ctx context.Context) (string, error)
 	CreateAppFunc               func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error)
 	CreateStaticAppFunc         func(ctx context.Context, input *iface.CreateStaticAppInput) (*iface.CreateAppOutput, error)
 	CreateStaticAppUploadFunc   func(ctx context.Context, input *iface.CreateStaticAppUploadInput) (*iface.CreateAppOutput, error)
 	ListAppsFunc                func(ctx context.Context, projectID string) ([]iface.App, error)
 	GetAppFunc                  func(ctx context.Context, appID string) (*iface.AppDetail, error)
 	DeleteAppFunc               func(ctx context.Context, appID string) error
+	RedeployFunc                func(ctx context.Context, input *iface.RedeployAppInput) (*iface.RedeployAppOutput, error)
+	GetDeployStatusFunc         func(ctx context.Context, appID string) (*iface.DeployStatus, error)
+	ListDeploymentsFunc         func(ctx context.Context, appID string) ([]iface.Deployment, error)
+	RollbackFunc                func(ctx context.Context, input *iface.RollbackAppInput) (*iface.RollbackAppOutput, error)
+	GetDomainFunc               func(ctx context.Context, appID string) (*iface.AppDomain, error)
+	SetDomainFunc               func(ctx context.Context, appID, domain string) (*iface.AppDomain, error)
+	RemoveDomainFunc            func(ctx context.Context, appID string) error
+	TransferAppFunc             func(ctx context.Context, appID, targetProjectID string) (*iface.TransferAppOutput, error)
+	UpdateStaticUploadFunc      func(ctx context.Context, input *iface.UpdateStaticAppUploadInput) (*iface.UpdateStaticAppUploadOutput, error)
+	UpdateAppFunc               func(ctx context.Context, appID string, input *iface.UpdateAppInput) (*iface.AppDetail, error)
+	GetAppEnvFunc               func(ctx context.Context, appID string) (*iface.AppEnv, error)
 }
 
 func (m *MockAppService) GetInstallations(ctx context.Context) ([]iface.Installation, error) {
@@ -88,6 +105,94 @@ func (m *MockAppService) DeleteApp(ctx context.Context, appID string) error {
 	return nil
 }
 
+func (m *MockAppService) Redeploy(ctx context.Context, input *iface.RedeployAppInput) (*iface.RedeployAppOutput, error) {
+	if m.RedeployFunc != nil {
+		return m.RedeployFunc(ctx, input)
+	}
+	return &iface.RedeployAppOutput{AppID: input.AppID}, nil
+}
+
+func (m *MockAppService) GetDeployStatus(ctx context.Context, appID string) (*iface.DeployStatus, error) {
+	if m.GetDeployStatusFunc != nil {
+		return m.GetDeployStatusFunc(ctx, appID)
+	}
+	return &iface.DeployStatus{Status: iface.DeployStatusRunning}, nil
+}
+
+func (m *MockAppService) ListDeployments(ctx context.Context, appID string) ([]iface.Deployment, error) {
+	if m.ListDeploymentsFunc != nil {
+		return m.ListDeploymentsFunc(ctx, appID)
+	}
+	return nil, nil
+}
+
+func (m *MockAppService) Rollback(ctx context.Context, input *iface.RollbackAppInput) (*iface.RollbackAppOutput, error) {
+	if m.RollbackFunc != nil {
+		return m.RollbackFunc(ctx, input)
+	}
+	return &iface.RollbackAppOutput{AppID: input.AppID, TargetID: input.DeploymentID}, nil
+}
+
+func (m *MockAppService) GetDomain(ctx context.Context, appID string) (*iface.AppDomain, error) {
+	if m.GetDomainFunc != nil {
+		return m.GetDomainFunc(ctx, appID)
+	}
+	return &iface.AppDomain{}, nil
+}
+
+func (m *MockAppService) SetDomain(ctx context.Context, appID, domain string) (*iface.AppDomain, error) {
+	if m.SetDomainFunc != nil {
+		return m.SetDomainFunc(ctx, appID, domain)
+	}
+	return &iface.AppDomain{Domain: domain}, nil
+}
+
+func (m *MockAppService) RemoveDomain(ctx context.Context, appID string) error {
+	if m.RemoveDomainFunc != nil {
+		return m.RemoveDomainFunc(ctx, appID)
+	}
+	return nil
+}
+
+func (m *MockAppService) TransferApp(ctx context.Context, appID, targetProjectID string) (*iface.TransferAppOutput, error) {
+	if m.TransferAppFunc != nil {
+		return m.TransferAppFunc(ctx, appID, targetProjectID)
+	}
+	return &iface.TransferAppOutput{AppID: appID, ProjectID: targetProjectID}, nil
+}
+
+func (m *MockAppService) UpdateStaticUpload(ctx context.Context, input *iface.UpdateStaticAppUploadInput) (*iface.UpdateStaticAppUploadOutput, error) {
+	if m.UpdateStaticUploadFunc != nil {
+		return m.UpdateStaticUploadFunc(ctx, input)
+	}
+	return &iface.UpdateStaticAppUploadOutput{AppID: input.AppID}, nil
+}
+
+func (m *MockAppService) GetGithubInstallURL(ctx context.Context) (string, error) {
+	if m.GetGithubInstallURLFunc != nil {
+		return m.GetGithubInstallURLFunc(ctx)
+	}
+	return "https://github.com/apps/kamui/installations/new", nil
+}
+
+func (m *MockAppService) UpdateApp(ctx context.Context, appID string, input *iface.UpdateAppInput) (*iface.AppDetail, error) {
+	if m.UpdateAppFunc != nil {
+		return m.UpdateAppFunc(ctx, appID, input)
+	}
+	detail := &iface.AppDetail{ID: appID}
+	if input.DisplayName != nil {
+		detail.DisplayName = *input.DisplayName
+	}
+	return detail, nil
+}
+
+func (m *MockAppService) GetAppEnv(ctx context.Context, appID string) (*iface.AppEnv, error) {
+	if m.GetAppEnvFunc != nil {
+		return m.GetAppEnvFunc(ctx, appID)
+	}
+	return &iface.AppEnv{EnvVars: map[string]string{}}, nil
+}
+
 func TestAppsListCommand_Run(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -123,116 +228,1869 @@ func TestAppsListCommand_Run(t *testing.T) {
 			wantErr:    false,
 		},
 		{
-			name:        "successfully lists apps by project ID",
-			projectFlag: "proj-456",
-			mockProjects: []iface.Project{
-				{
-					ID:   "proj-456",
-					Name: "another-project",
-					Apps: []iface.App{
-						{ID: "app-3", Name: "service"},
-					},
-				},
-			},
-			mockAppDetail: &iface.AppDetail{
-				DisplayName: "Service",
-				AppType:     "dynamic",
-			},
-			wantOutput: []string{"another-project", "proj-456", "Service"},
-			wantErr:    false,
+			name:        "successfully lists apps by project ID",
+			projectFlag: "proj-456",
+			mockProjects: []iface.Project{
+				{
+					ID:   "proj-456",
+					Name: "another-project",
+					Apps: []iface.App{
+						{ID: "app-3", Name: "service"},
+					},
+				},
+			},
+			mockAppDetail: &iface.AppDetail{
+				DisplayName: "Service",
+				AppType:     "dynamic",
+			},
+			wantOutput: []string{"another-project", "proj-456", "Service"},
+			wantErr:    false,
+		},
+		{
+			name:        "shows empty message when no apps",
+			projectFlag: "empty-project",
+			mockProjects: []iface.Project{
+				{
+					ID:   "proj-empty",
+					Name: "empty-project",
+					Apps: []iface.App{},
+				},
+			},
+			wantOutput: []string{"No apps found"},
+			wantErr:    false,
+		},
+		{
+			name:         "returns error when project not found",
+			projectFlag:  "nonexistent",
+			mockProjects: []iface.Project{},
+			wantErr:      true,
+			wantErrMsg:   "project not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock services
+			mockAuth := &MockAuthService{}
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					if tt.mockError != nil {
+						return nil, tt.mockError
+					}
+					return tt.mockProjects, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					if tt.mockAppDetail != nil {
+						return tt.mockAppDetail, nil
+					}
+					return &iface.AppDetail{DisplayName: "Default", AppType: "dynamic"}, nil
+				},
+			}
+
+			// Create container with mocks
+			container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+
+			// Create command hierarchy
+			root := NewRootCommand()
+			root.SetContainer(container)
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// Execute command
+			args := []string{"apps", "list", "-p", tt.projectFlag}
+			root.Command().SetArgs(args)
+
+			err := root.Command().Execute()
+
+			// Restore stdout and read output
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			// Check error
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.wantErrMsg != "" {
+				if !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Errorf("Error should contain %q, got: %v", tt.wantErrMsg, err)
+				}
+				return
+			}
+
+			// Check output contains expected strings
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+
+			// Check output does not contain unwanted strings
+			for _, notWant := range tt.wantNotOutput {
+				if strings.Contains(output, notWant) {
+					t.Errorf("Output should not contain %q, got: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAppsListCommand_All(t *testing.T) {
+	projects := []iface.Project{
+		{ID: "proj-1", Name: "alpha", Apps: []iface.App{{ID: "app-1", Name: "web"}, {ID: "app-2", Name: "worker"}}},
+		{ID: "proj-2", Name: "beta", Apps: []iface.App{{ID: "app-3", Name: "api"}}},
+		{ID: "proj-3", Name: "empty"},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOutput []string
+		wantErrMsg string
+	}{
+		{
+			name: "text output grouped by project",
+			args: []string{"--all"},
+			wantOutput: []string{
+				"Apps in project \"alpha\" (proj-1):\n\n  • Web-web\n",
+				"  • Web-worker\n",
+				"Apps in project \"beta\" (proj-2):\n\n  • Web-api\n",
+			},
+		},
+		{
+			name: "json output is a flat annotated array",
+			args: []string{"--all", "-o", "json"},
+			wantOutput: []string{
+				`"project_id": "proj-1"`,
+				`"project_name": "beta"`,
+				`"id": "app-3"`,
+				`"status": "running"`,
+			},
+		},
+		{
+			name: "fields prints one table",
+			args: []string{"--all", "--fields", "name,project,status"},
+			wantOutput: []string{
+				"NAME        PROJECT  STATUS\n",
+				"Web-web     alpha    running\n",
+				"Web-worker  alpha    running\n",
+				"Web-api     beta     running\n",
+			},
+		},
+		{
+			name: "csv output with default columns",
+			args: []string{"--all", "-o", "csv"},
+			wantOutput: []string{
+				"PROJECT,ID,NAME,TYPE,STATUS,URL\n" +
+					"alpha,app-1,Web-web,,running,\n" +
+					"alpha,app-2,Web-worker,,running,\n" +
+					"beta,app-3,Web-api,,running,\n",
+			},
+		},
+		{
+			name: "tsv output with fields",
+			args: []string{"--all", "-o", "tsv", "--fields", "id,name"},
+			wantOutput: []string{
+				"ID\tNAME\napp-1\tWeb-web\napp-2\tWeb-worker\napp-3\tWeb-api\n",
+			},
+		},
+		{
+			name: "jsonl output has one app per line",
+			args: []string{"--all", "-o", "jsonl"},
+			wantOutput: []string{
+				`{"project_id":"proj-1","project_name":"alpha","id":"app-1","name":"Web-web","status":"running"}` + "\n" +
+					`{"project_id":"proj-1","project_name":"alpha","id":"app-2","name":"Web-worker","status":"running"}` + "\n" +
+					`{"project_id":"proj-2","project_name":"beta","id":"app-3","name":"Web-api","status":"running"}` + "\n",
+			},
+		},
+		{
+			name:       "unknown field",
+			args:       []string{"--all", "--fields", "name,colour"},
+			wantErrMsg: `unknown field "colour" in --fields`,
+		},
+		{
+			name:       "all conflicts with project",
+			args:       []string{"--all", "-p", "alpha"},
+			wantErrMsg: "none of the others can be",
+		},
+		{
+			name:       "one of project or all is required",
+			args:       []string{},
+			wantErrMsg: "at least one of the flags in the group [project all] is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return projects, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					for _, p := range projects {
+						for _, a := range p.Apps {
+							if a.ID == appID {
+								return &iface.AppDetail{DisplayName: "Web-" + a.Name, Status: &iface.ProjectStatus{StatusRunning: 1}}, nil
+							}
+						}
+					}
+					return nil, errors.New("not found")
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "list"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Run() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+			if strings.Contains(output, "empty") {
+				t.Errorf("projects without apps should be skipped, got: %s", output)
+			}
+		})
+	}
+}
+
+func TestFetchAppDetails_PreservesOrderAndToleratesFailures(t *testing.T) {
+	apps := []iface.App{
+		{ID: "app-1", Name: "one"},
+		{ID: "app-2", Name: "two"},
+		{ID: "app-3", Name: "three"},
+		{ID: "app-4", Name: "four"},
+		{ID: "app-5", Name: "five"},
+		{ID: "app-6", Name: "six"},
+		{ID: "app-7", Name: "seven"},
+	}
+	mockApp := &MockAppService{
+		GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+			if appID == "app-3" {
+				return nil, errors.New("boom")
+			}
+			return &iface.AppDetail{ID: appID, DisplayName: "detail-" + appID}, nil
+		},
+	}
+
+	details := fetchAppDetails(context.Background(), mockApp, apps)
+
+	if len(details) != len(apps) {
+		t.Fatalf("len(details) = %d, want %d", len(details), len(apps))
+	}
+	for i, app := range apps {
+		if app.ID == "app-3" {
+			if details[i] != nil {
+				t.Errorf("details[%d] = %+v, want nil for failed fetch", i, details[i])
+			}
+			continue
+		}
+		if details[i] == nil || details[i].ID != app.ID {
+			t.Errorf("details[%d] = %+v, want detail for %s", i, details[i], app.ID)
+		}
+	}
+}
+
+func TestAppsGetCommand_Run(t *testing.T) {
+	tests := []struct {
+		name          string
+		appArg        string
+		outputFormat  string
+		mockProjects  []iface.Project
+		mockAppDetail *iface.AppDetail
+		wantOutput    []string
+		wantErr       bool
+		wantErrMsg    string
+	}{
+		{
+			name:   "shows app details by ID",
+			appArg: "app-123",
+			mockProjects: []iface.Project{
+				{ID: "proj-1", Name: "my-project", Apps: []iface.App{{ID: "app-123", Name: "web"}}},
+			},
+			mockAppDetail: &iface.AppDetail{
+				ID:              "app-123",
+				DisplayName:     "Web App",
+				AppType:         "dynamic",
+				URL:             "https://web.example.com",
+				SessionAffinity: true,
+				Status:          &iface.ProjectStatus{StatusRunning: 2},
+			},
+			wantOutput: []string{"Web App", "app-123", "my-project", "running", "https://web.example.com", "Session affinity: enabled"},
+		},
+		{
+			name:   "outputs JSON format",
+			appArg: "web",
+			mockProjects: []iface.Project{
+				{ID: "proj-1", Name: "my-project", Apps: []iface.App{{ID: "app-123", Name: "web"}}},
+			},
+			outputFormat: "json",
+			mockAppDetail: &iface.AppDetail{
+				ID:          "app-123",
+				DisplayName: "Web App",
+				AppType:     "dynamic",
+			},
+			wantOutput: []string{`"id": "app-123"`, `"session_affinity": false`},
+		},
+		{
+			name:         "returns error when app not found",
+			appArg:       "nonexistent",
+			mockProjects: []iface.Project{{ID: "proj-1", Name: "my-project"}},
+			wantErr:      true,
+			wantErrMsg:   "app not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := &MockAuthService{}
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return tt.mockProjects, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					if tt.mockAppDetail != nil {
+						return tt.mockAppDetail, nil
+					}
+					return &iface.AppDetail{ID: appID}, nil
+				},
+			}
+
+			container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+			root := NewRootCommand()
+			root.SetContainer(container)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			args := []string{"apps", "get", tt.appArg}
+			if tt.outputFormat == "json" {
+				args = append(args, "-o", "json")
+			}
+			root.Command().SetArgs(args)
+
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && tt.wantErrMsg != "" {
+				if !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Errorf("Error should contain %q, got: %v", tt.wantErrMsg, err)
+				}
+				return
+			}
+
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAppsCreateCommand_SessionAffinityFlag(t *testing.T) {
+	var got *iface.CreateAppInput
+	mockAuth := &MockAuthService{}
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+			got = input
+			return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+		},
+	}
+
+	container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+	root := NewRootCommand()
+	root.SetContainer(container)
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{
+		"apps", "create", "-p", "my-project",
+		"--name", "web", "--language", "go", "--start-command", "./server",
+		"--deploy-type", "docker_hub", "--replicas", "3", "--session-affinity",
+	})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got == nil || !got.SessionAffinity {
+		t.Errorf("CreateApp input SessionAffinity = false, want true")
+	}
+	if got != nil && got.Replicas != 3 {
+		t.Errorf("CreateApp input Replicas = %d, want 3", got.Replicas)
+	}
+}
+
+func TestAppsCreateCommand_JSONOutput(t *testing.T) {
+	mockAuth := &MockAuthService{}
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+			return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+		},
+	}
+
+	container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+	root := NewRootCommand()
+	root.SetContainer(container)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{
+		"apps", "create", "-p", "my-project", "-o", "json",
+		"--name", "web", "--language", "go", "--start-command", "./server",
+		"--deploy-type", "docker_hub",
+	})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var got createdApp
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not a JSON object: %v\n%s", err, buf.String())
+	}
+	want := createdApp{ID: "app-1", Name: "web", ProjectID: "proj-1"}
+	if got != want {
+		t.Errorf("output = %+v, want %+v", got, want)
+	}
+}
+
+func TestAppsCreateCommand_FromFile(t *testing.T) {
+	var got *iface.CreateAppInput
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+			got = input
+			return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetIn(strings.NewReader(`project: my-project
+name: web
+language: go
+deploy_type: docker_hub
+start_command: ./server
+replicas: 2
+env:
+  LOG_LEVEL: debug
+`))
+	root.Command().SetArgs([]string{"apps", "create", "-f", "-"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got == nil {
+		t.Fatal("CreateApp was not called")
+	}
+	if got.ProjectID != "proj-1" || got.AppName != "web" || got.Language != "go" || got.DeployType != "docker_hub" {
+		t.Errorf("CreateApp input = %+v", got)
+	}
+	if got.Replicas != 2 || got.AppSpecType != "nano" || got.HealthCheckPath != "/health" || got.Branch != "main" {
+		t.Errorf("CreateApp input defaults = %+v", got)
+	}
+	if got.EnvVars["LOG_LEVEL"] != "debug" {
+		t.Errorf("CreateApp input EnvVars = %v", got.EnvVars)
+	}
+}
+
+func TestAppsCreateCommand_Image(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		password string
+		want     iface.CreateAppInput
+		wantErr  string
+	}{
+		{
+			name: "ghcr image",
+			args: []string{"--image", "ghcr.io/org/app:v1.2.3"},
+			want: iface.CreateAppInput{DeployType: "container_registry", Registry: "ghcr.io", Image: "org/app", ImageTag: "v1.2.3"},
+		},
+		{
+			name: "docker hub image",
+			args: []string{"--image", "my-org/api", "--deploy-type", "docker_hub"},
+			want: iface.CreateAppInput{DeployType: "docker_hub", Registry: "docker.io", Image: "my-org/api", ImageTag: "latest"},
+		},
+		{
+			name:     "private registry",
+			args:     []string{"--image", "api:2", "--registry", "registry.example.com", "--registry-username", "ci"},
+			password: "s3cret",
+			want: iface.CreateAppInput{
+				DeployType: "container_registry", Registry: "registry.example.com", Image: "api", ImageTag: "2",
+				RegistryUsername: "ci", RegistryPassword: "s3cret",
+			},
+		},
+		{
+			name:    "invalid reference",
+			args:    []string{"--image", "ghcr.io/Org/App"},
+			wantErr: "invalid image reference",
+		},
+		{
+			name:    "deploy type mismatch",
+			args:    []string{"--image", "ghcr.io/org/app", "--deploy-type", "docker_hub"},
+			wantErr: "does not match the registry",
+		},
+		{
+			name:    "image with github",
+			args:    []string{"--image", "org/app", "--deploy-type", "github"},
+			wantErr: "cannot be used with --deploy-type=github",
+		},
+		{
+			name:    "registry without image",
+			args:    []string{"--registry", "ghcr.io", "--deploy-type", "docker_hub"},
+			wantErr: "require --image",
+		},
+		{
+			name:    "username without password",
+			args:    []string{"--image", "ghcr.io/org/app", "--registry-username", "ci"},
+			wantErr: "KAMUI_REGISTRY_PASSWORD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envRegistryPassword, tt.password)
+
+			var got *iface.CreateAppInput
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+					got = input
+					return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{
+				"apps", "create", "-p", "my-project", "-o", "json",
+				"--name", "web", "--language", "go", "--start-command", "./server",
+			}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErr)
+				}
+				if got != nil {
+					t.Error("CreateApp called despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got == nil {
+				t.Fatal("CreateApp not called")
+			}
+			if got.DeployType != tt.want.DeployType || got.Registry != tt.want.Registry || got.Image != tt.want.Image ||
+				got.ImageTag != tt.want.ImageTag || got.ImageDigest != tt.want.ImageDigest ||
+				got.RegistryUsername != tt.want.RegistryUsername || got.RegistryPassword != tt.want.RegistryPassword {
+				t.Errorf("CreateApp input = %+v, want image fields %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppsCreateCommand_Wait(t *testing.T) {
+	oldBackoff := statusPollBackoff
+	statusPollBackoff = fixedBackoff(time.Millisecond)
+	defer func() { statusPollBackoff = oldBackoff }()
+
+	tests := []struct {
+		name       string
+		args       []string
+		statuses   []iface.DeployStatus
+		wantPolls  int
+		wantErrMsg string
+		wantTimeout bool
+	}{
+		{
+			name:      "without --wait does not poll",
+			statuses:  []iface.DeployStatus{{Status: "building"}},
+			wantPolls: 0,
+		},
+		{
+			name:      "waits until running",
+			args:      []string{"--wait"},
+			statuses:  []iface.DeployStatus{{Status: "pending"}, {Status: "building"}, {Status: "building"}, {Status: iface.DeployStatusRunning}},
+			wantPolls: 4,
+		},
+		{
+			name:       "error status fails with reason",
+			args:       []string{"--wait"},
+			statuses:   []iface.DeployStatus{{Status: "building"}, {Status: iface.DeployStatusError, Message: "build failed: exit status 1"}},
+			wantPolls:  2,
+			wantErrMsg: "deployment failed: build failed: exit status 1",
+		},
+		{
+			name:       "times out",
+			args:       []string{"--wait", "--wait-timeout", "20ms"},
+			statuses:   []iface.DeployStatus{{Status: "building"}},
+			wantErrMsg: "deployment still in progress after 20ms",
+			wantTimeout: true,
+		},
+		{
+			name:      "running before the timeout",
+			args:      []string{"--wait", "--wait-timeout", "1m"},
+			statuses:  []iface.DeployStatus{{Status: "building"}, {Status: iface.DeployStatusRunning}},
+			wantPolls: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetDeployStatusFunc: func(ctx context.Context, appID string) (*iface.DeployStatus, error) {
+					if appID != "test-app-id" {
+						t.Errorf("GetDeployStatus appID = %q, want test-app-id", appID)
+					}
+					status := tt.statuses[min(polls, len(tt.statuses)-1)]
+					polls++
+					return &status, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			args := []string{"apps", "create", "-p", "my-project", "--name", "web", "--language", "go",
+				"--deploy-type", "docker_hub", "--start-command", "./server"}
+			root.Command().SetArgs(append(args, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErrMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if tt.wantTimeout {
+				if got := ExitCode(err); got != ExitWaitTimeout {
+					t.Errorf("ExitCode() = %d, want %d", got, ExitWaitTimeout)
+				}
+				return
+			}
+			if polls != tt.wantPolls {
+				t.Errorf("GetDeployStatus called %d times, want %d", polls, tt.wantPolls)
+			}
+		})
+	}
+}
+
+func TestAppsCreateCommand_EnvFile(t *testing.T) {
+	envFile := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(envFile, []byte("# app settings\nexport LOG_LEVEL=info\nGREETING=\"hello world\"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var got *iface.CreateAppInput
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+			got = input
+			return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+	oldStdout := os.Stdout
+	_, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{"apps", "create", "-p", "my-project", "--name", "web", "--language", "go",
+		"--deploy-type", "docker_hub", "--start-command", "./server",
+		"--env-file", envFile, "--env", "LOG_LEVEL=debug", "--env", "EXTRA=1"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	want := map[string]string{"LOG_LEVEL": "debug", "GREETING": "hello world", "EXTRA": "1"}
+	if got == nil {
+		t.Fatal("CreateApp was not called")
+	}
+	if !reflect.DeepEqual(got.EnvVars, want) {
+		t.Errorf("CreateApp EnvVars = %v, want %v", got.EnvVars, want)
+	}
+}
+
+func TestAppsCreateCommand_Secrets(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secrets.env")
+	if err := os.WriteFile(secretFile, []byte("API_KEY='file-secret-value'\nDB_PASSWORD=overridden\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	baseArgs := []string{"apps", "create", "-p", "my-project", "--name", "web", "--language", "go",
+		"--deploy-type", "docker_hub", "--start-command", "./server"}
+
+	tests := []struct {
+		name           string
+		args           []string
+		wantEnv        map[string]string
+		wantSecretKeys []string
+		wantErrMsg     string
+	}{
+		{
+			name:           "secrets are flagged and merged",
+			args:           []string{"--env", "LOG_LEVEL=debug", "--secret-file", secretFile, "--secret", "DB_PASSWORD=flag-secret-value"},
+			wantEnv:        map[string]string{"LOG_LEVEL": "debug", "API_KEY": "file-secret-value", "DB_PASSWORD": "flag-secret-value"},
+			wantSecretKeys: []string{"API_KEY", "DB_PASSWORD"},
+		},
+		{
+			name:           "json output",
+			args:           []string{"--secret", "DB_PASSWORD=flag-secret-value", "-o", "json"},
+			wantEnv:        map[string]string{"DB_PASSWORD": "flag-secret-value"},
+			wantSecretKeys: []string{"DB_PASSWORD"},
+		},
+		{
+			name:       "same key as env and secret",
+			args:       []string{"--env", "DB_PASSWORD=plain", "--secret", "DB_PASSWORD=flag-secret-value"},
+			wantErrMsg: "DB_PASSWORD is set both as an environment variable and as a secret",
+		},
+		{
+			name:       "malformed secret",
+			args:       []string{"--secret", "flag-secret-value"},
+			wantErrMsg: "--secret must be in KEY=VALUE format",
+		},
+		{
+			name: "from spec file",
+			args: []string{"-f", "-"},
+			wantEnv: map[string]string{
+				"LOG_LEVEL": "debug", "DB_PASSWORD": "spec-secret-value",
+			},
+			wantSecretKeys: []string{"DB_PASSWORD"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *iface.CreateAppInput
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+					got = input
+					return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stdout, os.Stderr = w, w
+
+			args := append(append([]string(nil), baseArgs...), tt.args...)
+			if tt.args[0] == "-f" {
+				args = []string{"apps", "create", "-f", "-"}
+				root.Command().SetIn(strings.NewReader("project: my-project\nname: web\nlanguage: go\ndeploy_type: docker_hub\nstart_command: ./server\nenv:\n  LOG_LEVEL: debug\nsecrets:\n  DB_PASSWORD: spec-secret-value\n"))
+			}
+			root.Command().SetArgs(args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if strings.Contains(buf.String(), "secret-value") || (err != nil && strings.Contains(err.Error(), "secret-value")) {
+				t.Errorf("a secret value was echoed:\n%s\nerror: %v", buf.String(), err)
+			}
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got == nil {
+				t.Fatal("CreateApp was not called")
+			}
+			if !reflect.DeepEqual(got.EnvVars, tt.wantEnv) {
+				t.Errorf("EnvVars = %v, want %v", got.EnvVars, tt.wantEnv)
+			}
+			if !reflect.DeepEqual(got.SecretKeys, tt.wantSecretKeys) {
+				t.Errorf("SecretKeys = %v, want %v", got.SecretKeys, tt.wantSecretKeys)
+			}
+		})
+	}
+}
+
+func TestAppsCreateStaticCommand_FromDir(t *testing.T) {
+	siteDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(siteDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var got *iface.CreateStaticAppUploadInput
+	var zipExisted bool
+	mockAuth := &MockAuthService{}
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Name: "my-project", PlanType: "pro"}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		CreateStaticAppUploadFunc: func(ctx context.Context, input *iface.CreateStaticAppUploadInput) (*iface.CreateAppOutput, error) {
+			got = input
+			_, err := os.Stat(input.FilePath)
+			zipExisted = err == nil
+			if err := validateZipContainsIndexHTML(input.FilePath); err != nil {
+				t.Errorf("uploaded ZIP is invalid: %v", err)
+			}
+			return &iface.CreateAppOutput{ID: "static-1", Name: input.AppName}, nil
+		},
+		GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+			return &iface.AppDetail{ID: appID, URL: "https://docs.example.com"}, nil
+		},
+	}
+
+	container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+	root := NewRootCommand()
+	root.SetContainer(container)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{
+		"apps", "create-static", "-p", "my-project",
+		"--name", "docs", "--from-dir", siteDir, "--app-spec", "small",
+	})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if got == nil {
+		t.Fatal("CreateStaticAppUpload was not called")
+	}
+	if !zipExisted {
+		t.Error("ZIP file did not exist during upload")
+	}
+	if _, err := os.Stat(got.FilePath); !os.IsNotExist(err) {
+		t.Errorf("temporary ZIP %s was not removed", got.FilePath)
+	}
+	if got.AppName != "docs" || got.AppSpecType != "small" || got.Replicas != 1 {
+		t.Errorf("unexpected upload input: %+v", got)
+	}
+	for _, want := range []string{"ID: static-1", "URL: https://docs.example.com"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestAppsCreateStaticCommand_JSONKeepsStdoutClean(t *testing.T) {
+	siteDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(siteDir, "index.html"), []byte("<html></html>"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mockAuth := &MockAuthService{}
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Name: "my-project", PlanType: "pro"}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		CreateStaticAppUploadFunc: func(ctx context.Context, input *iface.CreateStaticAppUploadInput) (*iface.CreateAppOutput, error) {
+			return &iface.CreateAppOutput{ID: "static-1", Name: input.AppName}, nil
+		},
+		GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+			return &iface.AppDetail{ID: appID, URL: "https://docs.example.com"}, nil
+		},
+	}
+
+	container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+	root := NewRootCommand()
+	root.SetContainer(container)
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	outR, outW, _ := os.Pipe()
+	errR, errW, _ := os.Pipe()
+	os.Stdout, os.Stderr = outW, errW
+
+	root.Command().SetArgs([]string{
+		"apps", "create-static", "-p", "my-project", "-o", "json",
+		"--name", "docs", "--from-dir", siteDir,
+	})
+	err := root.Command().Execute()
+
+	outW.Close()
+	errW.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+	var stdout, stderr bytes.Buffer
+	io.Copy(&stdout, outR)
+	io.Copy(&stderr, errR)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if !json.Valid(stdout.Bytes()) {
+		t.Errorf("stdout is not valid JSON:\n%s", stdout.String())
+	}
+	for _, want := range []string{"Using project: my-project", "Creating ZIP from directory..."} {
+		if !strings.Contains(stderr.String(), want) {
+			t.Errorf("stderr missing %q:\n%s", want, stderr.String())
+		}
+	}
+}
+
+func TestAppsCreateStaticCommand_FromGitHub(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		wantOwnerType string
+		wantBranch    string
+		wantErrMsg    string
+	}{
+		{
+			name:          "owner type looked up from installations",
+			args:          []string{"--owner", "my-org", "--repo", "site"},
+			wantOwnerType: "Organization",
+			wantBranch:    "main",
+		},
+		{
+			name:          "explicit owner type and branch",
+			args:          []string{"--owner", "me", "--repo", "blog", "--owner-type", "User", "--branch", "gh-pages"},
+			wantOwnerType: "User",
+			wantBranch:    "gh-pages",
+		},
+		{
+			name:       "repository not installed",
+			args:       []string{"--owner", "my-org", "--repo", "missing"},
+			wantErrMsg: "not accessible to the Kamui GitHub App",
+		},
+		{
+			name:          "owner/name shorthand",
+			args:          []string{"--repo", "my-org/site"},
+			wantOwnerType: "Organization",
+			wantBranch:    "main",
+		},
+		{
+			name:       "missing repo",
+			args:       []string{"--owner", "my-org"},
+			wantErrMsg: "--repo is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *iface.CreateStaticAppInput
+			mockAuth := &MockAuthService{}
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetInstallationsFunc: func(ctx context.Context) ([]iface.Installation, error) {
+					return []iface.Installation{{Owner: "my-org", Repository: "site", OwnerType: "Organization"}}, nil
+				},
+				CreateStaticAppFunc: func(ctx context.Context, input *iface.CreateStaticAppInput) (*iface.CreateAppOutput, error) {
+					got = input
+					return &iface.CreateAppOutput{ID: "static-2", Name: input.AppName}, nil
+				},
+			}
+
+			container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+			root := NewRootCommand()
+			root.SetContainer(container)
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			args := append([]string{"apps", "create-static", "-p", "my-project", "--name", "site", "--from-github"}, tt.args...)
+			root.Command().SetArgs(args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got.OwnerType != tt.wantOwnerType || got.RepositoryBranch != tt.wantBranch || got.DeployType != "github" {
+				t.Errorf("unexpected input: %+v", got)
+			}
+		})
+	}
+}
+
+func TestAppsCreateCommand_RepoShorthand(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		wantOwner     string
+		wantOwnerType string
+		wantRepo      string
+		wantLookup    bool
+		wantErrMsg    string
+	}{
+		{
+			name:          "owner type inferred from installations",
+			args:          []string{"--repo", "my-org/web"},
+			wantOwner:     "my-org",
+			wantOwnerType: "Organization",
+			wantRepo:      "web",
+			wantLookup:    true,
+		},
+		{
+			name:          "user owner matched case-insensitively",
+			args:          []string{"--repo", "Me/Blog"},
+			wantOwner:     "Me",
+			wantOwnerType: "User",
+			wantRepo:      "Blog",
+			wantLookup:    true,
+		},
+		{
+			name:          "explicit owner type skips the lookup",
+			args:          []string{"--repo", "my-org/web", "--owner-type", "Organization"},
+			wantOwner:     "my-org",
+			wantOwnerType: "Organization",
+			wantRepo:      "web",
+		},
+		{
+			name:          "separate owner and repo",
+			args:          []string{"--owner", "my-org", "--repo", "web"},
+			wantOwner:     "my-org",
+			wantOwnerType: "Organization",
+			wantRepo:      "web",
+			wantLookup:    true,
+		},
+		{
+			name:       "repository not installed",
+			args:       []string{"--repo", "my-org/missing"},
+			wantErrMsg: "repository my-org/missing is not accessible to the Kamui GitHub App",
+		},
+		{
+			name:       "conflicting owner",
+			args:       []string{"--owner", "other", "--repo", "my-org/web"},
+			wantErrMsg: "--owner other does not match",
+		},
+		{
+			name:       "owner missing",
+			args:       []string{"--repo", "web"},
+			wantErrMsg: "--owner is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *iface.CreateAppInput
+			lookedUp := false
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetInstallationsFunc: func(ctx context.Context) ([]iface.Installation, error) {
+					lookedUp = true
+					return []iface.Installation{
+						{Owner: "my-org", Repository: "web", OwnerType: "Organization"},
+						{Owner: "me", Repository: "blog", OwnerType: "User"},
+					}, nil
+				},
+				CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+					got = input
+					return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			args := append([]string{"apps", "create", "-p", "my-project", "--name", "web", "--language", "go", "--start-command", "./server"}, tt.args...)
+			root.Command().SetArgs(args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if got != nil {
+					t.Error("app created despite the error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got.Owner != tt.wantOwner || got.OwnerType != tt.wantOwnerType || got.Repository != tt.wantRepo {
+				t.Errorf("owner, owner type, repository = %q, %q, %q, want %q, %q, %q",
+					got.Owner, got.OwnerType, got.Repository, tt.wantOwner, tt.wantOwnerType, tt.wantRepo)
+			}
+			if lookedUp != tt.wantLookup {
+				t.Errorf("installations fetched = %v, want %v", lookedUp, tt.wantLookup)
+			}
+		})
+	}
+}
+
+func TestAppsRedeployCommand_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		githubRepo   string
+		deploymentID string
+		wantBranch   string
+		wantOutput   []string
+		wantErrMsg   string
+	}{
+		{
+			name:         "redeploy prints deployment id",
+			args:         []string{"web-app"},
+			deploymentID: "dep-42",
+			wantOutput:   []string{"Redeploy of \"web-app\" triggered", "Deployment ID: dep-42", "kamui apps get app-1"},
+		},
+		{
+			name:       "branch override for github app",
+			args:       []string{"web-app", "--branch", "release"},
+			githubRepo: "my-org/web",
+			wantBranch: "release",
+			wantOutput: []string{"Branch: release"},
+		},
+		{
+			name:       "branch override rejected for non-github app",
+			args:       []string{"web-app", "--branch", "release"},
+			wantErrMsg: "only supported for GitHub-deployed apps",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *iface.RedeployAppInput
+			mockAuth := &MockAuthService{}
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{
+						ID:   "proj-1",
+						Name: "my-project",
+						Apps: []iface.App{{ID: "app-1", Name: "web-app"}},
+					}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					return &iface.AppDetail{ID: appID, DisplayName: "Web App", GithubOrgRepo: tt.githubRepo}, nil
+				},
+				RedeployFunc: func(ctx context.Context, input *iface.RedeployAppInput) (*iface.RedeployAppOutput, error) {
+					got = input
+					return &iface.RedeployAppOutput{AppID: input.AppID, DeploymentID: tt.deploymentID}, nil
+				},
+			}
+
+			container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+			root := NewRootCommand()
+			root.SetContainer(container)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "redeploy"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if got != nil {
+					t.Error("Redeploy should not be called on validation failure")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got == nil || got.AppID != "app-1" || got.Branch != tt.wantBranch {
+				t.Errorf("Redeploy input = %+v, want app-1 branch %q", got, tt.wantBranch)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestAppsRollbackCommand_Run(t *testing.T) {
+	deployments := []iface.Deployment{
+		{ID: "dep-4", Status: "running", CommitSHA: "4444444aaaa", CommitMessage: "Break things", Current: true},
+		{ID: "dep-3", Status: iface.DeployStatusError, CommitSHA: "3333333bbbb"},
+		{ID: "dep-2", Status: "running", CommitSHA: "2222222cccc", CommitMessage: "Fix login\n\nDetails"},
+		{ID: "dep-1", Status: "running", CommitSHA: "1111111dddd"},
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		deployments []iface.Deployment
+		wantTarget  string
+		wantOutput  []string
+		wantErrMsg  string
+	}{
+		{
+			name:       "defaults to previous successful deployment",
+			args:       []string{"web-app", "--yes"},
+			wantTarget: "dep-2",
+			wantOutput: []string{"Rollback of \"web-app\" to deployment dep-2 triggered", "kamui apps get app-1"},
+		},
+		{
+			name: "skips deployments that never finished",
+			args: []string{"web-app", "--yes"},
+			deployments: []iface.Deployment{
+				{ID: "dep-4", Status: "running", Current: true},
+				{ID: "dep-3", Status: "pending"},
+				{ID: "dep-2", Status: "building"},
+				{ID: "dep-1", Status: "running"},
+			},
+			wantTarget: "dep-1",
+		},
+		{
+			name:       "explicit target",
+			args:       []string{"web-app", "--to", "dep-1", "-y"},
+			wantTarget: "dep-1",
+		},
+		{
+			name:       "target is current deployment",
+			args:       []string{"web-app", "--to", "dep-4", "--yes"},
+			wantErrMsg: "already the current deployment",
+		},
+		{
+			name:       "unknown target",
+			args:       []string{"web-app", "--to", "dep-9", "--yes"},
+			wantErrMsg: "deployment dep-9 not found",
+		},
+		{
+			name:        "no previous deployment",
+			args:        []string{"web-app", "--yes"},
+			deployments: deployments[:1],
+			wantErrMsg:  "no previous deployment",
+		},
+		{
+			name:       "list prints table without rolling back",
+			args:       []string{"web-app", "--list"},
+			wantOutput: []string{"dep-4", "4444444 Break things", "2222222 Fix login"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *iface.RollbackAppInput
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{
+						ID:   "proj-1",
+						Name: "my-project",
+						Apps: []iface.App{{ID: "app-1", Name: "web-app"}},
+					}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				ListDeploymentsFunc: func(ctx context.Context, appID string) ([]iface.Deployment, error) {
+					if tt.deployments != nil {
+						return tt.deployments, nil
+					}
+					return deployments, nil
+				},
+				RollbackFunc: func(ctx context.Context, input *iface.RollbackAppInput) (*iface.RollbackAppOutput, error) {
+					got = input
+					return &iface.RollbackAppOutput{AppID: input.AppID, TargetID: input.DeploymentID}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "rollback"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if got != nil {
+					t.Error("Rollback should not be called on failure")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if tt.wantTarget == "" {
+				if got != nil {
+					t.Errorf("Rollback called with %+v, want no rollback", got)
+				}
+			} else if got == nil || got.AppID != "app-1" || got.DeploymentID != tt.wantTarget {
+				t.Errorf("Rollback input = %+v, want app-1 to %s", got, tt.wantTarget)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestAppsRollbackCommand_ListJSON(t *testing.T) {
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		ListDeploymentsFunc: func(ctx context.Context, appID string) ([]iface.Deployment, error) {
+			return []iface.Deployment{{ID: "dep-2", Status: "running", Current: true}, {ID: "dep-1", Status: "running"}}, nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{"apps", "rollback", "web-app", "--list", "-o", "json"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var got []iface.Deployment
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not valid JSON: %v\n%s", err, buf.String())
+	}
+	if len(got) != 2 || got[0].ID != "dep-2" || !got[0].Current || got[1].ID != "dep-1" {
+		t.Errorf("deployments = %+v", got)
+	}
+}
+
+func TestAppsDeploymentsCommand_Run(t *testing.T) {
+	var deployments []iface.Deployment
+	for i := 12; i > 0; i-- {
+		deployments = append(deployments, iface.Deployment{ID: fmt.Sprintf("dep-%d", i), Status: "running", Branch: "main"})
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantIDs    int
+		wantErrMsg string
+	}{
+		{name: "default limit", args: []string{"web-app"}, wantIDs: 10},
+		{name: "custom limit", args: []string{"web-app", "--limit", "3"}, wantIDs: 3},
+		{name: "limit above history", args: []string{"web-app", "--limit", "50"}, wantIDs: 12},
+		{name: "invalid limit", args: []string{"web-app", "--limit", "0"}, wantErrMsg: "--limit must be at least 1"},
+		{name: "invalid since", args: []string{"web-app", "--since", "yesterday"}, wantErrMsg: "use a duration such as 30m, 24h or 7d"},
+		{name: "since after until", args: []string{"web-app", "--since", "1h", "--until", "2h"}, wantErrMsg: "must be before --until"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				ListDeploymentsFunc: func(ctx context.Context, appID string) ([]iface.Deployment, error) {
+					if appID != "app-1" {
+						t.Errorf("ListDeployments appID = %q, want app-1", appID)
+					}
+					return append([]iface.Deployment(nil), deployments...), nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "deployments", "-o", "json"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			var got []iface.Deployment
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("stdout is not valid JSON: %v\n%s", err, buf.String())
+			}
+			if len(got) != tt.wantIDs {
+				t.Fatalf("got %d deployments, want %d", len(got), tt.wantIDs)
+			}
+			if got[0].ID != "dep-12" {
+				t.Errorf("first deployment = %s, want newest dep-12", got[0].ID)
+			}
+		})
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		value      string
+		want       time.Time
+		wantErrMsg string
+	}{
+		{name: "empty is no bound", value: ""},
+		{name: "hours", value: "24h", want: now.Add(-24 * time.Hour)},
+		{name: "minutes", value: "90m", want: now.Add(-90 * time.Minute)},
+		{name: "days", value: "7d", want: now.Add(-7 * 24 * time.Hour)},
+		{name: "rfc3339", value: "2026-03-01T08:30:00Z", want: time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{name: "rfc3339 with offset", value: "2026-03-01T17:30:00+09:00", want: time.Date(2026, 3, 1, 8, 30, 0, 0, time.UTC)},
+		{name: "date only", value: "2026-03-01", wantErrMsg: `invalid --since "2026-03-01"`},
+		{name: "negative duration", value: "-1h", wantErrMsg: "RFC 3339 timestamp such as"},
+		{name: "garbage", value: "last week", wantErrMsg: "use a duration such as 30m, 24h or 7d"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseTimeBound("since", tt.value, now)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("parseTimeBound() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				var usage *usageError
+				if !errors.As(err, &usage) {
+					t.Errorf("parseTimeBound() error is %T, want a usage error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseTimeBound() error = %v", err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseTimeBound() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterDeployments(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, 3, 10, hour, 0, 0, 0, time.UTC) }
+	deployments := []iface.Deployment{
+		{ID: "dep-4", CreatedAt: at(12)},
+		{ID: "dep-3", CreatedAt: at(10)},
+		{ID: "dep-2", CreatedAt: at(8)},
+		{ID: "dep-1", CreatedAt: at(6)},
+	}
+
+	tests := []struct {
+		name    string
+		since   time.Time
+		until   time.Time
+		wantIDs []string
+	}{
+		{name: "no bounds", wantIDs: []string{"dep-4", "dep-3", "dep-2", "dep-1"}},
+		{name: "since is inclusive", since: at(8), wantIDs: []string{"dep-4", "dep-3", "dep-2"}},
+		{name: "until is exclusive", until: at(10), wantIDs: []string{"dep-2", "dep-1"}},
+		{name: "window", since: at(8), until: at(12), wantIDs: []string{"dep-3", "dep-2"}},
+		{name: "empty window", since: at(13), wantIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterDeployments(deployments, tt.since, tt.until)
+			ids := make([]string, len(got))
+			for i, d := range got {
+				ids[i] = d.ID
+			}
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("filterDeployments() = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestAppsOpenCommand_Run(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		detail     *iface.AppDetail
+		wantOpened string
+		wantOutput string
+		wantErrMsg string
+	}{
+		{
+			name:       "opens app url",
+			args:       []string{"web-app"},
+			detail:     &iface.AppDetail{URL: "https://web.example.com"},
+			wantOpened: "https://web.example.com",
+		},
+		{
+			name:       "prefers custom domain",
+			args:       []string{"web-app"},
+			detail:     &iface.AppDetail{URL: "https://web.example.com", CustomDomain: "www.example.org"},
+			wantOpened: "https://www.example.org",
 		},
 		{
-			name:        "shows empty message when no apps",
-			projectFlag: "empty-project",
-			mockProjects: []iface.Project{
-				{
-					ID:   "proj-empty",
-					Name: "empty-project",
-					Apps: []iface.App{},
-				},
-			},
-			wantOutput: []string{"No apps found"},
-			wantErr:    false,
+			name:       "print only",
+			args:       []string{"web-app", "--print"},
+			detail:     &iface.AppDetail{URL: "https://web.example.com"},
+			wantOutput: "https://web.example.com\n",
 		},
 		{
-			name:         "returns error when project not found",
-			projectFlag:  "nonexistent",
-			mockProjects: []iface.Project{},
-			wantErr:      true,
-			wantErrMsg:   "project not found",
+			name:       "no url yet",
+			args:       []string{"web-app"},
+			detail:     &iface.AppDetail{},
+			wantErrMsg: "has no URL yet",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			// Create mock services
+			var opened string
+			oldOpenURL := openURL
+			openURL = func(url string) error {
+				opened = url
+				return nil
+			}
+			defer func() { openURL = oldOpenURL }()
+
 			mockAuth := &MockAuthService{}
 			mockProject := &MockProjectService{
 				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
-					if tt.mockError != nil {
-						return nil, tt.mockError
-					}
-					return tt.mockProjects, nil
+					return []iface.Project{{
+						ID:   "proj-1",
+						Name: "my-project",
+						Apps: []iface.App{{ID: "app-1", Name: "web-app"}},
+					}}, nil
 				},
 			}
 			mockApp := &MockAppService{
 				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
-					if tt.mockAppDetail != nil {
-						return tt.mockAppDetail, nil
-					}
-					return &iface.AppDetail{DisplayName: "Default", AppType: "dynamic"}, nil
+					return tt.detail, nil
 				},
 			}
 
-			// Create container with mocks
 			container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
-
-			// Create command hierarchy
 			root := NewRootCommand()
 			root.SetContainer(container)
 
-			// Capture stdout
 			oldStdout := os.Stdout
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			// Execute command
-			args := []string{"apps", "list", "-p", tt.projectFlag}
-			root.Command().SetArgs(args)
-
+			root.Command().SetArgs(append([]string{"apps", "open"}, tt.args...))
 			err := root.Command().Execute()
 
-			// Restore stdout and read output
 			w.Close()
 			os.Stdout = oldStdout
 			var buf bytes.Buffer
 			io.Copy(&buf, r)
-			output := buf.String()
 
-			// Check error
-			if (err != nil) != tt.wantErr {
-				t.Errorf("Run() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
 				return
 			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if opened != tt.wantOpened {
+				t.Errorf("opened %q, want %q", opened, tt.wantOpened)
+			}
+			if tt.wantOutput != "" && buf.String() != tt.wantOutput {
+				t.Errorf("output = %q, want %q", buf.String(), tt.wantOutput)
+			}
+		})
+	}
+}
 
-			if tt.wantErr && tt.wantErrMsg != "" {
-				if !strings.Contains(err.Error(), tt.wantErrMsg) {
-					t.Errorf("Error should contain %q, got: %v", tt.wantErrMsg, err)
+func TestAppsStatusCommand_Run(t *testing.T) {
+	project := iface.Project{
+		ID:   "proj-1",
+		Name: "my-project",
+		Apps: []iface.App{
+			{ID: "app-1", Name: "web"},
+			{ID: "app-2", Name: "worker"},
+			{ID: "app-3", Name: "cron"},
+			{ID: "app-4", Name: "api"},
+		},
+	}
+	statuses := map[string]*iface.ProjectStatus{
+		"app-1": {StatusRunning: 2},
+		"app-2": {StatusError: 1},
+		"app-3": {StatusStopped: 1},
+		"app-4": {StatusRunning: 1},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantOutput []string
+		wantJSON   bool
+		wantErrMsg string
+	}{
+		{
+			name:       "table with summary",
+			args:       []string{"-p", "my-project"},
+			wantOutput: []string{"NAME", "STATUS", "worker", "error", "2 running, 1 error, 1 stopped"},
+		},
+		{
+			name:     "json",
+			args:     []string{"-p", "proj-1", "-o", "json"},
+			wantJSON: true,
+		},
+		{
+			name:       "project required",
+			args:       []string{},
+			wantErrMsg: "required flag",
+		},
+		{
+			name:       "project not found",
+			args:       []string{"-p", "missing"},
+			wantErrMsg: "project not found",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockAuth := &MockAuthService{}
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{project}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					return &iface.AppDetail{ID: appID, Status: statuses[appID]}, nil
+				},
+			}
+
+			container := di.NewContainerWithAllServices(mockAuth, mockProject, mockApp)
+			root := NewRootCommand()
+			root.SetContainer(container)
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "status"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
 				}
 				return
 			}
-
-			// Check output contains expected strings
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if strings.Contains(buf.String(), "\x1b[") {
+				t.Errorf("output contains ANSI codes although stdout is not a terminal:\n%q", buf.String())
+			}
 			for _, want := range tt.wantOutput {
-				if !strings.Contains(output, want) {
-					t.Errorf("Output should contain %q, got: %s", want, output)
+				if !strings.Contains(buf.String(), want) {
+					t.Errorf("output missing %q:\n%s", want, buf.String())
 				}
 			}
-
-			// Check output does not contain unwanted strings
-			for _, notWant := range tt.wantNotOutput {
-				if strings.Contains(output, notWant) {
-					t.Errorf("Output should not contain %q, got: %s", notWant, output)
+			if tt.wantJSON {
+				var report appStatusReport
+				if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+					t.Fatalf("output is not valid JSON: %v\n%s", err, buf.String())
+				}
+				want := appStatusSummary{Running: 2, Error: 1, Stopped: 1}
+				if report.Summary != want {
+					t.Errorf("summary = %+v, want %+v", report.Summary, want)
+				}
+				if len(report.Apps) != 4 || report.Apps[1].Status != "error" {
+					t.Errorf("unexpected apps: %+v", report.Apps)
 				}
 			}
 		})
@@ -447,6 +2305,55 @@ func TestAppsDeleteCommand_Run(t *testing.T) {
 	}
 }
 
+func TestAppsDeleteCommand_ListsProjectsOnce(t *testing.T) {
+	listCalls := 0
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			listCalls++
+			return []iface.Project{
+				{ID: "proj-1", Name: "project-1", Apps: []iface.App{{ID: "app-1", Name: "web-1"}}},
+				{ID: "proj-2", Name: "project-2", Apps: []iface.App{{ID: "app-2", Name: "worker"}}},
+			}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+			names := map[string]string{"app-1": "Web", "app-2": "Worker"}
+			return &iface.AppDetail{ID: appID, DisplayName: names[appID]}, nil
+		},
+		DeleteAppFunc: func(ctx context.Context, appID string) error {
+			return nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+	// Resolve through the container's service as a command would, then
+	// run the delete itself, which resolves the name again
+	if _, err := root.Container().ProjectService().ListProjects(context.Background()); err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{"apps", "delete", "Web", "--yes"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	io.Copy(io.Discard, r)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	if listCalls != 1 {
+		t.Errorf("ListProjects called %d times, want 1", listCalls)
+	}
+}
+
 func TestProjectsDeleteCommand_Run(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -577,6 +2484,137 @@ func TestProjectsDeleteCommand_Run(t *testing.T) {
 	}
 }
 
+func TestDeleteCommands_ConfirmName(t *testing.T) {
+	projects := []iface.Project{
+		{ID: "proj-1", Name: "busy-project", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}},
+		{ID: "proj-2", Name: "empty-project"},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		typed      string
+		wantPrompt string
+		wantDelete string
+		wantErrMsg string
+	}{
+		{
+			name:       "project with apps requires typed name",
+			args:       []string{"projects", "delete", "busy-project"},
+			typed:      "busy-project",
+			wantPrompt: `Type the project name "busy-project"`,
+			wantDelete: "proj-1",
+		},
+		{
+			name:       "mismatched project name aborts",
+			args:       []string{"projects", "delete", "busy-project"},
+			typed:      "busy-projet",
+			wantPrompt: `Type the project name "busy-project"`,
+			wantErrMsg: "does not match",
+		},
+		{
+			name:       "typed name is case sensitive",
+			args:       []string{"projects", "delete", "busy-project"},
+			typed:      "Busy-Project",
+			wantPrompt: `Type the project name "busy-project"`,
+			wantErrMsg: "does not match",
+		},
+		{
+			name:       "confirm-name on an empty project",
+			args:       []string{"projects", "delete", "empty-project", "--confirm-name"},
+			typed:      " empty-project\n",
+			wantPrompt: `Type the project name "empty-project"`,
+			wantDelete: "proj-2",
+		},
+		{
+			name:       "yes bypasses typed name",
+			args:       []string{"projects", "delete", "busy-project", "--yes"},
+			wantDelete: "proj-1",
+		},
+		{
+			name:       "app with confirm-name",
+			args:       []string{"apps", "delete", "web-app", "--confirm-name"},
+			typed:      "Web App",
+			wantPrompt: `Type the app name "Web App"`,
+			wantDelete: "app-1",
+		},
+		{
+			name:       "mismatched app name aborts",
+			args:       []string{"apps", "delete", "web-app", "--confirm-name"},
+			typed:      "web-app",
+			wantPrompt: `Type the app name "Web App"`,
+			wantErrMsg: "does not match",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPrompt string
+			oldAskInput := askInput
+			askInput = func(message string) (string, error) {
+				gotPrompt = message
+				return tt.typed, nil
+			}
+			t.Cleanup(func() { askInput = oldAskInput })
+
+			var deleted string
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return projects, nil
+				},
+				DeleteProjectFunc: func(ctx context.Context, id string) error {
+					deleted = id
+					return nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					return &iface.AppDetail{ID: appID, DisplayName: "Web App", AppType: "dynamic"}, nil
+				},
+				DeleteAppFunc: func(ctx context.Context, appID string) error {
+					deleted = appID
+					return nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(tt.args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			io.Copy(io.Discard, r)
+
+			if !strings.Contains(gotPrompt, tt.wantPrompt) || (tt.wantPrompt == "") != (gotPrompt == "") {
+				t.Errorf("prompt = %q, want containing %q", gotPrompt, tt.wantPrompt)
+			}
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if deleted != "" {
+					t.Errorf("%s was deleted despite the mismatch", deleted)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if deleted != tt.wantDelete {
+				t.Errorf("deleted = %q, want %q", deleted, tt.wantDelete)
+			}
+		})
+	}
+}
+
 func TestCreateZipFromDirectory(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -838,3 +2876,88 @@ func TestValidateZipContainsIndexHTML(t *testing.T) {
 	}
 }
 
+func TestCheckStaticDir(t *testing.T) {
+	tests := []struct {
+		name       string
+		files      map[string]string
+		path       string
+		wantSize   int64
+		wantErrMsg string
+	}{
+		{
+			name:     "site with index.html",
+			files:    map[string]string{"index.html": "<html></html>", "css/site.css": "body{}", ".env": "SECRET=1"},
+			wantSize: int64(len("<html></html>") + len("body{}")),
+		},
+		{
+			name:       "missing directory",
+			path:       "dist",
+			wantErrMsg: "directory not found:",
+		},
+		{
+			name:       "file instead of directory",
+			files:      map[string]string{"index.html": "<html></html>"},
+			path:       "index.html",
+			wantErrMsg: "not a directory:",
+		},
+		{
+			name:       "no index.html",
+			files:      map[string]string{"app.js": "//"},
+			wantErrMsg: "has no index.html at its root",
+		},
+		{
+			name:       "index.html only in a subdirectory",
+			files:      map[string]string{"dist/index.html": "<html></html>"},
+			wantErrMsg: "has no index.html at its root",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, content := range tt.files {
+				path := filepath.Join(dir, name)
+				if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			path := filepath.Join(dir, tt.path)
+
+			size, err := checkStaticDir(path)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) || !strings.Contains(err.Error(), path) {
+					t.Fatalf("checkStaticDir() error = %v, want containing %q and the directory", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkStaticDir() error = %v", err)
+			}
+			if size != tt.wantSize {
+				t.Errorf("size = %d, want %d (hidden files are not uploaded)", size, tt.wantSize)
+			}
+		})
+	}
+}
+
+func TestWarnLargeStaticDir(t *testing.T) {
+	tests := []struct {
+		size     int64
+		wantWarn bool
+	}{
+		{size: staticDirWarnSize, wantWarn: false},
+		{size: staticDirWarnSize + 1, wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		warnLargeStaticDir(&buf, "public", tt.size)
+		if got := strings.Contains(buf.String(), "public holds"); got != tt.wantWarn {
+			t.Errorf("size %d: warned = %v, want %v (output %q)", tt.size, got, tt.wantWarn, buf.String())
+		}
+	}
+}
+
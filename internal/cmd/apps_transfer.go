@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+// AppsTransferCommand represents the apps transfer command
+type AppsTransferCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	to string
+}
+
+// NewAppsTransferCommand creates a new apps transfer command
+func NewAppsTransferCommand(parent *AppsCommand) *AppsTransferCommand {
+	t := &AppsTransferCommand{
+		parent: parent,
+	}
+
+	t.cmd = &cobra.Command{
+		Use:   "transfer [app-name-or-id] --to <project-name-or-id>",
+		Short: "Move an application to another project",
+		Long: `Move an application to a different project without recreating it.
+
+The app keeps its ID, configuration, and deployments. The target project's
+plan must support the app; otherwise the transfer is refused. Asks for
+confirmation unless --yes is given.
+
+Examples:
+  kamui apps transfer my-api --to production
+  kamui apps transfer my-api --to 4f9c2a7e --yes -o json`,
+		Args: appArg,
+		RunE: t.Run,
+	}
+
+	t.cmd.Flags().StringVar(&t.to, "to", "", "Name or ID of the project to move the app to (required)")
+	t.cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	_ = t.cmd.MarkFlagRequired("to")
+
+	return t
+}
+
+// Command returns the underlying cobra command
+func (t *AppsTransferCommand) Command() *cobra.Command {
+	return t.cmd
+}
+
+// appTransfer is the JSON output of apps transfer
+type appTransfer struct {
+	AppID       string `json:"app_id"`
+	AppName     string `json:"app_name"`
+	FromProject string `json:"from_project_id"`
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name"`
+}
+
+// Run executes the apps transfer command
+func (t *AppsTransferCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	projectService := t.parent.Root().Container().ProjectService()
+	appService := t.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+	target, err := findProject(projects, t.to)
+	if err != nil {
+		return err
+	}
+	name := match.DisplayName
+	if name == "" {
+		name = match.AppName
+	}
+	if target.ID == match.ProjectID {
+		return fmt.Errorf("app \"%s\" is already in project \"%s\"", name, target.Name)
+	}
+
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	if !skipConfirm {
+		var confirm bool
+		if err := ask(&survey.Confirm{
+			Message: fmt.Sprintf("Move app \"%s\" from project \"%s\" to \"%s\"?", name, match.ProjectName, target.Name),
+			Default: false,
+		}, &confirm); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	result, err := appService.TransferApp(ctx, match.AppID, target.ID)
+	if err != nil {
+		// The API answers 409 when the target plan cannot host the app
+		var apiErr *api.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+			return fmt.Errorf("cannot move \"%s\" to project \"%s\" (%s plan): %w", name, target.Name, target.PlanType, err)
+		}
+		return err
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(appTransfer{
+			AppID:       result.AppID,
+			AppName:     match.AppName,
+			FromProject: match.ProjectID,
+			ProjectID:   result.ProjectID,
+			ProjectName: target.Name,
+		})
+	}
+
+	fmt.Printf("✓ App \"%s\" moved to project \"%s\"\n", name, target.Name)
+	fmt.Printf("  Project ID: %s\n", result.ProjectID)
+	fmt.Println("\n  View it with:")
+	fmt.Printf("  kamui apps list --project %s\n", target.Name)
+
+	return nil
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestAppsTransferCommand_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		transferErr  error
+		wantTarget   string
+		wantOutput   []string
+		wantErrMsg   string
+		wantExitCode int
+	}{
+		{
+			name:       "transfer by project name",
+			args:       []string{"web-app", "--to", "production", "--yes"},
+			wantTarget: "proj-2",
+			wantOutput: []string{`App "web-app" moved to project "production"`, "Project ID: proj-2"},
+		},
+		{
+			name:       "transfer by project ID",
+			args:       []string{"app-1", "--to", "proj-2", "--yes"},
+			wantTarget: "proj-2",
+		},
+		{
+			name:       "already in target project",
+			args:       []string{"web-app", "--to", "staging", "--yes"},
+			wantErrMsg: `already in project "staging"`,
+		},
+		{
+			name:         "unknown target project",
+			args:         []string{"web-app", "--to", "nope", "--yes"},
+			wantErrMsg:   "project not found",
+			wantExitCode: ExitNotFound,
+		},
+		{
+			name:         "incompatible plan",
+			args:         []string{"web-app", "--to", "production", "--yes"},
+			transferErr:  &api.APIError{StatusCode: http.StatusConflict, Message: "plan does not allow this app spec"},
+			wantTarget:   "proj-2",
+			wantErrMsg:   `cannot move "web-app" to project "production" (free plan)`,
+			wantExitCode: ExitAPI,
+		},
+		{
+			name:         "missing --to",
+			args:         []string{"web-app", "--yes"},
+			wantErrMsg:   `"to" not set`,
+			wantExitCode: ExitError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotTarget string
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{
+						{ID: "proj-1", Name: "staging", PlanType: "pro", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}},
+						{ID: "proj-2", Name: "production", PlanType: "free"},
+					}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				TransferAppFunc: func(ctx context.Context, appID, targetProjectID string) (*iface.TransferAppOutput, error) {
+					gotTarget = targetProjectID
+					if tt.transferErr != nil {
+						return nil, tt.transferErr
+					}
+					return &iface.TransferAppOutput{AppID: appID, ProjectID: targetProjectID}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(append([]string{"apps", "transfer"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if gotTarget != tt.wantTarget {
+				t.Errorf("TransferApp target = %q, want %q", gotTarget, tt.wantTarget)
+			}
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				if tt.wantExitCode != 0 && ExitCode(err) != tt.wantExitCode {
+					t.Errorf("ExitCode() = %d, want %d", ExitCode(err), tt.wantExitCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAppsTransferCommand_JSON(t *testing.T) {
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{
+				{ID: "proj-1", Name: "staging", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}},
+				{ID: "proj-2", Name: "production"},
+			}, nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, &MockAppService{}))
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs([]string{"apps", "transfer", "web-app", "--to", "production", "--yes", "-o", "json"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	if err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+	var got appTransfer
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("stdout is not a JSON object: %v\n%s", err, buf.String())
+	}
+	want := appTransfer{AppID: "app-1", AppName: "web-app", FromProject: "proj-1", ProjectID: "proj-2", ProjectName: "production"}
+	if got != want {
+		t.Errorf("output = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// Conditions accepted by apps watch --until
+const (
+	watchUntilRunning = "running" // at least one replica is running
+	watchUntilHealthy = "healthy" // every replica is running
+)
+
+// AppsWatchCommand represents the apps watch command
+type AppsWatchCommand struct {
+	parent *AppsCommand
+	cmd    *cobra.Command
+
+	interval    time.Duration
+	until       string
+	waitTimeout time.Duration
+}
+
+// NewAppsWatchCommand creates a new apps watch command
+func NewAppsWatchCommand(parent *AppsCommand) *AppsWatchCommand {
+	w := &AppsWatchCommand{
+		parent: parent,
+	}
+
+	w.cmd = &cobra.Command{
+		Use:   "watch [app-name-or-id]",
+		Short: "Watch an application's status change live",
+		Long: `Poll an application's replica status and show it as it changes.
+
+On a terminal the status is redrawn in place; otherwise one line is printed
+per poll. Polls start 2s apart and back off to 15s; --interval polls at a
+fixed rate instead. The command runs until Ctrl-C, or with --until until the
+app is running (at least one replica up) or healthy (every replica up). With
+--until it gives up after --wait-timeout (default 5m) and exits with code 7.
+
+Examples:
+  kamui apps watch my-api
+  kamui apps watch my-api --until healthy --wait-timeout 10m
+  kamui apps watch my-api --interval 10s > status.log`,
+		Args:        appArg,
+		RunE:        w.Run,
+		Annotations: map[string]string{noDeadlineAnnotation: "true"},
+	}
+
+	w.cmd.Flags().DurationVar(&w.interval, "interval", 0, "Poll the status at this fixed interval instead of backing off from 2s to 15s")
+	w.cmd.Flags().StringVar(&w.until, "until", "", "Exit once the app is running or healthy")
+	w.cmd.Flags().DurationVar(&w.waitTimeout, "wait-timeout", defaultWaitTimeout, "Maximum time to wait with --until")
+
+	return w
+}
+
+// Command returns the underlying cobra command
+func (w *AppsWatchCommand) Command() *cobra.Command {
+	return w.cmd
+}
+
+// Run executes the apps watch command
+func (w *AppsWatchCommand) Run(cmd *cobra.Command, args []string) error {
+	backoff := statusPollBackoff
+	if cmd.Flags().Changed("interval") {
+		if w.interval <= 0 {
+			return fmt.Errorf("--interval must be positive")
+		}
+		backoff = fixedBackoff(w.interval)
+	}
+	switch w.until {
+	case "", watchUntilRunning, watchUntilHealthy:
+	default:
+		return fmt.Errorf("--until must be running or healthy")
+	}
+	if w.until == "" && cmd.Flags().Changed("wait-timeout") {
+		return fmt.Errorf("--wait-timeout only applies with --until")
+	}
+	if w.waitTimeout <= 0 {
+		return fmt.Errorf("--wait-timeout must be greater than zero")
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt)
+	defer stop()
+
+	projectService := w.parent.Root().Container().ProjectService()
+	appService := w.parent.Root().Container().AppService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	match, err := resolveAppArg(ctx, projects, appService, args)
+	if err != nil {
+		return err
+	}
+
+	watcher := &appWatcher{
+		appService: appService,
+		appID:      match.AppID,
+		backoff:    backoff,
+		until:      w.until,
+		timeout:    w.waitTimeout,
+		w:          os.Stdout,
+		redraw:     isStdoutTTY(),
+	}
+	return watcher.run(ctx)
+}
+
+// appWatcher polls an app and renders its status counts
+type appWatcher struct {
+	appService iface.AppService
+	appID      string
+	backoff    pollBackoff
+	until      string
+	timeout    time.Duration // bounds the wait for until, if set
+	w          io.Writer
+
+	// redraw replaces the previous frame in place instead of appending a
+	// line per poll
+	redraw bool
+	lines  int
+}
+
+// run polls until ctx is cancelled, which ends the watch without error, or
+// until the --until condition holds. Waiting for the condition longer than
+// timeout fails with errWaitTimedOut.
+func (a *appWatcher) run(ctx context.Context) error {
+	pollCtx := ctx
+	if a.until != "" && a.timeout > 0 {
+		var cancel context.CancelFunc
+		pollCtx, cancel = context.WithTimeoutCause(ctx, a.timeout, errWaitTimedOut)
+		defer cancel()
+	}
+
+	name := a.appID
+	err := poll(pollCtx, a.backoff, func(ctx context.Context) (bool, error) {
+		app, err := a.appService.GetApp(ctx, a.appID)
+		if err != nil {
+			return false, err
+		}
+
+		if app.DisplayName != "" {
+			name = app.DisplayName
+		}
+		status := app.Status
+		if status == nil {
+			status = &iface.ProjectStatus{}
+		}
+		a.render(name, status, time.Now())
+
+		if a.satisfied(status) {
+			fmt.Fprintf(a.w, "✓ App \"%s\" is %s\n", name, a.until)
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil && pollCtx.Err() != nil {
+		if errors.Is(context.Cause(pollCtx), errWaitTimedOut) {
+			return fmt.Errorf("%w: app \"%s\" is still not %s after %s\n\nCheck status with: kamui apps get %s", errWaitTimedOut, name, a.until, a.timeout, a.appID)
+		}
+		return nil
+	}
+	return err
+}
+
+// satisfied reports whether status meets the --until condition
+func (a *appWatcher) satisfied(status *iface.ProjectStatus) bool {
+	switch a.until {
+	case watchUntilRunning:
+		return status.StatusRunning > 0
+	case watchUntilHealthy:
+		return status.Healthy()
+	}
+	return false
+}
+
+// render prints one poll result, either as a frame that replaces the
+// previous one or as a single appended line
+func (a *appWatcher) render(name string, status *iface.ProjectStatus, at time.Time) {
+	stamp := at.Format("15:04:05")
+	if !a.redraw {
+		fmt.Fprintf(a.w, "%s %s %s %s\n", stamp, name, status.String(), status.Summary())
+		return
+	}
+
+	frame := []string{
+		fmt.Sprintf("%s — %s (updated %s, Ctrl-C to stop)", name, status.String(), stamp),
+		fmt.Sprintf("  Running: %d", status.StatusRunning),
+		fmt.Sprintf("  Stopped: %d", status.StatusStopped),
+		fmt.Sprintf("  Error:   %d", status.StatusError),
+		fmt.Sprintf("  Unknown: %d", status.StatusUnknown),
+	}
+
+	var b strings.Builder
+	if a.lines > 0 {
+		// Move back to the first line of the previous frame
+		fmt.Fprintf(&b, "\033[%dA", a.lines)
+	}
+	for _, line := range frame {
+		b.WriteString("\r\033[2K")
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+	fmt.Fprint(a.w, b.String())
+	a.lines = len(frame)
+}
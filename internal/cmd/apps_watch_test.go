@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestAppsWatchCommand_Run(t *testing.T) {
+	starting := &iface.ProjectStatus{StatusUnknown: 2}
+	partial := &iface.ProjectStatus{StatusRunning: 1, StatusUnknown: 1}
+	healthy := &iface.ProjectStatus{StatusRunning: 2}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantPolls  int
+		wantOutput []string
+		wantErrMsg string
+	}{
+		{
+			name:       "until running",
+			args:       []string{"--until", "running"},
+			wantPolls:  2,
+			wantOutput: []string{"Web App unknown running=0 stopped=0 error=0 unknown=2", "Web App running running=1", `✓ App "Web App" is running`},
+		},
+		{
+			name:       "until healthy",
+			args:       []string{"--until", "healthy"},
+			wantPolls:  3,
+			wantOutput: []string{"running=2 stopped=0 error=0 unknown=0", `✓ App "Web App" is healthy`},
+		},
+		{
+			name:       "invalid until",
+			args:       []string{"--until", "done"},
+			wantErrMsg: "--until must be running or healthy",
+		},
+		{
+			name:       "invalid interval",
+			args:       []string{"--interval", "0s"},
+			wantErrMsg: "--interval must be positive",
+		},
+		{
+			name:       "wait timeout without until",
+			args:       []string{"--wait-timeout", "1m"},
+			wantErrMsg: "--wait-timeout only applies with --until",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statuses := []*iface.ProjectStatus{starting, partial, healthy}
+			polls := 0
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Apps: []iface.App{{ID: "app-1", Name: "web-app"}}}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					status := statuses[min(polls, len(statuses)-1)]
+					polls++
+					return &iface.AppDetail{ID: appID, DisplayName: "Web App", Status: status}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			args := append([]string{"apps", "watch", "web-app", "--interval", "1ms"}, tt.args...)
+			root.Command().SetArgs(args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if polls != tt.wantPolls {
+				t.Errorf("GetApp called %d times, want %d", polls, tt.wantPolls)
+			}
+			if strings.Contains(output, "\033[") {
+				t.Errorf("non-TTY output should not redraw: %q", output)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+		})
+	}
+}
+
+func TestAppWatcher_StopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	polls := 0
+	appService := &MockAppService{
+		GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+			polls++
+			if polls == 2 {
+				cancel()
+			}
+			return &iface.AppDetail{ID: appID, Status: &iface.ProjectStatus{StatusStopped: 1}}, nil
+		},
+	}
+
+	var out bytes.Buffer
+	watcher := &appWatcher{appService: appService, appID: "app-1", backoff: fixedBackoff(time.Millisecond), w: &out}
+	if err := watcher.run(ctx); err != nil {
+		t.Fatalf("run() error = %v, want nil after cancellation", err)
+	}
+	if polls != 2 {
+		t.Errorf("GetApp called %d times, want 2", polls)
+	}
+}
+
+func TestAppWatcher_WaitTimeout(t *testing.T) {
+	tests := []struct {
+		name        string
+		healthyAt   int
+		timeout     time.Duration
+		wantTimeout bool
+	}{
+		{name: "healthy before the timeout", healthyAt: 3, timeout: time.Minute},
+		{name: "never healthy", timeout: 20 * time.Millisecond, wantTimeout: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			polls := 0
+			appService := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					polls++
+					status := &iface.ProjectStatus{StatusUnknown: 1}
+					if polls == tt.healthyAt {
+						status = &iface.ProjectStatus{StatusRunning: 1}
+					}
+					return &iface.AppDetail{ID: appID, DisplayName: "Web App", Status: status}, nil
+				},
+			}
+
+			var out bytes.Buffer
+			watcher := &appWatcher{appService: appService, appID: "app-1", backoff: fixedBackoff(time.Millisecond),
+				until: watchUntilHealthy, timeout: tt.timeout, w: &out}
+			err := watcher.run(context.Background())
+
+			if tt.wantTimeout {
+				if got := ExitCode(err); got != ExitWaitTimeout {
+					t.Fatalf("run() error = %v, exit code %d, want %d", err, got, ExitWaitTimeout)
+				}
+				if !strings.Contains(err.Error(), `app "Web App" is still not healthy after 20ms`) {
+					t.Errorf("run() error = %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("run() error = %v", err)
+			}
+			if polls != tt.healthyAt {
+				t.Errorf("GetApp called %d times, want %d", polls, tt.healthyAt)
+			}
+		})
+	}
+}
+
+func TestAppWatcher_Redraw(t *testing.T) {
+	var out bytes.Buffer
+	watcher := &appWatcher{w: &out, redraw: true}
+	at := time.Date(2026, 1, 2, 15, 4, 5, 0, time.UTC)
+
+	watcher.render("my-api", &iface.ProjectStatus{StatusUnknown: 1}, at)
+	first := out.String()
+	if strings.Contains(first, "\033[5A") {
+		t.Errorf("first frame should not move the cursor up: %q", first)
+	}
+	if !strings.Contains(first, "my-api — unknown (updated 15:04:05") {
+		t.Errorf("first frame = %q", first)
+	}
+
+	out.Reset()
+	watcher.render("my-api", &iface.ProjectStatus{StatusRunning: 1}, at)
+	second := out.String()
+	if !strings.HasPrefix(second, "\033[5A") {
+		t.Errorf("second frame should replace the previous 5 lines: %q", second)
+	}
+	if !strings.Contains(second, "\r\033[2K  Running: 1\n") {
+		t.Errorf("second frame = %q", second)
+	}
+}
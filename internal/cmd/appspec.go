@@ -0,0 +1,245 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"gopkg.in/yaml.v3"
+)
+
+// appSpec is the document read by `apps create --file`. It can be written
+// in YAML or JSON; both use the same snake_case keys. Only dynamic apps are
+// supported. Defaults match the non-interactive flags of apps create.
+//
+//	project: my-project          # required, name or ID
+//	name: web                    # required
+//	type: dynamic                # default dynamic
+//	language: go                 # required: node, go, python
+//	deploy_type: github          # github (default) or docker_hub
+//	owner: my-org                # required for github
+//	owner_type: Organization     # required for github: Organization or User
+//	repo: web                    # required for github
+//	branch: main                 # default main
+//	directory: services/web
+//	start_command: ./server      # required
+//	setup_command: go build -o server .
+//	pre_command: ./migrate
+//	health_check: /health        # default /health
+//	replicas: 2                  # default 1
+//	app_spec: small              # nano (default), small, medium, large
+//	database_id: db-123
+//	session_affinity: true
+//	env:
+//	  LOG_LEVEL: debug
+//	secrets:                     # like env, but never echoed back
+//	  DB_PASSWORD: hunter2
+type appSpec struct {
+	Project         string            `yaml:"project"`
+	Name            string            `yaml:"name"`
+	Type            string            `yaml:"type"`
+	Language        string            `yaml:"language"`
+	DeployType      string            `yaml:"deploy_type"`
+	Owner           string            `yaml:"owner"`
+	OwnerType       string            `yaml:"owner_type"`
+	Repo            string            `yaml:"repo"`
+	Branch          string            `yaml:"branch"`
+	Directory       string            `yaml:"directory"`
+	StartCommand    string            `yaml:"start_command"`
+	SetupCommand    string            `yaml:"setup_command"`
+	PreCommand      string            `yaml:"pre_command"`
+	HealthCheck     string            `yaml:"health_check"`
+	Replicas        int               `yaml:"replicas"`
+	AppSpec         string            `yaml:"app_spec"`
+	DatabaseID      string            `yaml:"database_id"`
+	SessionAffinity bool              `yaml:"session_affinity"`
+	Env             map[string]string `yaml:"env"`
+	Secrets         map[string]string `yaml:"secrets"`
+
+	// lines maps each top-level key to the line it appears on, for errors
+	lines map[string]int
+}
+
+// readAppSpec reads and validates an app spec from path, or from stdin when
+// path is "-"
+func readAppSpec(path string, stdin io.Reader) (*appSpec, error) {
+	data, err := readFileOrStdin(path, stdin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read app spec: %w", err)
+	}
+
+	spec, err := parseAppSpec(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app spec %s: %w", sourceName(path), err)
+	}
+	return spec, nil
+}
+
+// readFileOrStdin reads path, or stdin when path is "-"
+func readFileOrStdin(path string, stdin io.Reader) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(stdin)
+	}
+	return os.ReadFile(path)
+}
+
+// sourceName names the input read by readFileOrStdin in error messages
+func sourceName(path string) string {
+	if path == "-" {
+		return "stdin"
+	}
+	return path
+}
+
+// parseAppSpec decodes a YAML or JSON app spec, rejecting unknown fields,
+// and validates it
+func parseAppSpec(data []byte) (*appSpec, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New("document is empty")
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of fields", root.Line)
+	}
+
+	var spec appSpec
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&spec); err != nil {
+		return nil, err
+	}
+
+	spec.lines = keyLines(root)
+
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// keyLines maps each key of a YAML mapping node to the line it appears on
+func keyLines(node *yaml.Node) map[string]int {
+	lines := make(map[string]int, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		lines[node.Content[i].Value] = node.Content[i].Line
+	}
+	return lines
+}
+
+// fieldError describes an invalid field, prefixed with its line when the
+// field is present in the document
+func (s *appSpec) fieldError(field, format string, args ...interface{}) error {
+	msg := fmt.Sprintf("field %q: %s", field, fmt.Sprintf(format, args...))
+	if line, ok := s.lines[field]; ok {
+		return fmt.Errorf("line %d: %s", line, msg)
+	}
+	return errors.New(msg)
+}
+
+// validate checks required fields and allowed values, reporting every
+// problem at once
+func (s *appSpec) validate() error {
+	var errs []error
+	required := func(field, value string) {
+		if strings.TrimSpace(value) == "" {
+			errs = append(errs, s.fieldError(field, "is required"))
+		}
+	}
+	oneOf := func(field, value string, allowed ...string) {
+		if value == "" {
+			return
+		}
+		for _, a := range allowed {
+			if value == a {
+				return
+			}
+		}
+		errs = append(errs, s.fieldError(field, "must be one of %s (got %q)", strings.Join(allowed, ", "), value))
+	}
+
+	required("project", s.Project)
+	required("name", s.Name)
+	oneOf("type", s.Type, "dynamic")
+	required("language", s.Language)
+	oneOf("language", s.Language, "node", "go", "python")
+	oneOf("deploy_type", s.DeployType, "github", "docker_hub")
+	if s.DeployType == "" || s.DeployType == "github" {
+		required("owner", s.Owner)
+		required("owner_type", s.OwnerType)
+		required("repo", s.Repo)
+	}
+	oneOf("owner_type", s.OwnerType, "Organization", "User")
+	required("start_command", s.StartCommand)
+	if s.Replicas < 0 {
+		errs = append(errs, s.fieldError("replicas", "must not be negative"))
+	}
+	oneOf("app_spec", s.AppSpec, "nano", "small", "medium", "large")
+	for key := range s.Env {
+		if key == "" || strings.Contains(key, "=") {
+			errs = append(errs, s.fieldError("env", "invalid variable name %q", key))
+		}
+	}
+	for key := range s.Secrets {
+		if key == "" || strings.Contains(key, "=") {
+			errs = append(errs, s.fieldError("secrets", "invalid variable name %q", key))
+		} else if _, ok := s.Env[key]; ok {
+			errs = append(errs, s.fieldError("secrets", "%s is also set in env", key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// input converts the spec into a CreateAppInput for project, filling in
+// the same defaults as the non-interactive flags
+func (s *appSpec) input(project iface.Project) *iface.CreateAppInput {
+	input := &iface.CreateAppInput{
+		ProjectID:       project.ID,
+		AppName:         s.Name,
+		Language:        s.Language,
+		DeployType:      s.DeployType,
+		Owner:           s.Owner,
+		OwnerType:       s.OwnerType,
+		Repository:      s.Repo,
+		Branch:          s.Branch,
+		Directory:       s.Directory,
+		StartCommand:    s.StartCommand,
+		SetupCommand:    s.SetupCommand,
+		PreCommand:      s.PreCommand,
+		HealthCheckPath: s.HealthCheck,
+		Replicas:        s.Replicas,
+		AppSpecType:     s.AppSpec,
+		DatabaseID:      s.DatabaseID,
+		SessionAffinity: s.SessionAffinity,
+	}
+	if input.DeployType == "" {
+		input.DeployType = "github"
+	}
+	if input.Branch == "" {
+		input.Branch = "main"
+	}
+	if input.HealthCheckPath == "" {
+		input.HealthCheckPath = "/health"
+	}
+	if input.Replicas < 1 {
+		input.Replicas = 1
+	}
+	if input.AppSpecType == "" {
+		input.AppSpecType = "nano"
+	}
+	envVars := make(map[string]string, len(s.Env)+len(s.Secrets))
+	for key, val := range s.Env {
+		envVars[key] = val
+	}
+	// validate has already rejected names set in both maps
+	input.EnvVars, input.SecretKeys, _ = addSecrets(envVars, s.Secrets)
+	return input
+}
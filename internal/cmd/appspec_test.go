@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseAppSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		wantErrs []string
+	}{
+		{
+			name: "valid yaml",
+			doc: `project: my-project
+name: web
+language: go
+owner: my-org
+owner_type: Organization
+repo: web
+start_command: ./server
+env:
+  LOG_LEVEL: debug
+`,
+		},
+		{
+			name: "valid json",
+			doc:  `{"project": "my-project", "name": "web", "language": "node", "deploy_type": "docker_hub", "start_command": "npm start"}`,
+		},
+		{
+			name:     "empty document",
+			doc:      "",
+			wantErrs: []string{"document is empty"},
+		},
+		{
+			name:     "not a mapping",
+			doc:      "- web\n",
+			wantErrs: []string{"line 1: expected a mapping"},
+		},
+		{
+			name:     "unknown field",
+			doc:      "project: p\nname: web\nlanguage: go\ndeploy_type: docker_hub\nstart_command: x\nreplica: 2\n",
+			wantErrs: []string{"line 6: field replica not found"},
+		},
+		{
+			name:     "secret also set in env",
+			doc:      "project: p\nname: web\nlanguage: go\ndeploy_type: docker_hub\nstart_command: x\nenv:\n  TOKEN: a\nsecrets:\n  TOKEN: b\n",
+			wantErrs: []string{`line 8: field "secrets": TOKEN is also set in env`},
+		},
+		{
+			name:     "wrong type",
+			doc:      "project: p\nname: web\nreplicas: many\n",
+			wantErrs: []string{"line 3: cannot unmarshal"},
+		},
+		{
+			name: "missing required fields",
+			doc:  "language: go\n",
+			wantErrs: []string{
+				`field "project": is required`,
+				`field "name": is required`,
+				`field "owner": is required`,
+				`field "owner_type": is required`,
+				`field "repo": is required`,
+				`field "start_command": is required`,
+			},
+		},
+		{
+			name: "invalid values report their line",
+			doc:  "project: p\nname: web\nlanguage: ruby\ndeploy_type: docker_hub\nstart_command: x\napp_spec: huge\n",
+			wantErrs: []string{
+				`line 3: field "language": must be one of node, go, python (got "ruby")`,
+				`line 6: field "app_spec": must be one of nano, small, medium, large (got "huge")`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseAppSpec([]byte(tt.doc))
+			if len(tt.wantErrs) == 0 {
+				if err != nil {
+					t.Fatalf("parseAppSpec() error = %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("parseAppSpec() error = nil, want error")
+			}
+			for _, want := range tt.wantErrs {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error should contain %q, got: %v", want, err)
+				}
+			}
+		})
+	}
+}
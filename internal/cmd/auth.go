@@ -0,0 +1,172 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/service"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// defaultProfile names the single set of credentials the CLI stores
+const defaultProfile = "default"
+
+// AuthCommand represents the auth command group
+type AuthCommand struct {
+	root *RootCommand
+	cmd  *cobra.Command
+
+	// Subcommands
+	statusCmd *AuthStatusCommand
+}
+
+// NewAuthCommand creates a new auth command group
+func NewAuthCommand(root *RootCommand) *AuthCommand {
+	a := &AuthCommand{
+		root: root,
+	}
+
+	a.cmd = &cobra.Command{
+		Use:   "auth",
+		Short: "Inspect authentication state",
+		Long: `Inspect the credentials stored by 'kamui login'.
+
+Examples:
+  kamui auth status
+  kamui auth status --verify -o json`,
+	}
+
+	a.statusCmd = NewAuthStatusCommand(a)
+	a.cmd.AddCommand(a.statusCmd.Command())
+
+	return a
+}
+
+// Command returns the underlying cobra command
+func (a *AuthCommand) Command() *cobra.Command {
+	return a.cmd
+}
+
+// Root returns the root command
+func (a *AuthCommand) Root() *RootCommand {
+	return a.root
+}
+
+// AuthStatusCommand represents the auth status command
+type AuthStatusCommand struct {
+	parent *AuthCommand
+	cmd    *cobra.Command
+
+	verify bool
+}
+
+// NewAuthStatusCommand creates a new auth status command
+func NewAuthStatusCommand(parent *AuthCommand) *AuthStatusCommand {
+	s := &AuthStatusCommand{
+		parent: parent,
+	}
+
+	s.cmd = &cobra.Command{
+		Use:   "status",
+		Short: "Report whether you are logged in",
+		Long: `Report whether valid credentials are stored, for use in scripts.
+
+The command exits 0 when logged in and 3 when not. By default it only reads
+the local config and makes no network request; an expired access token
+still counts as logged in if a refresh token can renew it. --verify also
+checks the credentials against the API and reports the account.
+
+Examples:
+  kamui auth status
+  kamui auth status -o json
+  kamui auth status --verify
+  kamui auth status >/dev/null 2>&1 || kamui login`,
+		Args: cobra.NoArgs,
+		RunE: s.Run,
+	}
+
+	s.cmd.Flags().BoolVar(&s.verify, "verify", false, "Also check the credentials against the API")
+
+	return s
+}
+
+// Command returns the underlying cobra command
+func (s *AuthStatusCommand) Command() *cobra.Command {
+	return s.cmd
+}
+
+// authStatus is the output of auth status
+type authStatus struct {
+	LoggedIn  bool        `json:"logged_in"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	Scope     string      `json:"scope,omitempty"`
+	Profile   string      `json:"profile"`
+	User      *iface.User `json:"user,omitempty"`
+}
+
+// Run executes the auth status command
+func (s *AuthStatusCommand) Run(cmd *cobra.Command, args []string) error {
+	configManager := s.parent.Root().Container().ConfigManager()
+
+	cfg, err := configManager.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	status := authStatus{
+		LoggedIn: configManager.IsLoggedIn() || cfg.RefreshToken != "",
+		Profile:  defaultProfile,
+	}
+	if !cfg.ExpiresAt.IsZero() {
+		expiresAt := cfg.ExpiresAt
+		status.ExpiresAt = &expiresAt
+	}
+	if status.LoggedIn {
+		status.Scope = cfg.Scope
+	}
+
+	var verifyErr error
+	if status.LoggedIn && s.verify {
+		authService := s.parent.Root().Container().AuthService()
+		status.User, verifyErr = authService.GetCurrentUser(cmd.Context())
+		if verifyErr != nil {
+			status.LoggedIn = false
+		}
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(status); err != nil {
+			return err
+		}
+	} else if status.LoggedIn {
+		fmt.Printf("✓ Logged in (profile %s)\n", status.Profile)
+		if status.User != nil {
+			fmt.Printf("  Account: %s <%s>\n", status.User.Name, status.User.Email)
+		}
+		if status.Scope != "" {
+			fmt.Printf("  Scope: %s\n", status.Scope)
+		}
+		if status.ExpiresAt != nil {
+			if status.ExpiresAt.After(time.Now()) {
+				fmt.Printf("  Access token expires: %s\n", status.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Println("  Access token expired; it will be refreshed on the next command")
+			}
+		}
+	} else {
+		fmt.Printf("✗ Not logged in (profile %s)\n", status.Profile)
+	}
+
+	if verifyErr != nil {
+		return verifyErr
+	}
+	if !status.LoggedIn {
+		return service.ErrNotLoggedIn
+	}
+	return nil
+}
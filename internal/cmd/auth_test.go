@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestAuthStatusCommand_Run(t *testing.T) {
+	valid := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	expired := time.Now().Add(-time.Hour).UTC().Truncate(time.Second)
+
+	tests := []struct {
+		name         string
+		config       *config.Config
+		args         []string
+		verifyErr    error
+		wantExit     int
+		wantLoggedIn bool
+		wantExpires  *time.Time
+		wantScope    string
+		wantVerified bool
+		wantOutput   string
+	}{
+		{
+			name:         "valid token",
+			config:       &config.Config{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: valid},
+			wantLoggedIn: true,
+			wantExpires:  &valid,
+			wantOutput:   "Logged in (profile default)",
+		},
+		{
+			name:         "granted scope",
+			config:       &config.Config{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: valid, Scope: "read"},
+			wantLoggedIn: true,
+			wantExpires:  &valid,
+			wantScope:    "read",
+			wantOutput:   "Scope: read",
+		},
+		{
+			name:         "expired token with refresh token",
+			config:       &config.Config{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: expired},
+			wantLoggedIn: true,
+			wantExpires:  &expired,
+			wantOutput:   "will be refreshed",
+		},
+		{
+			name:        "expired token without refresh token",
+			config:      &config.Config{AccessToken: "access", ExpiresAt: expired},
+			wantExit:    ExitAuth,
+			wantExpires: &expired,
+			wantOutput:  "Not logged in",
+		},
+		{
+			name:       "no tokens",
+			config:     &config.Config{ClientID: "client-id"},
+			wantExit:   ExitAuth,
+			wantOutput: "Not logged in",
+		},
+		{
+			name:         "verify reports the account",
+			config:       &config.Config{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: valid},
+			args:         []string{"--verify"},
+			wantLoggedIn: true,
+			wantExpires:  &valid,
+			wantVerified: true,
+			wantOutput:   "Account: Jane <jane@example.com>",
+		},
+		{
+			name:         "verify fails on rejected credentials",
+			config:       &config.Config{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: valid},
+			args:         []string{"--verify"},
+			verifyErr:    errors.New("failed to verify credentials: session expired"),
+			wantExit:     ExitError,
+			wantExpires:  &valid,
+			wantVerified: true,
+			wantOutput:   "Not logged in",
+		},
+	}
+
+	for _, tt := range tests {
+		for _, format := range []string{"text", "json"} {
+			t.Run(tt.name+"/"+format, func(t *testing.T) {
+				m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+				if err := m.Save(tt.config); err != nil {
+					t.Fatal(err)
+				}
+
+				verified := false
+				mock := &MockAuthService{
+					GetCurrentUserFunc: func(ctx context.Context) (*iface.User, error) {
+						verified = true
+						if tt.verifyErr != nil {
+							return nil, tt.verifyErr
+						}
+						return &iface.User{ID: "u1", Name: "Jane", Email: "jane@example.com"}, nil
+					},
+				}
+
+				root := NewRootCommand()
+				root.SetContainer(di.NewContainerWithAuthService(m, mock))
+				root.Command().SilenceErrors = true
+				root.Command().SilenceUsage = true
+
+				oldStdout := os.Stdout
+				r, w, _ := os.Pipe()
+				os.Stdout = w
+
+				args := append([]string{"auth", "status"}, tt.args...)
+				if format == "json" {
+					args = append(args, "-o", "json")
+				}
+				root.Command().SetArgs(args)
+				err := root.Command().Execute()
+
+				w.Close()
+				os.Stdout = oldStdout
+				var out bytes.Buffer
+				io.Copy(&out, r)
+
+				if got := ExitCode(err); got != tt.wantExit {
+					t.Fatalf("ExitCode() = %d, want %d (error %v)", got, tt.wantExit, err)
+				}
+				if verified != tt.wantVerified {
+					t.Errorf("GetCurrentUser called = %v, want %v", verified, tt.wantVerified)
+				}
+
+				if format == "text" {
+					if !strings.Contains(out.String(), tt.wantOutput) {
+						t.Errorf("output = %q, want containing %q", out.String(), tt.wantOutput)
+					}
+					return
+				}
+
+				var got authStatus
+				if err := json.Unmarshal(out.Bytes(), &got); err != nil {
+					t.Fatalf("invalid JSON output %q: %v", out.String(), err)
+				}
+				if got.LoggedIn != tt.wantLoggedIn {
+					t.Errorf("logged_in = %v, want %v", got.LoggedIn, tt.wantLoggedIn)
+				}
+				if got.Scope != tt.wantScope {
+					t.Errorf("scope = %q, want %q", got.Scope, tt.wantScope)
+				}
+				if got.Profile != "default" {
+					t.Errorf("profile = %q, want %q", got.Profile, "default")
+				}
+				switch {
+				case tt.wantExpires == nil && got.ExpiresAt != nil:
+					t.Errorf("expires_at = %v, want omitted", got.ExpiresAt)
+				case tt.wantExpires != nil && (got.ExpiresAt == nil || !got.ExpiresAt.Equal(*tt.wantExpires)):
+					t.Errorf("expires_at = %v, want %v", got.ExpiresAt, tt.wantExpires)
+				}
+				if (got.User != nil) != (tt.wantVerified && tt.verifyErr == nil) {
+					t.Errorf("user = %+v, unexpected", got.User)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// promptBranch fetches the branches of owner/repo and asks the user to pick
+// one, preselecting main or master. When the repository reports no
+// branches the name is typed in instead of guessing one that may not exist.
+func promptBranch(ctx context.Context, appService iface.AppService, owner, repo string) (string, error) {
+	fmt.Fprintln(os.Stderr, "\nFetching branches...")
+	sp := startSpinner()
+	branches, err := appService.GetBranches(ctx, owner, repo)
+	sp.Stop()
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch branches: %w", err)
+	}
+
+	if len(branches) == 0 {
+		fmt.Fprintf(os.Stderr, "No branches found for %s/%s.\n", owner, repo)
+		for {
+			branch, err := askInput("Branch name:")
+			if err != nil {
+				return "", err
+			}
+			if branch = strings.TrimSpace(branch); branch != "" {
+				return branch, nil
+			}
+			fmt.Fprintln(os.Stderr, "Branch name cannot be empty.")
+		}
+	}
+
+	branchOptions := make([]string, len(branches))
+	for i, b := range branches {
+		branchOptions[i] = b.Name
+	}
+
+	// Try to default to main or master
+	defaultBranch := ""
+	for _, b := range branchOptions {
+		if b == "main" || b == "master" {
+			defaultBranch = b
+			break
+		}
+	}
+
+	var branch string
+	if err := ask(&survey.Select{
+		Message: "Select branch:",
+		Options: branchOptions,
+		Default: defaultBranch,
+	}, &branch); err != nil {
+		return "", err
+	}
+	return branch, nil
+}
+
+// validateBranch checks that a branch given with --branch exists in
+// owner/repo. A repository that reports no branches cannot be checked and
+// is let through.
+func validateBranch(ctx context.Context, appService iface.AppService, owner, repo, branch string) error {
+	sp := startSpinner()
+	branches, err := appService.GetBranches(ctx, owner, repo)
+	sp.Stop()
+	if err != nil {
+		return fmt.Errorf("failed to fetch branches: %w", err)
+	}
+	if len(branches) == 0 {
+		return nil
+	}
+
+	names := make([]string, len(branches))
+	for i, b := range branches {
+		if b.Name == branch {
+			return nil
+		}
+		names[i] = b.Name
+	}
+	return fmt.Errorf("branch %q not found in %s/%s; available branches: %s", branch, owner, repo, strings.Join(names, ", "))
+}
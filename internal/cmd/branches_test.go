@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestPromptBranch_EmptyBranchesAsksForName(t *testing.T) {
+	answers := []string{"", "  develop "}
+	asked := 0
+	oldAskInput := askInput
+	askInput = func(message string) (string, error) {
+		answer := answers[asked]
+		asked++
+		return answer, nil
+	}
+	t.Cleanup(func() { askInput = oldAskInput })
+
+	appService := &MockAppService{
+		GetBranchesFunc: func(ctx context.Context, owner, repo string) ([]iface.Branch, error) {
+			return nil, nil
+		},
+	}
+
+	branch, err := promptBranch(context.Background(), appService, "my-org", "web")
+	if err != nil {
+		t.Fatalf("promptBranch() error = %v", err)
+	}
+	if branch != "develop" {
+		t.Errorf("promptBranch() = %q, want %q", branch, "develop")
+	}
+	if asked != 2 {
+		t.Errorf("asked %d times, want 2 (an empty answer is asked again)", asked)
+	}
+}
+
+func TestAppsCreateCommand_BranchValidation(t *testing.T) {
+	tests := []struct {
+		name       string
+		branch     string
+		branches   []iface.Branch
+		wantCreate bool
+		wantErrMsg string
+	}{
+		{
+			name:       "existing branch",
+			branch:     "release",
+			branches:   []iface.Branch{{Name: "main"}, {Name: "release"}},
+			wantCreate: true,
+		},
+		{
+			name:       "unknown branch lists valid ones",
+			branch:     "relase",
+			branches:   []iface.Branch{{Name: "main"}, {Name: "release"}},
+			wantErrMsg: `branch "relase" not found in my-org/web; available branches: main, release`,
+		},
+		{
+			name:       "repository without branches is not checked",
+			branch:     "release",
+			wantCreate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			created := false
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return []iface.Project{{ID: "proj-1", Name: "my-project"}}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetBranchesFunc: func(ctx context.Context, owner, repo string) ([]iface.Branch, error) {
+					return tt.branches, nil
+				},
+				CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+					created = true
+					return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs([]string{
+				"apps", "create", "-p", "my-project",
+				"--name", "web", "--language", "go", "--start-command", "./server",
+				"--owner", "my-org", "--owner-type", "Organization", "--repo", "web",
+				"--branch", tt.branch,
+			})
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if created != tt.wantCreate {
+				t.Errorf("CreateApp called = %v, want %v", created, tt.wantCreate)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"regexp"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// ANSI escape sequences for the colors used in text output
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+)
+
+// ansiPattern matches the SGR escape sequences written by colorize
+var ansiPattern = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// envNoColor disables colored output when set to any non-empty value
+// (see https://no-color.org)
+const envNoColor = "NO_COLOR"
+
+// colorEnabled reports whether cmd may color its stdout
+func colorEnabled(cmd *cobra.Command) bool {
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	return shouldColor(noColor, os.Getenv(envNoColor), isStdoutTTY())
+}
+
+// shouldColor decides whether to emit color: only on a terminal, and
+// neither --no-color nor NO_COLOR may be set
+func shouldColor(noColorFlag bool, noColorEnv string, tty bool) bool {
+	return !noColorFlag && noColorEnv == "" && tty
+}
+
+// colorize wraps s in the given ANSI color when enabled is set
+func colorize(s, color string, enabled bool) string {
+	if !enabled || color == "" {
+		return s
+	}
+	return color + s + ansiReset
+}
+
+// colorStatus colors an app status word as returned by
+// iface.ProjectStatus.String: running is green, error is red, and stopped
+// is yellow
+func colorStatus(status string, enabled bool) string {
+	switch status {
+	case iface.AppStatusRunning:
+		return colorize(status, ansiGreen, enabled)
+	case iface.AppStatusError:
+		return colorize(status, ansiRed, enabled)
+	case iface.AppStatusStopped:
+		return colorize(status, ansiYellow, enabled)
+	default:
+		return status
+	}
+}
+
+// stripANSI removes color escape sequences, e.g. to measure display width
+func stripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestShouldColor(t *testing.T) {
+	tests := []struct {
+		name   string
+		flag   bool
+		env    string
+		tty    bool
+		expect bool
+	}{
+		{name: "terminal", tty: true, expect: true},
+		{name: "not a terminal", tty: false, expect: false},
+		{name: "--no-color", flag: true, tty: true, expect: false},
+		{name: "NO_COLOR", env: "1", tty: true, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldColor(tt.flag, tt.env, tt.tty); got != tt.expect {
+				t.Errorf("shouldColor(%v, %q, %v) = %v, want %v", tt.flag, tt.env, tt.tty, got, tt.expect)
+			}
+		})
+	}
+}
+
+func TestColorStatus(t *testing.T) {
+	if got := colorStatus("running", true); got != ansiGreen+"running"+ansiReset {
+		t.Errorf("colorStatus(running) = %q", got)
+	}
+	if got := colorStatus("error", true); got != ansiRed+"error"+ansiReset {
+		t.Errorf("colorStatus(error) = %q", got)
+	}
+	if got := colorStatus("unknown", true); got != "unknown" {
+		t.Errorf("colorStatus(unknown) = %q, want uncolored", got)
+	}
+	for _, status := range []string{"running", "error", "stopped"} {
+		if got := colorStatus(status, false); got != status {
+			t.Errorf("colorStatus(%q, false) = %q, want no color codes", status, got)
+		}
+	}
+}
+
+func TestAppsListCommand_NoColorFlag(t *testing.T) {
+	t.Setenv(envNoColor, "")
+
+	root := NewRootCommand()
+	cmd, _, err := root.Command().Find([]string{"apps", "list"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.ParseFlags([]string{"--no-color"}); err != nil {
+		t.Fatalf("ParseFlags: %v", err)
+	}
+	if colorEnabled(cmd) {
+		t.Error("colorEnabled() = true with --no-color")
+	}
+}
+
+func TestPrintTable_IgnoresColorCodesInWidth(t *testing.T) {
+	var buf bytes.Buffer
+	printTable(&buf, "", []string{"NAME", "STATUS", "ID"}, [][]string{
+		{"web", colorStatus("running", true), "app-1"},
+		{"worker", "unknown", "app-2"},
+	})
+
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("got %d lines:\n%s", len(lines), buf.String())
+	}
+	col := strings.Index(lines[3], "app-2")
+	if got := strings.Index(stripANSI(lines[2]), "app-1"); got != col {
+		t.Errorf("ID column at %d in colored row, %d in plain row:\n%s", got, col, buf.String())
+	}
+}
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	"github.com/kamui-project/kamui-cli/internal/service"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// completionTimeout bounds a completion lookup so a slow network never
+// freezes the shell
+const completionTimeout = 500 * time.Millisecond
+
+// registerCompletions wires dynamic project and app name completion into
+// the command tree
+func (r *RootCommand) registerCompletions() {
+	apps := r.appsCmd
+	for _, c := range []*cobra.Command{
+		apps.getCmd.Command(),
+		apps.redeployCmd.Command(),
+		apps.rollbackCmd.Command(),
+		apps.deploymentsCmd.Command(),
+		apps.openCmd.Command(),
+		apps.watchCmd.Command(),
+		apps.transferCmd.Command(),
+		apps.renameCmd.Command(),
+		apps.deployStaticCmd.Command(),
+		apps.deleteCmd.Command(),
+		apps.domainCmd.showCmd.Command(),
+		apps.domainCmd.setCmd.Command(),
+		apps.domainCmd.removeCmd.Command(),
+		apps.envCmd.exportCmd.Command(),
+	} {
+		c.ValidArgsFunction = r.completeApps
+	}
+
+	projects := r.projectsCmd
+	for _, c := range []*cobra.Command{
+		projects.getCmd.Command(),
+		projects.describeCmd.Command(),
+		projects.updateCmd.Command(),
+		projects.deleteCmd.Command(),
+	} {
+		c.ValidArgsFunction = r.completeProjects
+	}
+
+	walkCommands(r.cmd, func(c *cobra.Command) {
+		if c.Flags().Lookup("project") != nil {
+			_ = c.RegisterFlagCompletionFunc("project", r.completeProjectFlag)
+		}
+	})
+	_ = apps.transferCmd.Command().RegisterFlagCompletionFunc("to", r.completeProjectFlag)
+}
+
+// walkCommands calls fn for c and every command below it
+func walkCommands(c *cobra.Command, fn func(*cobra.Command)) {
+	fn(c)
+	for _, sub := range c.Commands() {
+		walkCommands(sub, fn)
+	}
+}
+
+// completeProjects suggests project names for the first argument
+func (r *RootCommand) completeProjects(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return r.completeProjectFlag(cmd, args, toComplete)
+}
+
+// completeProjectFlag suggests project names for --project and --to
+func (r *RootCommand) completeProjectFlag(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	projects := r.completionProjects(cmd)
+
+	var names []string
+	for _, p := range projects {
+		if strings.HasPrefix(p.Name, toComplete) {
+			names = append(names, p.Name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeApps suggests app names from every project for the first
+// argument
+func (r *RootCommand) completeApps(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	projects := r.completionProjects(cmd)
+
+	var names []string
+	for _, p := range projects {
+		for _, a := range p.Apps {
+			if strings.HasPrefix(a.Name, toComplete) {
+				names = append(names, a.Name)
+			}
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completionProjects fetches the project list for a completion, returning
+// nothing when the lookup fails, e.g. when not logged in, or does not
+// finish within completionTimeout
+func (r *RootCommand) completionProjects(cmd *cobra.Command) []iface.Project {
+	container, err := r.completionContainer(cmd)
+	if err != nil || container.ProjectService() == nil {
+		return nil
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithTimeout(ctx, completionTimeout)
+	defer cancel()
+
+	// Don't rely on the service honoring ctx to return in time
+	done := make(chan []iface.Project, 1)
+	go func() {
+		projects, err := container.ProjectService().ListProjects(ctx)
+		if err != nil {
+			projects = nil
+		}
+		done <- projects
+	}()
+
+	select {
+	case projects := <-done:
+		return projects
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// completionContainer returns the container for completion lookups.
+// Cobra runs completions without PersistentPreRunE, so the container is
+// built here. Like any other command it reads the project list cache only
+// when project_cache is enabled.
+func (r *RootCommand) completionContainer(cmd *cobra.Command) (*di.Container, error) {
+	if r.container != nil {
+		return r.container, nil
+	}
+
+	transport, err := resolveTransport(cmd)
+	if err != nil {
+		return nil, err
+	}
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	org, _ := cmd.Flags().GetString("org")
+
+	allowHTTP, _ := cmd.Flags().GetBool("insecure-allow-http")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+
+	r.container, err = di.NewContainer(service.HTTPOptions{
+		Timeout:           completionTimeout,
+		Transport:         transport,
+		AllowInsecureHTTP: allowHTTP,
+	}, apiURL, org, noCache)
+	if err != nil {
+		return nil, err
+	}
+	return r.container, nil
+}
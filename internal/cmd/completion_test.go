@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+func TestCompletion_Dynamic(t *testing.T) {
+	projects := []iface.Project{
+		{ID: "proj-1", Name: "staging", Apps: []iface.App{{ID: "app-1", Name: "web"}, {ID: "app-2", Name: "worker"}}},
+		{ID: "proj-2", Name: "production", Apps: []iface.App{{ID: "app-3", Name: "api"}}},
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		listErr error
+		slow    bool
+		want    []string
+	}{
+		{
+			name: "app argument",
+			args: []string{"apps", "get", ""},
+			want: []string{"web", "worker", "api"},
+		},
+		{
+			name: "app argument with prefix",
+			args: []string{"apps", "delete", "w"},
+			want: []string{"web", "worker"},
+		},
+		{
+			name: "second argument is not completed",
+			args: []string{"apps", "domain", "set", "web", ""},
+		},
+		{
+			name: "project argument",
+			args: []string{"projects", "describe", "st"},
+			want: []string{"staging"},
+		},
+		{
+			name: "project flag",
+			args: []string{"apps", "list", "--project", ""},
+			want: []string{"staging", "production"},
+		},
+		{
+			name: "transfer target",
+			args: []string{"apps", "transfer", "web", "--to", "pro"},
+			want: []string{"production"},
+		},
+		{
+			name:    "not logged in",
+			args:    []string{"apps", "get", ""},
+			listErr: errors.New("not logged in"),
+		},
+		{
+			name: "lookup times out",
+			args: []string{"apps", "get", ""},
+			slow: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			release := make(chan struct{})
+			defer close(release)
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					if tt.slow {
+						// Ignore ctx to check the completer doesn't wait anyway
+						<-release
+					}
+					return projects, tt.listErr
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, &MockAppService{}))
+
+			var out bytes.Buffer
+			root.Command().SetOut(&out)
+			root.Command().SetArgs(append([]string{cobra.ShellCompRequestCmd}, tt.args...))
+
+			start := time.Now()
+			if err := root.Command().Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if elapsed := time.Since(start); elapsed > 2*completionTimeout {
+				t.Errorf("completion took %v, want it bounded by %v", elapsed, completionTimeout)
+			}
+
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			directive := lines[len(lines)-1]
+			if want := ":4"; directive != want {
+				t.Errorf("directive = %q, want %q (ShellCompDirectiveNoFileComp)", directive, want)
+			}
+			got := lines[:len(lines)-1]
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("suggestions = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
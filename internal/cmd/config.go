@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/spf13/cobra"
+)
+
+// ConfigCommand groups the `config` subcommands for viewing and editing
+// ~/.kamui/config.json.
+type ConfigCommand struct {
+	root *RootCommand
+	cmd  *cobra.Command
+
+	getCmd  *ConfigGetCommand
+	setCmd  *ConfigSetCommand
+	viewCmd *ConfigViewCommand
+	pathCmd *ConfigPathCommand
+}
+
+// NewConfigCommand creates the config command group.
+func NewConfigCommand(root *RootCommand) *ConfigCommand {
+	c := &ConfigCommand{root: root}
+
+	c.cmd = &cobra.Command{
+		Use:   "config",
+		Short: "View and edit CLI configuration",
+		Long: `View and edit the CLI configuration stored in ~/.kamui/config.json.
+
+Settable keys:
+` + configKeysHelp() + `
+Examples:
+  kamui config get api_url
+  kamui config set api_url https://api.kamui-platform.com
+  kamui config view
+  kamui config path`,
+	}
+
+	c.getCmd = NewConfigGetCommand(c)
+	c.setCmd = NewConfigSetCommand(c)
+	c.viewCmd = NewConfigViewCommand(c)
+	c.pathCmd = NewConfigPathCommand(c)
+
+	c.cmd.AddCommand(c.getCmd.Command())
+	c.cmd.AddCommand(c.setCmd.Command())
+	c.cmd.AddCommand(c.viewCmd.Command())
+	c.cmd.AddCommand(c.pathCmd.Command())
+
+	return c
+}
+
+func (c *ConfigCommand) Command() *cobra.Command { return c.cmd }
+func (c *ConfigCommand) Root() *RootCommand      { return c.root }
+
+// configManager returns the config manager from the DI container
+func (c *ConfigCommand) configManager() (*config.Manager, error) {
+	m := c.root.Container().ConfigManager()
+	if m == nil {
+		return nil, fmt.Errorf("configuration is not available")
+	}
+	return m, nil
+}
+
+// configKeysHelp renders the settable keys for help text
+func configKeysHelp() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	for _, key := range config.SettingKeys() {
+		fmt.Fprintf(w, "  %s\t%s\n", key, config.SettingDescription(key))
+	}
+	w.Flush()
+	return b.String()
+}
+
+// ── config get ───────────────────────────────────────────────────────────────
+
+type ConfigGetCommand struct {
+	parent *ConfigCommand
+	cmd    *cobra.Command
+}
+
+func NewConfigGetCommand(parent *ConfigCommand) *ConfigGetCommand {
+	g := &ConfigGetCommand{parent: parent}
+	g.cmd = &cobra.Command{
+		Use:       "get <key>",
+		Short:     "Print the value of a configuration key",
+		Args:      cobra.ExactArgs(1),
+		ValidArgs: config.SettingKeys(),
+		RunE:      g.Run,
+	}
+	return g
+}
+
+func (g *ConfigGetCommand) Command() *cobra.Command { return g.cmd }
+
+func (g *ConfigGetCommand) Run(cmd *cobra.Command, args []string) error {
+	m, err := g.parent.configManager()
+	if err != nil {
+		return err
+	}
+	value, err := m.GetSetting(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Println(value)
+	return nil
+}
+
+// ── config set ───────────────────────────────────────────────────────────────
+
+type ConfigSetCommand struct {
+	parent *ConfigCommand
+	cmd    *cobra.Command
+}
+
+func NewConfigSetCommand(parent *ConfigCommand) *ConfigSetCommand {
+	s := &ConfigSetCommand{parent: parent}
+	s.cmd = &cobra.Command{
+		Use:       "set <key> <value>",
+		Short:     "Set a configuration key",
+		Args:      cobra.ExactArgs(2),
+		ValidArgs: config.SettingKeys(),
+		RunE:      s.Run,
+	}
+	return s
+}
+
+func (s *ConfigSetCommand) Command() *cobra.Command { return s.cmd }
+
+func (s *ConfigSetCommand) Run(cmd *cobra.Command, args []string) error {
+	m, err := s.parent.configManager()
+	if err != nil {
+		return err
+	}
+	key, value := args[0], args[1]
+	if err := m.SetSetting(key, value); err != nil {
+		return err
+	}
+	fmt.Printf("✓ Set %s to %s\n", key, value)
+	return nil
+}
+
+// ── config view ──────────────────────────────────────────────────────────────
+
+type ConfigViewCommand struct {
+	parent *ConfigCommand
+	cmd    *cobra.Command
+}
+
+func NewConfigViewCommand(parent *ConfigCommand) *ConfigViewCommand {
+	v := &ConfigViewCommand{parent: parent}
+	v.cmd = &cobra.Command{
+		Use:   "view",
+		Short: "Print the configuration with secrets redacted",
+		Long: `Print the whole configuration file. Access tokens, refresh tokens and
+the OAuth client secret are always redacted.`,
+		Args: cobra.NoArgs,
+		RunE: v.Run,
+	}
+	return v
+}
+
+func (v *ConfigViewCommand) Command() *cobra.Command { return v.cmd }
+
+func (v *ConfigViewCommand) Run(cmd *cobra.Command, args []string) error {
+	m, err := v.parent.configManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := m.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(cfg.Redacted())
+}
+
+// ── config path ──────────────────────────────────────────────────────────────
+
+type ConfigPathCommand struct {
+	parent *ConfigCommand
+	cmd    *cobra.Command
+}
+
+func NewConfigPathCommand(parent *ConfigCommand) *ConfigPathCommand {
+	p := &ConfigPathCommand{parent: parent}
+	p.cmd = &cobra.Command{
+		Use:   "path",
+		Short: "Print the path of the configuration file",
+		Args:  cobra.NoArgs,
+		RunE:  p.Run,
+	}
+	return p
+}
+
+func (p *ConfigPathCommand) Command() *cobra.Command { return p.cmd }
+
+func (p *ConfigPathCommand) Run(cmd *cobra.Command, args []string) error {
+	m, err := p.parent.configManager()
+	if err != nil {
+		return err
+	}
+	fmt.Println(m.ConfigPath())
+	return nil
+}
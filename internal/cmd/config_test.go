@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/kamui-project/kamui-cli/internal/di"
+)
+
+func runConfigCommand(t *testing.T, m *config.Manager, args ...string) (string, error) {
+	t.Helper()
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithConfigManager(m))
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	root.Command().SetArgs(append([]string{"config"}, args...))
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout = oldStdout
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String(), err
+}
+
+func TestConfigCommands(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	m := config.NewManagerWithPath(path)
+	if err := m.SaveTokens("access-secret", "refresh-secret", 3600); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.SaveClientCredentials("client-1", "client-secret"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		args          []string
+		wantOutput    []string
+		wantNotOutput []string
+		wantErrMsg    string
+	}{
+		{
+			name:       "path",
+			args:       []string{"path"},
+			wantOutput: []string{path},
+		},
+		{
+			name:       "get default api_url",
+			args:       []string{"get", "api_url"},
+			wantOutput: []string{config.DefaultAPIURL},
+		},
+		{
+			name:       "set api_url",
+			args:       []string{"set", "api_url", "https://api.staging.example.com"},
+			wantOutput: []string{"✓ Set api_url to https://api.staging.example.com"},
+		},
+		{
+			name:       "set rejects invalid api_url",
+			args:       []string{"set", "api_url", "http://insecure.example.com"},
+			wantErrMsg: "invalid value for api_url",
+		},
+		{
+			name:       "set rejects unknown key",
+			args:       []string{"set", "access_token", "x"},
+			wantErrMsg: `unknown config key "access_token"`,
+		},
+		{
+			name:          "view redacts secrets",
+			args:          []string{"view"},
+			wantOutput:    []string{`"api_url": "https://api.staging.example.com"`, `"client_id": "client-1"`, `"access_token": "[REDACTED]"`},
+			wantNotOutput: []string{"access-secret", "refresh-secret", "client-secret"},
+		},
+	}
+
+	// Cases run in order: "view" observes the api_url written by "set"
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := runConfigCommand(t, m, tt.args...)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("output should contain %q, got: %s", want, output)
+				}
+			}
+			for _, notWant := range tt.wantNotOutput {
+				if strings.Contains(output, notWant) {
+					t.Errorf("output should not contain %q, got: %s", notWant, output)
+				}
+			}
+		})
+	}
+}
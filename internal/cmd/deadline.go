@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// commandTimeoutFactor scales the per-request timeout into the overall
+	// deadline of a command, which may issue several requests
+	commandTimeoutFactor = 10
+
+	// noDeadlineAnnotation opts a command out of the overall deadline, for
+	// flows that wait on the user rather than the API (e.g. browser login)
+	noDeadlineAnnotation = "kamui/no-deadline"
+)
+
+// errOperationTimedOut is returned when a command exceeds its overall deadline
+var errOperationTimedOut = errors.New("operation timed out")
+
+// commandDeadline returns the overall deadline for a command: ten times the
+// request timeout, but never less than the upload timeout so uploads can
+// finish. Zero timeouts mean the API client defaults.
+func commandDeadline(timeout, uploadTimeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		timeout = api.DefaultTimeout
+	}
+	if uploadTimeout <= 0 {
+		uploadTimeout = api.DefaultUploadTimeout
+	}
+	if d := timeout * commandTimeoutFactor; d > uploadTimeout {
+		return d
+	}
+	return uploadTimeout
+}
+
+// applyDeadline bounds cmd's context by the overall command deadline derived
+// from --timeout and --upload-timeout. The returned cancel func releases the
+// deadline's timer.
+func applyDeadline(cmd *cobra.Command) (context.CancelFunc, error) {
+	if cmd.Annotations[noDeadlineAnnotation] != "" {
+		return func() {}, nil
+	}
+
+	timeout, err := resolveDuration(cmd, "timeout", envTimeout)
+	if err != nil {
+		return nil, err
+	}
+	uploadTimeout, err := resolveDuration(cmd, "upload-timeout", envUploadTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	d := commandDeadline(timeout, uploadTimeout)
+	ctx, cancel := context.WithTimeoutCause(ctx, d, fmt.Errorf("%w after %s", errOperationTimedOut, d))
+	cmd.SetContext(ctx)
+	return cancel, nil
+}
+
+// markDeadlineErrors wraps RunE of c and its subcommands so that an error
+// caused by the overall deadline is reported as "operation timed out"
+// instead of a raw context error. Errors that do not stem from a context
+// deadline are returned unchanged, even if the deadline has since passed.
+func markDeadlineErrors(c *cobra.Command) {
+	if run := c.RunE; run != nil {
+		c.RunE = func(cmd *cobra.Command, args []string) error {
+			err := run(cmd, args)
+			if err == nil {
+				return nil
+			}
+			ctx := cmd.Context()
+			if ctx == nil || ctx.Err() == nil || !errors.Is(err, context.DeadlineExceeded) {
+				return err
+			}
+			if cause := context.Cause(ctx); errors.Is(cause, errOperationTimedOut) {
+				return fmt.Errorf("%w; use --timeout to allow more time", cause)
+			}
+			return err
+		}
+	}
+	for _, sub := range c.Commands() {
+		markDeadlineErrors(sub)
+	}
+}
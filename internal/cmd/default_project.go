@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// noDefaultProjectAnnotation opts a command out of applyDefaultProject, for
+// destructive commands where --project narrows what they act on and an
+// implicit value would change which apps are deleted
+const noDefaultProjectAnnotation = "kamui/no-default-project"
+
+// addSetDefaultFlags adds --set-default to every command under c that
+// takes --project
+func addSetDefaultFlags(c *cobra.Command) {
+	walkCommands(c, func(c *cobra.Command) {
+		if c.Flags().Lookup("project") != nil {
+			c.Flags().Bool("set-default", false, "Save --project as the default project for later commands")
+		}
+	})
+}
+
+// applyDefaultProject fills in --project from the default_project setting
+// when the command takes a project and none was given. An explicit
+// --project always wins, and --all and --file, which choose projects
+// themselves, leave it unset, as do commands marked with
+// noDefaultProjectAnnotation. The stored name or ID is resolved by the
+// command like any other --project value.
+func (r *RootCommand) applyDefaultProject(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup("project")
+	if flag == nil {
+		return nil
+	}
+	setDefault, _ := cmd.Flags().GetBool("set-default")
+	if flag.Changed {
+		return nil
+	}
+	if setDefault {
+		return &usageError{err: fmt.Errorf("--set-default requires --project")}
+	}
+	for _, name := range []string{"all", "file"} {
+		if f := cmd.Flags().Lookup(name); f != nil && f.Changed {
+			return nil
+		}
+	}
+	if cmd.Annotations[noDefaultProjectAnnotation] != "" {
+		return nil
+	}
+
+	configManager := r.container.ConfigManager()
+	if configManager == nil {
+		return nil
+	}
+	cfg, err := configManager.Load()
+	if err != nil || cfg.DefaultProject == "" {
+		return nil
+	}
+	return cmd.Flags().Set("project", cfg.DefaultProject)
+}
+
+// saveDefaultProject stores --project as the default project after a
+// successful command run with --set-default
+func (r *RootCommand) saveDefaultProject(cmd *cobra.Command) error {
+	if setDefault, _ := cmd.Flags().GetBool("set-default"); !setDefault {
+		return nil
+	}
+	configManager := r.container.ConfigManager()
+	if configManager == nil {
+		return nil
+	}
+	project, _ := cmd.Flags().GetString("project")
+	if err := configManager.SetSetting("default_project", project); err != nil {
+		return fmt.Errorf("failed to save default project: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✓ Default project set to %s\n", project)
+	return nil
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestDefaultProject(t *testing.T) {
+	projects := []iface.Project{
+		{ID: "proj-1", Name: "staging", Apps: []iface.App{{ID: "app-1", Name: "web"}}},
+		{ID: "proj-2", Name: "production", Apps: []iface.App{{ID: "app-2", Name: "api"}}},
+	}
+
+	tests := []struct {
+		name           string
+		defaultProject string
+		args           []string
+		wantOutput     []string
+		wantErrMsg     string
+		wantDefault    string
+	}{
+		{
+			name:           "falls back to the default",
+			defaultProject: "staging",
+			args:           []string{"apps", "list"},
+			wantOutput:     []string{`Apps in project "staging"`},
+			wantDefault:    "staging",
+		},
+		{
+			name:           "default given as an ID",
+			defaultProject: "proj-2",
+			args:           []string{"apps", "list"},
+			wantOutput:     []string{`Apps in project "production"`},
+			wantDefault:    "proj-2",
+		},
+		{
+			name:           "explicit --project wins",
+			defaultProject: "staging",
+			args:           []string{"apps", "list", "-p", "production"},
+			wantOutput:     []string{`Apps in project "production"`},
+			wantDefault:    "staging",
+		},
+		{
+			name:           "--all ignores the default",
+			defaultProject: "staging",
+			args:           []string{"apps", "list", "--all"},
+			wantOutput:     []string{`Apps in project "staging"`, `Apps in project "production"`},
+			wantDefault:    "staging",
+		},
+		{
+			name:       "no default keeps the required error",
+			args:       []string{"apps", "status"},
+			wantErrMsg: `required flag(s) "project" not set`,
+		},
+		{
+			name:           "stale default is resolved at use time",
+			defaultProject: "deleted-project",
+			args:           []string{"apps", "list"},
+			wantErrMsg:     "project not found",
+			wantDefault:    "deleted-project",
+		},
+		{
+			name:        "--set-default saves the project",
+			args:        []string{"apps", "list", "-p", "production", "--set-default"},
+			wantOutput:  []string{`Apps in project "production"`},
+			wantDefault: "production",
+		},
+		{
+			name:       "--set-default needs --project",
+			args:       []string{"apps", "list", "--all", "--set-default"},
+			wantErrMsg: "--set-default requires --project",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if tt.defaultProject != "" {
+				if err := m.SetSetting("default_project", tt.defaultProject); err != nil {
+					t.Fatal(err)
+				}
+			}
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return projects, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					return &iface.AppDetail{ID: appID}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp).WithConfigManager(m))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(tt.args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+			output := buf.String()
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output should contain %q, got: %s", want, output)
+				}
+			}
+			if got, _ := m.GetSetting("default_project"); got != tt.wantDefault {
+				t.Errorf("default_project = %q, want %q", got, tt.wantDefault)
+			}
+		})
+	}
+}
+
+func TestDefaultProject_AppsDeleteIgnoresDefault(t *testing.T) {
+	projects := []iface.Project{
+		{ID: "proj-1", Name: "staging", Apps: []iface.App{{ID: "app-1", Name: "web"}}},
+		{ID: "proj-2", Name: "production", Apps: []iface.App{{ID: "app-2", Name: "api"}}},
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		wantDeleted []string
+		wantErrMsg  string
+	}{
+		{
+			name:        "single delete searches every project",
+			args:        []string{"apps", "delete", "api", "--yes"},
+			wantDeleted: []string{"app-2"},
+		},
+		{
+			name:       "pattern still needs --project or --all",
+			args:       []string{"apps", "delete", "--pattern", "w*", "--yes"},
+			wantErrMsg: "--pattern needs --project or --all",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.SetSetting("default_project", "staging"); err != nil {
+				t.Fatal(err)
+			}
+			var deleted []string
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return projects, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetAppFunc: func(ctx context.Context, appID string) (*iface.AppDetail, error) {
+					return &iface.AppDetail{ID: appID}, nil
+				},
+				DeleteAppFunc: func(ctx context.Context, appID string) error {
+					deleted = append(deleted, appID)
+					return nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp).WithConfigManager(m))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			_, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(tt.args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if strings.Join(deleted, ",") != strings.Join(tt.wantDeleted, ",") {
+				t.Errorf("deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"time"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// waitForDeploy polls the deployment status of an app until it is running or
+// has failed, printing each status change to w. The wait is bounded by
+// timeout instead of the overall command deadline, and Ctrl-C stops it.
+func waitForDeploy(ctx context.Context, appService iface.AppService, appID string, timeout time.Duration, w io.Writer) error {
+	ctx, stop := signal.NotifyContext(context.WithoutCancel(ctx), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w: deployment still in progress after %s", errWaitTimedOut, timeout))
+	defer cancel()
+
+	fmt.Fprintln(w, "\nWaiting for deployment...")
+	start := time.Now()
+	last := ""
+	err := poll(ctx, statusPollBackoff, func(ctx context.Context) (bool, error) {
+		status, err := appService.GetDeployStatus(ctx, appID)
+		if err != nil {
+			return false, err
+		}
+
+		if status.Status != last {
+			fmt.Fprintf(w, "  [%s] %s\n", time.Since(start).Round(time.Second), status.Status)
+			last = status.Status
+		}
+
+		switch status.Status {
+		case iface.DeployStatusRunning:
+			fmt.Fprintln(w, "✓ Deployment is running")
+			return true, nil
+		case iface.DeployStatusError:
+			reason := status.Message
+			if reason == "" {
+				reason = "no reason reported"
+			}
+			return false, fmt.Errorf("deployment failed: %s", reason)
+		}
+		return false, nil
+	})
+	if err != nil && ctx.Err() != nil {
+		return deployWaitStopped(ctx, appID)
+	}
+	return err
+}
+
+// waitForDeployment polls the deployments of an app until deploymentID is the
+// current deployment and running, or has failed. Unlike waitForDeploy it
+// cannot mistake the previous version for the new one while the new one is
+// still rolling out.
+func waitForDeployment(ctx context.Context, appService iface.AppService, appID, deploymentID string, timeout time.Duration, w io.Writer) error {
+	if deploymentID == "" {
+		return waitForDeploy(ctx, appService, appID, timeout, w)
+	}
+
+	ctx, stop := signal.NotifyContext(context.WithoutCancel(ctx), os.Interrupt)
+	defer stop()
+	ctx, cancel := context.WithTimeoutCause(ctx, timeout, fmt.Errorf("%w: deployment still in progress after %s", errWaitTimedOut, timeout))
+	defer cancel()
+
+	fmt.Fprintf(w, "\nWaiting for deployment %s...\n", deploymentID)
+	start := time.Now()
+	last := ""
+	err := poll(ctx, statusPollBackoff, func(ctx context.Context) (bool, error) {
+		deployments, err := appService.ListDeployments(ctx, appID)
+		if err != nil {
+			return false, err
+		}
+
+		i := slices.IndexFunc(deployments, func(d iface.Deployment) bool { return d.ID == deploymentID })
+		if i < 0 {
+			// not listed yet
+			return false, nil
+		}
+		d := deployments[i]
+
+		if d.Status != last {
+			fmt.Fprintf(w, "  [%s] %s\n", time.Since(start).Round(time.Second), d.Status)
+			last = d.Status
+		}
+
+		switch {
+		case d.Status == iface.DeployStatusError:
+			return false, fmt.Errorf("deployment %s failed", deploymentID)
+		case d.Status == iface.DeployStatusRunning && d.Current:
+			fmt.Fprintln(w, "✓ Deployment is running")
+			return true, nil
+		}
+		return false, nil
+	})
+	if err != nil && ctx.Err() != nil {
+		return deployWaitStopped(ctx, appID)
+	}
+	return err
+}
+
+// deployWaitStopped explains why waitForDeploy gave up; the deployment
+// itself carries on either way
+func deployWaitStopped(ctx context.Context, appID string) error {
+	if cause := context.Cause(ctx); errors.Is(cause, errWaitTimedOut) {
+		return fmt.Errorf("%w; the deployment continues in the background\n\nCheck status with: kamui apps get %s\nSee its deployments with: kamui apps deployments %s", cause, appID, appID)
+	}
+	return fmt.Errorf("stopped waiting; the deployment continues in the background\n\nCheck status with: kamui apps get %s", appID)
+}
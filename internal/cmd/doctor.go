@@ -0,0 +1,350 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/kamui-project/kamui-cli/internal/service"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// doctorProbeTimeout bounds the API reachability check
+const doctorProbeTimeout = 10 * time.Second
+
+// checkStatus is the outcome of one doctor check
+type checkStatus string
+
+const (
+	checkPass checkStatus = "pass"
+	checkWarn checkStatus = "warn"
+	checkFail checkStatus = "fail"
+	checkSkip checkStatus = "skip"
+)
+
+// doctorCheck is one line of the doctor report. A failed check is
+// critical and makes the command exit non-zero; a warning is not.
+type doctorCheck struct {
+	Name    string      `json:"name"`
+	Status  checkStatus `json:"status"`
+	Message string      `json:"message"`
+	Hint    string      `json:"hint,omitempty"`
+}
+
+// doctorReport is the JSON output of doctor
+type doctorReport struct {
+	OK     bool          `json:"ok"`
+	Checks []doctorCheck `json:"checks"`
+}
+
+// DoctorCommand represents the doctor command
+type DoctorCommand struct {
+	root *RootCommand
+	cmd  *cobra.Command
+}
+
+// NewDoctorCommand creates a new doctor command
+func NewDoctorCommand(root *RootCommand) *DoctorCommand {
+	d := &DoctorCommand{
+		root: root,
+	}
+
+	d.cmd = &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose common setup problems",
+		Long: `Check that the CLI is set up to talk to Kamui Platform and print a
+pass/warn/fail report with a hint for each problem found.
+
+The checks are, in order: the config file can be read, the API URL answers,
+an access token is stored and not expired, the API accepts it, and a GitHub
+App installation exists for deploying from GitHub. Checks that depend on an
+earlier failed one are skipped.
+
+The command exits non-zero if any check fails; warnings don't affect the
+exit code.
+
+Examples:
+  kamui doctor
+  kamui doctor -o json`,
+		Args: cobra.NoArgs,
+		RunE: d.Run,
+		// doctor reports an expired session instead of logging in again
+		Annotations: map[string]string{noReloginAnnotation: "true"},
+	}
+
+	return d
+}
+
+// Command returns the underlying cobra command
+func (d *DoctorCommand) Command() *cobra.Command {
+	return d.cmd
+}
+
+// Run executes the doctor command
+func (d *DoctorCommand) Run(cmd *cobra.Command, args []string) error {
+	ctx := cmd.Context()
+	container := d.root.Container()
+	configManager := container.ConfigManager()
+
+	client := &http.Client{
+		Timeout:   doctorProbeTimeout,
+		Transport: api.SharedTransport(d.root.transport),
+	}
+
+	var checks []doctorCheck
+	skip := func(names ...string) {
+		for _, name := range names {
+			checks = append(checks, doctorCheck{Name: name, Status: checkSkip, Message: "skipped after an earlier failure"})
+		}
+	}
+
+	check, cfg := checkConfigFile(configManager)
+	checks = append(checks, check)
+	if cfg == nil {
+		skip("api", "token", "account", "github")
+		return d.report(cmd, checks)
+	}
+
+	apiURL, err := configManager.GetAPIURL()
+	if err != nil {
+		apiURL = cfg.APIURL
+	}
+	check = checkAPIReachable(ctx, client, apiURL)
+	checks = append(checks, check)
+	if check.Status == checkFail {
+		skip("token", "account", "github")
+		return d.report(cmd, checks)
+	}
+
+	check = checkToken(cfg, time.Now())
+	checks = append(checks, check)
+	if check.Status == checkFail {
+		skip("account", "github")
+		return d.report(cmd, checks)
+	}
+
+	check = checkAccount(ctx, container.AuthService())
+	checks = append(checks, check)
+	if check.Status == checkFail {
+		skip("github")
+		return d.report(cmd, checks)
+	}
+
+	checks = append(checks, checkGitHubInstallations(ctx, container.AppService()))
+	return d.report(cmd, checks)
+}
+
+// report prints the checks and returns an error if any of them failed
+func (d *DoctorCommand) report(cmd *cobra.Command, checks []doctorCheck) error {
+	failed := 0
+	for _, c := range checks {
+		if c.Status == checkFail {
+			failed++
+		}
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(doctorReport{OK: failed == 0, Checks: checks}); err != nil {
+			return err
+		}
+	} else {
+		rows := make([][]string, len(checks))
+		for i, c := range checks {
+			rows[i] = []string{checkSymbol(c.Status), c.Name, c.Message}
+		}
+		printTable(os.Stdout, "", []string{"", "CHECK", "RESULT"}, rows)
+
+		first := true
+		for _, c := range checks {
+			if c.Hint == "" || (c.Status != checkFail && c.Status != checkWarn) {
+				continue
+			}
+			if first {
+				fmt.Println("\nTo fix:")
+				first = false
+			}
+			fmt.Printf("  %s %s: %s\n", checkSymbol(c.Status), c.Name, c.Hint)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d doctor check(s) failed", failed)
+	}
+	return nil
+}
+
+// checkSymbol returns the marker printed in front of a check
+func checkSymbol(s checkStatus) string {
+	switch s {
+	case checkPass:
+		return "✓"
+	case checkWarn:
+		return "⚠"
+	case checkFail:
+		return "✗"
+	default:
+		return "-"
+	}
+}
+
+// checkConfigFile reads the config file. It returns the config, or nil
+// when the file exists but cannot be read. A missing file is only a
+// warning: the CLI runs with defaults until the first login.
+func checkConfigFile(m *config.Manager) (doctorCheck, *config.Config) {
+	check := doctorCheck{Name: "config"}
+	path := m.ConfigPath()
+
+	cfg, err := m.Load()
+	if err != nil {
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("cannot read %s: %v", path, err)
+		check.Hint = "fix the file's permissions or JSON, or remove it and run 'kamui login' again"
+		return check, nil
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("no config file at %s; using defaults", path)
+		check.Hint = "run 'kamui login' to create it"
+		return check, cfg
+	}
+
+	check.Status = checkPass
+	check.Message = path
+	return check, cfg
+}
+
+// checkAPIReachable sends a HEAD request to apiURL. Any HTTP response
+// counts as reachable; only a network or TLS failure fails the check.
+func checkAPIReachable(ctx context.Context, client *http.Client, apiURL string) doctorCheck {
+	check := doctorCheck{Name: "api"}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, apiURL, nil)
+	if err != nil {
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("invalid API URL %s: %v", apiURL, err)
+		check.Hint = "set a valid URL with 'kamui config set api_url <url>'"
+		return check
+	}
+	req.Header.Set("User-Agent", api.UserAgent())
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("%s is unreachable: %v", apiURL, err)
+		check.Hint = "check 'kamui config get api_url', your network and proxy (--proxy, HTTPS_PROXY), and --ca-cert if the API uses a private CA"
+		return check
+	}
+	resp.Body.Close()
+
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("%s answered in %s", apiURL, time.Since(start).Round(time.Millisecond))
+	if resp.StatusCode >= http.StatusInternalServerError {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("%s answered %s", apiURL, resp.Status)
+		check.Hint = "the API may be having problems; try again later"
+	}
+	return check
+}
+
+// checkToken inspects the stored credentials without calling the API. An
+// expired access token only warns when a refresh token can renew it.
+func checkToken(cfg *config.Config, now time.Time) doctorCheck {
+	check := doctorCheck{Name: "token"}
+
+	switch {
+	case cfg.AccessToken == "" && cfg.RefreshToken == "":
+		check.Status = checkFail
+		check.Message = "not logged in"
+		check.Hint = "run 'kamui login'"
+	case cfg.AccessToken == "":
+		check.Status = checkWarn
+		check.Message = "no access token; one will be requested with the stored refresh token"
+	case cfg.ExpiresAt.IsZero():
+		check.Status = checkPass
+		check.Message = "access token stored, with no expiry"
+	case cfg.ExpiresAt.After(now):
+		check.Status = checkPass
+		check.Message = fmt.Sprintf("access token valid until %s", cfg.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+	case cfg.RefreshToken != "":
+		check.Status = checkWarn
+		check.Message = "access token expired; it will be refreshed on the next command"
+	default:
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("access token expired at %s and cannot be refreshed", cfg.ExpiresAt.Local().Format("2006-01-02 15:04:05"))
+		check.Hint = "run 'kamui login'"
+	}
+	return check
+}
+
+// checkAccount checks the credentials against the API. Only a 401 or 403,
+// or a session that can no longer be refreshed, means the credentials were
+// rejected; any other error is reported as a connection problem.
+func checkAccount(ctx context.Context, authService iface.AuthService) doctorCheck {
+	check := doctorCheck{Name: "account"}
+
+	user, err := authService.GetCurrentUser(ctx)
+	if err != nil {
+		check.Status = checkFail
+		if credentialsRejected(err) {
+			check.Message = fmt.Sprintf("the API rejected the stored credentials: %v", err)
+			check.Hint = "run 'kamui login' again"
+		} else {
+			check.Message = fmt.Sprintf("could not verify the stored credentials: %v", err)
+			check.Hint = "check your network and proxy (--proxy, HTTPS_PROXY), and --ca-cert if the API uses a private CA"
+		}
+		return check
+	}
+
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("logged in as %s <%s>", user.Name, user.Email)
+	return check
+}
+
+// credentialsRejected reports whether err means the API or the OAuth server
+// refused the stored credentials
+func credentialsRejected(err error) bool {
+	if errors.Is(err, service.ErrSessionExpired) || errors.Is(err, service.ErrNotLoggedIn) {
+		return true
+	}
+	var apiErr *api.APIError
+	return errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+// checkGitHubInstallations checks that the Kamui GitHub App is installed
+// somewhere. Without it only Docker Hub and static apps can be deployed, so
+// this never fails the report.
+func checkGitHubInstallations(ctx context.Context, appService iface.AppService) doctorCheck {
+	check := doctorCheck{Name: "github"}
+
+	installations, err := appService.GetInstallations(ctx)
+	if err != nil {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("could not list GitHub installations: %v", err)
+		check.Hint = "try again later; GitHub deploys need the Kamui GitHub App"
+		return check
+	}
+	if len(installations) == 0 {
+		check.Status = checkWarn
+		check.Message = "no GitHub App installations; apps can't be deployed from GitHub"
+		check.Hint = "install the Kamui GitHub App on your account or organization"
+		if url, err := appService.GetGithubInstallURL(ctx); err == nil {
+			check.Hint += ": " + url
+		}
+		return check
+	}
+
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("%d GitHub App installation(s)", len(installations))
+	return check
+}
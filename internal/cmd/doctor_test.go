@@ -0,0 +1,283 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	"github.com/kamui-project/kamui-cli/internal/service"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestCheckConfigFile(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantStatus checkStatus
+		wantConfig bool
+	}{
+		{name: "missing file warns", wantStatus: checkWarn, wantConfig: true},
+		{name: "valid file", content: `{"api_url": "https://api.example.com"}`, wantStatus: checkPass, wantConfig: true},
+		{name: "broken json fails", content: `{"api_url": `, wantStatus: checkFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			if tt.content != "" {
+				if err := os.WriteFile(path, []byte(tt.content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			check, cfg := checkConfigFile(config.NewManagerWithPath(path))
+			if check.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s (%s)", check.Status, tt.wantStatus, check.Message)
+			}
+			if (cfg != nil) != tt.wantConfig {
+				t.Errorf("config returned = %v, want %v", cfg != nil, tt.wantConfig)
+			}
+		})
+	}
+}
+
+func TestCheckAPIReachable(t *testing.T) {
+	ok := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ok.Close()
+	broken := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer broken.Close()
+	closed := httptest.NewServer(http.NotFoundHandler())
+	closed.Close()
+
+	tests := []struct {
+		name       string
+		url        string
+		wantStatus checkStatus
+	}{
+		{name: "any response is reachable", url: ok.URL, wantStatus: checkPass},
+		{name: "server error warns", url: broken.URL, wantStatus: checkWarn},
+		{name: "connection refused fails", url: closed.URL, wantStatus: checkFail},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkAPIReachable(context.Background(), &http.Client{Timeout: 5 * time.Second}, tt.url)
+			if check.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s (%s)", check.Status, tt.wantStatus, check.Message)
+			}
+			if check.Status == checkFail && check.Hint == "" {
+				t.Error("failed check has no hint")
+			}
+		})
+	}
+}
+
+func TestCheckToken(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		cfg        config.Config
+		wantStatus checkStatus
+	}{
+		{name: "not logged in", cfg: config.Config{}, wantStatus: checkFail},
+		{name: "valid token", cfg: config.Config{AccessToken: "t", ExpiresAt: now.Add(time.Hour)}, wantStatus: checkPass},
+		{name: "token without expiry", cfg: config.Config{AccessToken: "t"}, wantStatus: checkPass},
+		{name: "expired but refreshable", cfg: config.Config{AccessToken: "t", RefreshToken: "r", ExpiresAt: now.Add(-time.Hour)}, wantStatus: checkWarn},
+		{name: "expired for good", cfg: config.Config{AccessToken: "t", ExpiresAt: now.Add(-time.Hour)}, wantStatus: checkFail},
+		{name: "refresh token only", cfg: config.Config{RefreshToken: "r"}, wantStatus: checkWarn},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkToken(&tt.cfg, now)
+			if check.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s (%s)", check.Status, tt.wantStatus, check.Message)
+			}
+		})
+	}
+}
+
+func TestCheckAccount(t *testing.T) {
+	check := checkAccount(context.Background(), &MockAuthService{
+		GetCurrentUserFunc: func(ctx context.Context) (*iface.User, error) {
+			return &iface.User{Name: "Ada", Email: "ada@example.com"}, nil
+		},
+	})
+	if check.Status != checkPass || !strings.Contains(check.Message, "ada@example.com") {
+		t.Errorf("check = %+v, want pass naming the account", check)
+	}
+
+	tests := []struct {
+		name     string
+		err      error
+		wantHint string
+	}{
+		{name: "401", err: &api.APIError{StatusCode: http.StatusUnauthorized, Message: "invalid token"}, wantHint: "kamui login"},
+		{name: "403", err: fmt.Errorf("failed to verify credentials: %w", &api.APIError{StatusCode: http.StatusForbidden}), wantHint: "kamui login"},
+		{name: "session expired", err: service.ErrSessionExpired, wantHint: "kamui login"},
+		{name: "server error", err: &api.APIError{StatusCode: http.StatusBadGateway}, wantHint: "proxy"},
+		{name: "network error", err: errors.New("dial tcp: connection refused"), wantHint: "proxy"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkAccount(context.Background(), &MockAuthService{
+				GetCurrentUserFunc: func(ctx context.Context) (*iface.User, error) {
+					return nil, tt.err
+				},
+			})
+			if check.Status != checkFail || !strings.Contains(check.Hint, tt.wantHint) {
+				t.Errorf("check = %+v, want a failure with a hint mentioning %q", check, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestCheckGitHubInstallations(t *testing.T) {
+	tests := []struct {
+		name          string
+		installations []iface.Installation
+		err           error
+		urlErr        error
+		wantStatus    checkStatus
+		wantHint      string
+	}{
+		{name: "installed", installations: []iface.Installation{{ID: 1, Owner: "my-org"}}, wantStatus: checkPass},
+		{name: "none installed warns with the install URL", wantStatus: checkWarn, wantHint: "install the Kamui GitHub App on your account or organization: https://github.com/apps/kamui/installations/new"},
+		{name: "none installed without an install URL", urlErr: errors.New("boom"), wantStatus: checkWarn, wantHint: "install the Kamui GitHub App on your account or organization"},
+		{name: "lookup error warns", err: errors.New("boom"), wantStatus: checkWarn, wantHint: "try again later; GitHub deploys need the Kamui GitHub App"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			check := checkGitHubInstallations(context.Background(), &MockAppService{
+				GetInstallationsFunc: func(ctx context.Context) ([]iface.Installation, error) {
+					return tt.installations, tt.err
+				},
+				GetGithubInstallURLFunc: func(ctx context.Context) (string, error) {
+					return "https://github.com/apps/kamui/installations/new", tt.urlErr
+				},
+			})
+			if check.Status != tt.wantStatus {
+				t.Errorf("status = %s, want %s (%s)", check.Status, tt.wantStatus, check.Message)
+			}
+			if check.Hint != tt.wantHint {
+				t.Errorf("hint = %q, want %q", check.Hint, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestDoctorCommand_Run(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	tests := []struct {
+		name         string
+		cfg          config.Config
+		wantErr      bool
+		wantOK       bool
+		wantStatuses map[string]checkStatus
+	}{
+		{
+			name:   "all good",
+			cfg:    config.Config{APIURL: server.URL, AccessToken: "t", ExpiresAt: time.Now().Add(time.Hour)},
+			wantOK: true,
+			wantStatuses: map[string]checkStatus{
+				"config": checkPass, "api": checkPass, "token": checkPass, "account": checkPass, "github": checkPass,
+			},
+		},
+		{
+			name:    "not logged in skips the API checks",
+			cfg:     config.Config{APIURL: server.URL},
+			wantErr: true,
+			wantStatuses: map[string]checkStatus{
+				"config": checkPass, "api": checkPass, "token": checkFail, "account": checkSkip, "github": checkSkip,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			configManager := config.NewManagerWithPath(path)
+			if err := configManager.Save(&tt.cfg); err != nil {
+				t.Fatal(err)
+			}
+
+			mockAuth := &MockAuthService{
+				GetCurrentUserFunc: func(ctx context.Context) (*iface.User, error) {
+					return &iface.User{Name: "Ada", Email: "ada@example.com"}, nil
+				},
+			}
+			mockApp := &MockAppService{
+				GetInstallationsFunc: func(ctx context.Context) ([]iface.Installation, error) {
+					return []iface.Installation{{ID: 1, Owner: "my-org"}}, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(mockAuth, &MockProjectService{}, mockApp).WithConfigManager(configManager))
+
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			r, w, _ := os.Pipe()
+			errR, errW, _ := os.Pipe()
+			os.Stdout, os.Stderr = w, errW
+
+			root.Command().SetArgs([]string{"doctor", "-o", "json", "--insecure-skip-verify"})
+			err := root.Command().Execute()
+
+			w.Close()
+			errW.Close()
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+			var buf, stderr bytes.Buffer
+			io.Copy(&buf, r)
+			io.Copy(&stderr, errR)
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if n := strings.Count(stderr.String(), "TLS certificate verification is disabled"); n != 1 {
+				t.Errorf("insecure TLS warning printed %d times, want once:\n%s", n, stderr.String())
+			}
+
+			var report doctorReport
+			if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+				t.Fatalf("stdout is not valid JSON: %v\n%s", err, buf.String())
+			}
+			if report.OK != tt.wantOK {
+				t.Errorf("ok = %v, want %v", report.OK, tt.wantOK)
+			}
+			got := map[string]checkStatus{}
+			for _, c := range report.Checks {
+				got[c.Name] = c.Status
+			}
+			for name, want := range tt.wantStatuses {
+				if got[name] != want {
+					t.Errorf("check %s = %s, want %s", name, got[name], want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// envNamePattern matches a valid environment variable name
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// readEnvFile reads a dotenv file into a map of environment variables
+func readEnvFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+	vars, err := parseDotenv(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid env file %s: %w", path, err)
+	}
+	return vars, nil
+}
+
+// parseDotenv parses dotenv-formatted data. Each line is KEY=VALUE,
+// optionally prefixed with "export". Blank lines and lines starting with #
+// are ignored. Values may be single-quoted (taken literally) or
+// double-quoted (supporting \n, \t, \", and \\ escapes); unquoted values end
+// at a " #" comment. A later assignment of the same key wins.
+func parseDotenv(data []byte) (map[string]string, error) {
+	vars := make(map[string]string)
+	var errs []error
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if lineNo == 1 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if rest, ok := strings.CutPrefix(line, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+			line = strings.TrimSpace(rest)
+		}
+
+		key, raw, ok := strings.Cut(line, "=")
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: expected KEY=VALUE", lineNo))
+			continue
+		}
+		key = strings.TrimSpace(key)
+		if !envNamePattern.MatchString(key) {
+			errs = append(errs, fmt.Errorf("line %d: invalid variable name %q", lineNo, key))
+			continue
+		}
+
+		value, err := parseDotenvValue(raw)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %s: %w", lineNo, key, err))
+			continue
+		}
+		vars[key] = value
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// parseDotenvValue unquotes the value part of a dotenv line and strips any
+// trailing comment
+func parseDotenvValue(raw string) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || (trimmed != raw && trimmed[0] == '#') {
+		return "", nil
+	}
+	raw = trimmed
+
+	var value, rest string
+	switch raw[0] {
+	case '\'':
+		end := strings.IndexByte(raw[1:], '\'')
+		if end < 0 {
+			return "", errors.New("unterminated single-quoted value")
+		}
+		value, rest = raw[1:end+1], raw[end+2:]
+	case '"':
+		var b strings.Builder
+		i := 1
+		for ; i < len(raw) && raw[i] != '"'; i++ {
+			if raw[i] != '\\' || i+1 == len(raw) {
+				b.WriteByte(raw[i])
+				continue
+			}
+			i++
+			switch raw[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 't':
+				b.WriteByte('\t')
+			case 'r':
+				b.WriteByte('\r')
+			case '"', '\\':
+				b.WriteByte(raw[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(raw[i])
+			}
+		}
+		if i >= len(raw) {
+			return "", errors.New("unterminated double-quoted value")
+		}
+		value, rest = b.String(), raw[i+1:]
+	default:
+		// An unquoted value ends at a comment preceded by whitespace
+		for i := 1; i < len(raw); i++ {
+			if raw[i] == '#' && (raw[i-1] == ' ' || raw[i-1] == '\t') {
+				raw = raw[:i]
+				break
+			}
+		}
+		return strings.TrimSpace(raw), nil
+	}
+
+	if rest = strings.TrimSpace(rest); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected text after closing quote: %q", rest)
+	}
+	return value, nil
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		want     map[string]string
+		wantErrs []string
+	}{
+		{
+			name: "plain values, comments and blank lines",
+			data: "# database\nDB_HOST=localhost\n\n  DB_PORT = 5432  \n# trailing\n",
+			want: map[string]string{"DB_HOST": "localhost", "DB_PORT": "5432"},
+		},
+		{
+			name: "export prefix",
+			data: "export API_KEY=abc\nexport\tREGION=tokyo\nexporter=yes\n",
+			want: map[string]string{"API_KEY": "abc", "REGION": "tokyo", "exporter": "yes"},
+		},
+		{
+			name: "inline comments",
+			data: "A=value # comment\nB=color#fff\nC= # only a comment\nD=\n",
+			want: map[string]string{"A": "value", "B": "color#fff", "C": "", "D": ""},
+		},
+		{
+			name: "single quotes are literal",
+			data: `A='hello # not a comment'` + "\n" + `B='C:\path\n'` + "\n",
+			want: map[string]string{"A": "hello # not a comment", "B": `C:\path\n`},
+		},
+		{
+			name:     "text after closing quote",
+			data:     `A='it''s'` + "\n",
+			wantErrs: []string{`line 1: A: unexpected text after closing quote: "'s'"`},
+		},
+		{
+			name: "double quotes with escapes",
+			data: `A="line1\nline2"` + "\n" + `B="say \"hi\"" # comment` + "\n" + `C="back\\slash"` + "\n" + `D=" padded "` + "\n",
+			want: map[string]string{"A": "line1\nline2", "B": `say "hi"`, "C": `back\slash`, "D": " padded "},
+		},
+		{
+			name: "equals sign in value",
+			data: "URL=postgres://u:p@h/db?sslmode=require\nQ='a=b'\n",
+			want: map[string]string{"URL": "postgres://u:p@h/db?sslmode=require", "Q": "a=b"},
+		},
+		{
+			name: "later assignment wins",
+			data: "A=1\nA=2\n",
+			want: map[string]string{"A": "2"},
+		},
+		{
+			name: "byte order mark and CRLF",
+			data: "\ufeffA=1\r\nB=\"2\"\r\n",
+			want: map[string]string{"A": "1", "B": "2"},
+		},
+		{
+			name: "malformed lines report every line number",
+			data: "OK=1\nNOEQUALS\n1BAD=x\nQ=\"open\nS='open\n",
+			wantErrs: []string{
+				"line 2: expected KEY=VALUE",
+				`line 3: invalid variable name "1BAD"`,
+				"line 4: Q: unterminated double-quoted value",
+				"line 5: S: unterminated single-quoted value",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseDotenv([]byte(tt.data))
+			if len(tt.wantErrs) > 0 {
+				if err == nil {
+					t.Fatalf("parseDotenv() = %v, want error", got)
+				}
+				for _, want := range tt.wantErrs {
+					if !strings.Contains(err.Error(), want) {
+						t.Errorf("error should contain %q, got: %v", want, err)
+					}
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseDotenv() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseDotenv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadEnvFile_NamesFileInError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".env")
+	if err := os.WriteFile(path, []byte("GOOD=1\nBAD LINE\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := readEnvFile(path)
+	if err == nil || !strings.Contains(err.Error(), path) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("readEnvFile() error = %v, want file name and line 2", err)
+	}
+}
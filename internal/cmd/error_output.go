@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/redact"
+	"github.com/spf13/cobra"
+)
+
+// jsonErrors records that the command line asked for JSON errors, so
+// ExitWithError can honor it outside of a command's returned error
+var jsonErrors bool
+
+// errorReport is the document written to stderr in --json-errors mode
+type errorReport struct {
+	Error errorDetail `json:"error"`
+}
+
+// errorDetail describes a failed command. Status, RequestID, and Fields
+// are only set for API error responses.
+type errorDetail struct {
+	Message   string           `json:"message"`
+	Code      int              `json:"code"`
+	Status    int              `json:"status,omitempty"`
+	RequestID string           `json:"request_id,omitempty"`
+	Fields    []api.FieldError `json:"fields,omitempty"`
+}
+
+// jsonErrorsEnabled reports whether errors of cmd should be written as
+// JSON: with --json-errors, or implied by -o json
+func jsonErrorsEnabled(cmd *cobra.Command) bool {
+	if f := cmd.Flag("json-errors"); f != nil && f.Value.String() == "true" {
+		return true
+	}
+	return resolveOutputFormat(cmd) == "json"
+}
+
+// printCommandError reports an error returned by cmd on its stderr, as JSON
+// when jsonErrorsEnabled, otherwise in the same form cobra prints it. Secrets
+// in the message are masked either way.
+func printCommandError(cmd *cobra.Command, err error) {
+	if jsonErrorsEnabled(cmd) {
+		writeJSONError(cmd.ErrOrStderr(), err)
+		return
+	}
+
+	cmd.PrintErrln(cmd.ErrPrefix(), redact.String(err.Error()))
+	if strings.HasPrefix(err.Error(), "unknown command ") {
+		cmd.PrintErrf("Run '%v --help' for usage.\n", cmd.CommandPath())
+		return
+	}
+	cmd.PrintErrln(cmd.UsageString())
+}
+
+// writeJSONError writes err as a single-line errorReport, with the exit
+// code ExitCode picks and the details of a wrapped *api.APIError
+func writeJSONError(w io.Writer, err error) {
+	detail := errorDetail{
+		Message: redact.String(err.Error()),
+		Code:    ExitCode(err),
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		detail.Status = apiErr.StatusCode
+		detail.RequestID = apiErr.RequestID
+		detail.Fields = apiErr.FieldErrors
+	}
+
+	data, marshalErr := json.Marshal(errorReport{Error: detail})
+	if marshalErr != nil {
+		fmt.Fprintf(w, "Error: %v\n", err)
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestWriteJSONError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantCode      int
+		wantStatus    int
+		wantRequestID string
+	}{
+		{
+			name: "API error",
+			err: fmt.Errorf("failed to fetch projects: %w", &api.APIError{
+				StatusCode: http.StatusForbidden,
+				Message:    "forbidden",
+				RequestID:  "req-123",
+			}),
+			wantCode:      ExitAuth,
+			wantStatus:    http.StatusForbidden,
+			wantRequestID: "req-123",
+		},
+		{
+			name:     "generic error",
+			err:      errors.New("something broke"),
+			wantCode: ExitError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			writeJSONError(&buf, tt.err)
+
+			var got errorReport
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("invalid JSON %q: %v", buf.String(), err)
+			}
+			if got.Error.Message != tt.err.Error() {
+				t.Errorf("message = %q, want %q", got.Error.Message, tt.err.Error())
+			}
+			if got.Error.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d", got.Error.Code, tt.wantCode)
+			}
+			if got.Error.Status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got.Error.Status, tt.wantStatus)
+			}
+			if got.Error.RequestID != tt.wantRequestID {
+				t.Errorf("request_id = %q, want %q", got.Error.RequestID, tt.wantRequestID)
+			}
+			if tt.wantStatus == 0 && strings.Contains(buf.String(), `"status"`) {
+				t.Errorf("status should be omitted for a generic error: %s", buf.String())
+			}
+		})
+	}
+}
+
+func TestWriteJSONError_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	writeJSONError(&buf, errors.New(`token refresh failed: body "refresh_token=r-secret&client_secret=c-secret", Authorization: Bearer at-secret`))
+
+	for _, secret := range []string{"r-secret", "c-secret", "at-secret"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("error output leaked %q: %s", secret, buf.String())
+		}
+	}
+}
+
+func TestRootCommand_Execute_ErrorFormat(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		wantJSON bool
+	}{
+		{name: "text by default", args: []string{"projects", "list"}},
+		{name: "json-errors flag", args: []string{"projects", "list", "--json-errors"}, wantJSON: true},
+		{name: "implied by -o json", args: []string{"projects", "list", "-o", "json"}, wantJSON: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProject := &MockProjectService{
+				ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+					return nil, &api.APIError{StatusCode: http.StatusInternalServerError, Message: "boom"}
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithServices(&MockAuthService{}, mockProject))
+			var stderr bytes.Buffer
+			root.Command().SetErr(&stderr)
+			root.Command().SetOut(&bytes.Buffer{})
+			root.Command().SetArgs(tt.args)
+
+			err := root.Execute()
+			if ExitCode(err) != ExitAPI {
+				t.Fatalf("ExitCode() = %d, want %d (error %v)", ExitCode(err), ExitAPI, err)
+			}
+
+			if !tt.wantJSON {
+				if !strings.HasPrefix(stderr.String(), "Error: ") {
+					t.Errorf("stderr = %q, want a human-readable error", stderr.String())
+				}
+				return
+			}
+
+			var got errorReport
+			if err := json.Unmarshal(stderr.Bytes(), &got); err != nil {
+				t.Fatalf("stderr is not a JSON error %q: %v", stderr.String(), err)
+			}
+			if got.Error.Status != http.StatusInternalServerError || got.Error.Code != ExitAPI {
+				t.Errorf("error = %+v, want status 500 and code %d", got.Error, ExitAPI)
+			}
+			if !strings.Contains(got.Error.Message, "boom") {
+				t.Errorf("message = %q, want containing %q", got.Error.Message, "boom")
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/service"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes returned by the kamui binary, so scripts can tell failure
+// classes apart
+const (
+	// ExitOK means the command succeeded
+	ExitOK = 0
+
+	// ExitError is any failure not covered by a more specific code
+	ExitError = 1
+
+	// ExitUsage means the arguments or flags were invalid
+	ExitUsage = 2
+
+	// ExitAuth means the user is not logged in or the credentials were rejected
+	ExitAuth = 3
+
+	// ExitNotFound means the requested project, app, or other resource does not exist
+	ExitNotFound = 4
+
+	// ExitAPI means the API returned an error response
+	ExitAPI = 5
+
+	// ExitNetwork means the API could not be reached or the command timed out
+	ExitNetwork = 6
+
+	// ExitWaitTimeout means a wait for a deployment or app status ran past
+	// --wait-timeout while it was still in progress
+	ExitWaitTimeout = 7
+)
+
+var (
+	// errProjectNotFound is wrapped by project lookups that find no match
+	errProjectNotFound = errors.New("project not found")
+
+	// errAppNotFound is wrapped by app lookups that find no match
+	errAppNotFound = errors.New("app not found")
+
+	// errDatabaseNotFound is wrapped by database lookups that find no match
+	errDatabaseNotFound = errors.New("database not found")
+)
+
+// usageError marks an error caused by invalid arguments or flags
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// markUsageErrors wraps flag parsing and argument validation errors of c
+// and its subcommands in usageError
+func markUsageErrors(c *cobra.Command) {
+	c.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+	if args := c.Args; args != nil {
+		c.Args = func(cmd *cobra.Command, a []string) error {
+			if err := args(cmd, a); err != nil {
+				return &usageError{err: err}
+			}
+			return nil
+		}
+	}
+	for _, sub := range c.Commands() {
+		markUsageErrors(sub)
+	}
+}
+
+// ExitCode maps an error returned by a command to the process exit code
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var usageErr *usageError
+	if errors.As(err, &usageErr) || strings.HasPrefix(err.Error(), "unknown command ") {
+		return ExitUsage
+	}
+	if errors.Is(err, service.ErrNotLoggedIn) || errors.Is(err, service.ErrSessionExpired) {
+		return ExitAuth
+	}
+	if errors.Is(err, errProjectNotFound) || errors.Is(err, errAppNotFound) || errors.Is(err, errDatabaseNotFound) {
+		return ExitNotFound
+	}
+
+	var apiErr *api.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.IsUnauthorized(), apiErr.StatusCode == http.StatusForbidden:
+			return ExitAuth
+		case apiErr.IsNotFound():
+			return ExitNotFound
+		default:
+			return ExitAPI
+		}
+	}
+
+	if errors.Is(err, errWaitTimedOut) {
+		return ExitWaitTimeout
+	}
+
+	var netErr net.Error
+	if errors.Is(err, errOperationTimedOut) || errors.As(err, &netErr) {
+		return ExitNetwork
+	}
+
+	return ExitError
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/service"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: ExitOK},
+		{name: "generic", err: errors.New("boom"), want: ExitError},
+		{name: "usage", err: &usageError{err: errors.New("accepts 1 arg(s), received 0")}, want: ExitUsage},
+		{name: "unknown command", err: errors.New(`unknown command "frobnicate" for "kamui"`), want: ExitUsage},
+		{name: "not logged in", err: service.ErrNotLoggedIn, want: ExitAuth},
+		{name: "session expired wrapped", err: fmt.Errorf("failed to fetch projects: %w", service.ErrSessionExpired), want: ExitAuth},
+		{name: "api 401", err: &api.APIError{StatusCode: 401}, want: ExitAuth},
+		{name: "api 403", err: &api.APIError{StatusCode: 403}, want: ExitAuth},
+		{name: "api 404", err: &api.APIError{StatusCode: 404}, want: ExitNotFound},
+		{name: "project lookup", err: fmt.Errorf("%w: my-project", errProjectNotFound), want: ExitNotFound},
+		{name: "app lookup", err: fmt.Errorf("%w: web", errAppNotFound), want: ExitNotFound},
+		{name: "api 500", err: fmt.Errorf("failed: %w", &api.APIError{StatusCode: 500}), want: ExitAPI},
+		{name: "api 422", err: &api.APIError{StatusCode: 422}, want: ExitAPI},
+		{
+			name: "network",
+			err:  fmt.Errorf("request failed: %w", &url.Error{Op: "Get", URL: "https://api.test", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}}),
+			want: ExitNetwork,
+		},
+		{name: "wait timeout", err: fmt.Errorf("%w: deployment still in progress after 5m0s", errWaitTimedOut), want: ExitWaitTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode_CommandLineErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+	}{
+		{name: "unknown flag", args: []string{"projects", "list", "--bogus"}},
+		{name: "missing argument", args: []string{"projects", "get"}},
+		{name: "unknown command", args: []string{"frobnicate"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := NewRootCommand()
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+			root.Command().SetArgs(tt.args)
+
+			err := root.Command().Execute()
+			if got := ExitCode(err); got != ExitUsage {
+				t.Errorf("ExitCode(%v) = %d, want %d", err, got, ExitUsage)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// ensureInstallations returns the repositories the Kamui GitHub App can
+// access. When there are none, it shows the URL that installs the app and,
+// on a terminal, offers to open it and fetches the installations again once
+// the user has finished. Elsewhere the URL is part of the error.
+func ensureInstallations(ctx context.Context, appService iface.AppService, interactive bool, w io.Writer) ([]iface.Installation, error) {
+	fmt.Fprintln(w, "\nFetching GitHub repositories...")
+	installations, err := fetchInstallations(ctx, appService)
+	if err != nil || len(installations) > 0 {
+		return installations, err
+	}
+
+	url, err := appService.GetGithubInstallURL(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("no GitHub repositories found, and %w", err)
+	}
+	if !interactive {
+		return nil, fmt.Errorf("no GitHub repositories found. Install the Kamui GitHub App on the repositories to deploy:\n  %s", url)
+	}
+
+	fmt.Fprintln(w, "\nNo GitHub repositories are connected to Kamui yet.")
+	fmt.Fprintf(w, "Install the Kamui GitHub App on the repositories to deploy:\n  %s\n\n", url)
+	openPage, err := askConfirm("Open this page in your browser?", true)
+	if err != nil {
+		return nil, err
+	}
+	if openPage {
+		if err := openURL(url); err != nil {
+			fmt.Fprintf(w, "Could not open a browser (%v); visit the URL above instead.\n", err)
+		}
+	}
+
+	for {
+		done, err := askConfirm("Have you finished installing the GitHub App?", true)
+		if err != nil {
+			return nil, err
+		}
+		if !done {
+			return nil, fmt.Errorf("no GitHub repositories connected; run the command again after installing the GitHub App:\n  %s", url)
+		}
+
+		fmt.Fprintln(w, "\nFetching GitHub repositories...")
+		installations, err := fetchInstallations(ctx, appService)
+		if err != nil || len(installations) > 0 {
+			return installations, err
+		}
+		fmt.Fprintln(w, "Still no repositories found. The installation may take a moment to show up.")
+	}
+}
+
+// fetchInstallations lists the GitHub App installations behind a spinner
+func fetchInstallations(ctx context.Context, appService iface.AppService) ([]iface.Installation, error) {
+	sp := startSpinner()
+	installations, err := appService.GetInstallations(ctx)
+	sp.Stop()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch GitHub repositories: %w", err)
+	}
+	return installations, nil
+}
+
+// splitRepoFlag resolves --owner and --repo, where --repo may be given as
+// owner/name. A bare name keeps owner as given; with owner/name, --owner
+// may be omitted or must name the same owner.
+func splitRepoFlag(owner, repo string) (string, string, error) {
+	if !strings.Contains(repo, "/") {
+		return owner, repo, nil
+	}
+	repoOwner, name, _ := strings.Cut(repo, "/")
+	if repoOwner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("--repo must be a repository name or owner/name (got %q)", repo)
+	}
+	if owner != "" && !strings.EqualFold(owner, repoOwner) {
+		return "", "", fmt.Errorf("--owner %s does not match the owner in --repo %s", owner, repo)
+	}
+	return repoOwner, name, nil
+}
+
+// lookupOwnerType finds whether owner is a GitHub Organization or User from
+// the installation of the Kamui GitHub App on owner/repo
+func lookupOwnerType(ctx context.Context, appService iface.AppService, owner, repo string) (string, error) {
+	installations, err := fetchInstallations(ctx, appService)
+	if err != nil {
+		return "", err
+	}
+	for _, inst := range installations {
+		if strings.EqualFold(inst.Owner, owner) && strings.EqualFold(inst.Repository, repo) && inst.OwnerType != "" {
+			return inst.OwnerType, nil
+		}
+	}
+	return "", fmt.Errorf("repository %s/%s is not accessible to the Kamui GitHub App; check the installation or pass --owner-type", owner, repo)
+}
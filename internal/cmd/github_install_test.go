@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestEnsureInstallations(t *testing.T) {
+	const installURL = "https://github.com/apps/kamui/installations/new"
+	repo := iface.Installation{ID: 1, Owner: "acme", Repository: "web", OwnerType: "Organization"}
+
+	tests := []struct {
+		name        string
+		interactive bool
+		fetches     [][]iface.Installation
+		urlErr      error
+		answers     []bool
+		wantOpened  bool
+		wantRepos   int
+		wantErr     string
+		wantOutput  []string
+	}{
+		{
+			name:      "installations found",
+			fetches:   [][]iface.Installation{{repo}},
+			wantRepos: 1,
+		},
+		{
+			name:    "non-interactive prints the install URL",
+			fetches: [][]iface.Installation{nil},
+			wantErr: "Install the Kamui GitHub App on the repositories to deploy:\n  " + installURL,
+		},
+		{
+			name:        "installs, then fetches again",
+			interactive: true,
+			fetches:     [][]iface.Installation{nil, nil, {repo}},
+			answers:     []bool{true, true, true},
+			wantOpened:  true,
+			wantRepos:   1,
+			wantOutput:  []string{installURL, "Still no repositories found"},
+		},
+		{
+			name:        "user gives up",
+			interactive: true,
+			fetches:     [][]iface.Installation{nil},
+			answers:     []bool{false, false},
+			wantErr:     "run the command again after installing the GitHub App:\n  " + installURL,
+			wantOutput:  []string{installURL},
+		},
+		{
+			name:    "install URL unavailable",
+			fetches: [][]iface.Installation{nil},
+			urlErr:  errors.New("failed to fetch GitHub App install URL: boom"),
+			wantErr: "no GitHub repositories found, and failed to fetch GitHub App install URL: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fetches := 0
+			appService := &MockAppService{
+				GetInstallationsFunc: func(ctx context.Context) ([]iface.Installation, error) {
+					installations := tt.fetches[min(fetches, len(tt.fetches)-1)]
+					fetches++
+					return installations, nil
+				},
+				GetGithubInstallURLFunc: func(ctx context.Context) (string, error) {
+					if tt.urlErr != nil {
+						return "", tt.urlErr
+					}
+					return installURL, nil
+				},
+			}
+
+			oldConfirm, oldOpen := askConfirm, openURL
+			defer func() { askConfirm, openURL = oldConfirm, oldOpen }()
+			answers := tt.answers
+			askConfirm = func(message string, def bool) (bool, error) {
+				if len(answers) == 0 {
+					t.Fatalf("unexpected prompt %q", message)
+				}
+				answer := answers[0]
+				answers = answers[1:]
+				return answer, nil
+			}
+			opened := ""
+			openURL = func(url string) error {
+				opened = url
+				return nil
+			}
+
+			var out bytes.Buffer
+			installations, err := ensureInstallations(context.Background(), appService, tt.interactive, &out)
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ensureInstallations() error = %v, want containing %q", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("ensureInstallations() error = %v", err)
+			}
+			if len(installations) != tt.wantRepos {
+				t.Errorf("got %d installations, want %d", len(installations), tt.wantRepos)
+			}
+			if tt.wantOpened != (opened == installURL) {
+				t.Errorf("opened %q, want opened %v", opened, tt.wantOpened)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(out.String(), want) {
+					t.Errorf("output should contain %q, got: %s", want, out.String())
+				}
+			}
+		})
+	}
+}
+
+func TestSplitRepoFlag(t *testing.T) {
+	tests := []struct {
+		owner     string
+		repo      string
+		wantOwner string
+		wantRepo  string
+		wantErr   string
+	}{
+		{owner: "my-org", repo: "web", wantOwner: "my-org", wantRepo: "web"},
+		{repo: "my-org/web", wantOwner: "my-org", wantRepo: "web"},
+		{owner: "My-Org", repo: "my-org/web", wantOwner: "my-org", wantRepo: "web"},
+		{repo: "web", wantRepo: "web"},
+		{owner: "other", repo: "my-org/web", wantErr: "does not match"},
+		{repo: "my-org/", wantErr: "owner/name"},
+		{repo: "/web", wantErr: "owner/name"},
+		{repo: "my-org/web/extra", wantErr: "owner/name"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.owner+" "+tt.repo, func(t *testing.T) {
+			owner, repo, err := splitRepoFlag(tt.owner, tt.repo)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("splitRepoFlag() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("splitRepoFlag() error = %v", err)
+			}
+			if owner != tt.wantOwner || repo != tt.wantRepo {
+				t.Errorf("splitRepoFlag() = %q, %q, want %q, %q", owner, repo, tt.wantOwner, tt.wantRepo)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// healthCheckMethods are the HTTP methods a health check can use
+var healthCheckMethods = []string{http.MethodGet, http.MethodHead}
+
+// healthCheck holds the health check settings beyond the path. Zero values
+// leave a setting to the platform default.
+type healthCheck struct {
+	method       string
+	port         int
+	status       int
+	interval     time.Duration
+	timeout      time.Duration
+	initialDelay time.Duration
+}
+
+// addFlags registers the --health-* flags on cmd
+func (h *healthCheck) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVar(&h.method, "health-method", "", "Health check HTTP method: GET or HEAD (default GET)")
+	flags.IntVar(&h.port, "health-port", 0, "Port the health check connects to (default the app's port)")
+	flags.IntVar(&h.status, "health-status", 0, "HTTP status the health check expects (default any 2xx or 3xx)")
+	flags.DurationVar(&h.interval, "health-interval", 0, "Time between health checks, in whole seconds (default platform setting)")
+	flags.DurationVar(&h.timeout, "health-timeout", 0, "Time a health check may take, in whole seconds (default platform setting)")
+	flags.DurationVar(&h.initialDelay, "health-initial-delay", 0, "Time to wait after start before the first health check (default platform setting)")
+}
+
+// isSet reports whether any setting differs from its default
+func (h healthCheck) isSet() bool {
+	return h != healthCheck{}
+}
+
+// validate normalizes the method and checks the numeric settings
+func (h *healthCheck) validate() error {
+	if h.method != "" {
+		h.method = strings.ToUpper(h.method)
+		if !slices.Contains(healthCheckMethods, h.method) {
+			return fmt.Errorf("--health-method must be %s", strings.Join(healthCheckMethods, " or "))
+		}
+	}
+	if h.port < 0 || h.port > 65535 {
+		return fmt.Errorf("--health-port must be between 1 and 65535")
+	}
+	if h.status != 0 && (h.status < 100 || h.status > 599) {
+		return fmt.Errorf("--health-status must be an HTTP status between 100 and 599")
+	}
+	for _, d := range []struct {
+		flag  string
+		value time.Duration
+		min   time.Duration
+	}{
+		{"--health-interval", h.interval, time.Second},
+		{"--health-timeout", h.timeout, time.Second},
+		{"--health-initial-delay", h.initialDelay, 0},
+	} {
+		if d.value == 0 {
+			continue
+		}
+		if d.value < d.min {
+			return fmt.Errorf("%s must be at least %s", d.flag, d.min)
+		}
+		if d.value%time.Second != 0 {
+			return fmt.Errorf("%s must be a whole number of seconds", d.flag)
+		}
+	}
+	if h.interval != 0 && h.timeout > h.interval {
+		return fmt.Errorf("--health-timeout must not be longer than --health-interval")
+	}
+	return nil
+}
+
+// apply copies the settings into input
+func (h healthCheck) apply(input *iface.CreateAppInput) {
+	input.HealthCheckMethod = h.method
+	input.HealthCheckPort = h.port
+	input.HealthCheckStatus = h.status
+	input.HealthCheckInterval = h.interval
+	input.HealthCheckTimeout = h.timeout
+	input.HealthCheckInitialDelay = h.initialDelay
+}
+
+// promptHealthCheck asks whether to customize the health check and, if
+// so, for each setting. Empty answers keep the platform defaults.
+func promptHealthCheck() (healthCheck, error) {
+	var h healthCheck
+
+	var customize bool
+	if err := ask(&survey.Confirm{
+		Message: "Customize the health check (method, port, status, timing)?",
+		Default: false,
+	}, &customize); err != nil {
+		return h, err
+	}
+	if !customize {
+		return h, nil
+	}
+
+	if err := ask(&survey.Select{
+		Message: "Health check method:",
+		Options: healthCheckMethods,
+		Default: http.MethodGet,
+	}, &h.method); err != nil {
+		return h, err
+	}
+
+	var err error
+	if h.port, err = askInt("Health check port (empty for the app's port):", 1, 65535); err != nil {
+		return h, err
+	}
+	if h.status, err = askInt("Expected HTTP status (empty for any 2xx or 3xx):", 100, 599); err != nil {
+		return h, err
+	}
+	if h.interval, err = askSeconds("Interval between checks in seconds (empty for the default):", 1); err != nil {
+		return h, err
+	}
+	if h.timeout, err = askSeconds("Timeout per check in seconds (empty for the default):", 1); err != nil {
+		return h, err
+	}
+	if h.initialDelay, err = askSeconds("Initial delay in seconds (empty for the default):", 0); err != nil {
+		return h, err
+	}
+
+	return h, h.validate()
+}
+
+// askInt asks for an optional integer between min and max; an empty answer
+// returns 0
+func askInt(message string, min, max int) (int, error) {
+	var answer string
+	if err := ask(&survey.Input{Message: message}, &answer, survey.WithValidator(func(ans interface{}) error {
+		_, err := parseOptionalInt(ans.(string), min, max)
+		return err
+	})); err != nil {
+		return 0, err
+	}
+	return parseOptionalInt(answer, min, max)
+}
+
+// askSeconds asks for an optional number of seconds of at least min
+func askSeconds(message string, min int) (time.Duration, error) {
+	n, err := askInt(message, min, 24*60*60)
+	return time.Duration(n) * time.Second, err
+}
+
+// parseOptionalInt parses s as an integer between min and max; an empty s
+// returns 0
+func parseOptionalInt(s string, min, max int) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("enter a number between %d and %d", min, max)
+	}
+	return n, nil
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthCheck_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		h          healthCheck
+		wantMethod string
+		wantErr    string
+	}{
+		{name: "defaults"},
+		{
+			name:       "all settings",
+			h:          healthCheck{method: "head", port: 9090, status: 204, interval: 30 * time.Second, timeout: 5 * time.Second, initialDelay: time.Minute},
+			wantMethod: "HEAD",
+		},
+		{name: "unknown method", h: healthCheck{method: "DELETE"}, wantErr: "--health-method"},
+		{name: "port out of range", h: healthCheck{port: 70000}, wantErr: "--health-port"},
+		{name: "negative port", h: healthCheck{port: -1}, wantErr: "--health-port"},
+		{name: "status out of range", h: healthCheck{status: 42}, wantErr: "--health-status"},
+		{name: "interval below a second", h: healthCheck{interval: 500 * time.Millisecond}, wantErr: "--health-interval must be at least"},
+		{name: "fractional timeout", h: healthCheck{timeout: 1500 * time.Millisecond}, wantErr: "--health-timeout must be a whole number"},
+		{name: "negative initial delay", h: healthCheck{initialDelay: -time.Second}, wantErr: "--health-initial-delay"},
+		{name: "timeout longer than interval", h: healthCheck{interval: 5 * time.Second, timeout: 10 * time.Second}, wantErr: "must not be longer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.h.validate()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("validate() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("validate() error = %v", err)
+			}
+			if tt.h.method != tt.wantMethod {
+				t.Errorf("method = %q, want %q", tt.h.method, tt.wantMethod)
+			}
+		})
+	}
+}
+
+func TestParseOptionalInt(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    int
+		wantErr bool
+	}{
+		{in: "", want: 0},
+		{in: " 8080 ", want: 8080},
+		{in: "0", wantErr: true},
+		{in: "65536", wantErr: true},
+		{in: "http", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseOptionalInt(tt.in, 1, 65535)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseOptionalInt(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if err == nil && got != tt.want {
+			t.Errorf("parseOptionalInt(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+const (
+	// defaultRegistry is the registry of image references that name none
+	defaultRegistry = "docker.io"
+	// defaultImageTag is the tag of image references with no tag or digest
+	defaultImageTag = "latest"
+
+	// deployTypeDockerHub deploys an image from Docker Hub
+	deployTypeDockerHub = "docker_hub"
+	// deployTypeRegistry deploys an image from any other registry
+	deployTypeRegistry = "container_registry"
+
+	// envRegistryPassword holds the password for --registry-username
+	envRegistryPassword = "KAMUI_REGISTRY_PASSWORD"
+)
+
+var (
+	// registryPattern matches a registry host with an optional port
+	registryPattern = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?(?:\.[a-zA-Z0-9](?:[a-zA-Z0-9-]*[a-zA-Z0-9])?)*(?::[0-9]+)?$`)
+	// pathComponentPattern matches one component of a repository path
+	pathComponentPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+	// tagPattern matches an image tag
+	tagPattern = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	// digestPattern matches a content digest such as sha256:<hex>
+	digestPattern = regexp.MustCompile(`^[a-z0-9]+(?:[+._-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+)
+
+// imageRef is a parsed container image reference of the form
+// [registry/]repository[:tag][@digest]
+type imageRef struct {
+	Registry   string // e.g. ghcr.io; defaultRegistry when the reference names none
+	Repository string // path within the registry, e.g. org/app
+	Tag        string // defaultImageTag when neither a tag nor a digest is given
+	Digest     string // e.g. sha256:<hex>, if pinned
+}
+
+// parseImageRef parses and validates an image reference such as nginx,
+// my-org/api:1.4 or ghcr.io/org/app:v1.2.3. Like docker, the first path
+// component is only taken as the registry when it contains a "." or ":",
+// or is "localhost".
+func parseImageRef(ref string) (imageRef, error) {
+	invalid := func(format string, args ...interface{}) (imageRef, error) {
+		return imageRef{}, fmt.Errorf("invalid image reference %q: %s", ref, fmt.Sprintf(format, args...))
+	}
+
+	if ref == "" {
+		return invalid("must not be empty")
+	}
+	if strings.ContainsAny(ref, " \t\n") {
+		return invalid("must not contain whitespace")
+	}
+
+	var img imageRef
+	rest := ref
+	if name, digest, ok := strings.Cut(rest, "@"); ok {
+		if !digestPattern.MatchString(digest) {
+			return invalid("digest %q must look like sha256:<hex>", digest)
+		}
+		img.Digest = digest
+		rest = name
+	}
+
+	// A ":" after the last "/" starts the tag; one before it is a port
+	if i := strings.LastIndex(rest, ":"); i > strings.LastIndex(rest, "/") {
+		img.Tag = rest[i+1:]
+		rest = rest[:i]
+		if !tagPattern.MatchString(img.Tag) {
+			return invalid("tag %q may only contain letters, digits, '_', '.' and '-' (at most 128 characters)", img.Tag)
+		}
+	}
+
+	components := strings.Split(rest, "/")
+	if len(components) > 1 && (strings.ContainsAny(components[0], ".:") || components[0] == "localhost") {
+		img.Registry = components[0]
+		components = components[1:]
+		if !registryPattern.MatchString(img.Registry) {
+			return invalid("registry %q is not a valid host", img.Registry)
+		}
+	}
+	for _, c := range components {
+		if !pathComponentPattern.MatchString(c) {
+			return invalid("repository path %q must be lowercase letters, digits and separators", strings.Join(components, "/"))
+		}
+	}
+	img.Repository = strings.Join(components, "/")
+
+	if img.Registry == "" {
+		img.Registry = defaultRegistry
+	}
+	if img.Tag == "" && img.Digest == "" {
+		img.Tag = defaultImageTag
+	}
+	return img, nil
+}
+
+// withRegistry returns img pulled from registry, as set with --registry.
+// A reference that already names a different registry is an error.
+func (img imageRef) withRegistry(registry, ref string) (imageRef, error) {
+	registry = strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://"), "/")
+	if registry == "" {
+		return img, nil
+	}
+	if !registryPattern.MatchString(registry) {
+		return imageRef{}, fmt.Errorf("invalid registry %q: must be a host such as ghcr.io or registry.example.com:5000", registry)
+	}
+	if img.Registry != defaultRegistry && img.Registry != registry {
+		return imageRef{}, fmt.Errorf("image %q is from %s, but --registry is %s", ref, img.Registry, registry)
+	}
+	img.Registry = registry
+	return img, nil
+}
+
+// deployType returns the deploy type that pulls img
+func (img imageRef) deployType() string {
+	if img.Registry == defaultRegistry {
+		return deployTypeDockerHub
+	}
+	return deployTypeRegistry
+}
+
+// String formats img as a full reference
+func (img imageRef) String() string {
+	s := img.Registry + "/" + img.Repository
+	if img.Tag != "" {
+		s += ":" + img.Tag
+	}
+	if img.Digest != "" {
+		s += "@" + img.Digest
+	}
+	return s
+}
+
+// setImage points input at img, pulled with the given registry credentials
+// when username is set
+func setImage(input *iface.CreateAppInput, img imageRef, username, password string) {
+	input.DeployType = img.deployType()
+	input.Image = img.Repository
+	input.ImageTag = img.Tag
+	input.ImageDigest = img.Digest
+	input.Registry = img.Registry
+	if username != "" {
+		input.RegistryUsername = username
+		input.RegistryPassword = password
+	}
+}
+
+// promptImage asks for the image to deploy and, if the registry requires a
+// login, its credentials
+func promptImage() (imageRef, string, string, error) {
+	var ref string
+	if err := ask(&survey.Input{
+		Message: "Image (e.g. my-org/web:1.4 or ghcr.io/org/app:v1.2.3):",
+	}, &ref, survey.WithValidator(survey.Required), survey.WithValidator(func(ans interface{}) error {
+		_, err := parseImageRef(strings.TrimSpace(ans.(string)))
+		return err
+	})); err != nil {
+		return imageRef{}, "", "", err
+	}
+	img, err := parseImageRef(strings.TrimSpace(ref))
+	if err != nil {
+		return imageRef{}, "", "", err
+	}
+
+	var private bool
+	if err := ask(&survey.Confirm{
+		Message: fmt.Sprintf("Does %s require a login to pull the image?", img.Registry),
+		Default: false,
+	}, &private); err != nil {
+		return imageRef{}, "", "", err
+	}
+	if !private {
+		return img, "", "", nil
+	}
+
+	var username, password string
+	if err := ask(&survey.Input{
+		Message: "Registry username:",
+	}, &username, survey.WithValidator(survey.Required)); err != nil {
+		return imageRef{}, "", "", err
+	}
+	if err := ask(&survey.Password{
+		Message: "Registry password or access token:",
+	}, &password, survey.WithValidator(survey.Required)); err != nil {
+		return imageRef{}, "", "", err
+	}
+	return img, username, password, nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseImageRef(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("ab", 32)
+
+	tests := []struct {
+		ref     string
+		want    imageRef
+		wantErr string
+	}{
+		{
+			ref:  "ghcr.io/org/app:v1.2.3",
+			want: imageRef{Registry: "ghcr.io", Repository: "org/app", Tag: "v1.2.3"},
+		},
+		{
+			ref:  "nginx",
+			want: imageRef{Registry: "docker.io", Repository: "nginx", Tag: "latest"},
+		},
+		{
+			ref:  "my-org/api:1.4",
+			want: imageRef{Registry: "docker.io", Repository: "my-org/api", Tag: "1.4"},
+		},
+		{
+			ref:  "registry.example.com:5000/team/svc/api",
+			want: imageRef{Registry: "registry.example.com:5000", Repository: "team/svc/api", Tag: "latest"},
+		},
+		{
+			ref:  "localhost/app:dev",
+			want: imageRef{Registry: "localhost", Repository: "app", Tag: "dev"},
+		},
+		{
+			ref:  "ghcr.io/org/app@" + digest,
+			want: imageRef{Registry: "ghcr.io", Repository: "org/app", Digest: digest},
+		},
+		{
+			ref:  "ghcr.io/org/app:v1@" + digest,
+			want: imageRef{Registry: "ghcr.io", Repository: "org/app", Tag: "v1", Digest: digest},
+		},
+		{ref: "", wantErr: "must not be empty"},
+		{ref: "ghcr.io/Org/App:v1", wantErr: "repository path"},
+		{ref: "org//app", wantErr: "repository path"},
+		{ref: "ghcr.io/org/app:", wantErr: "tag"},
+		{ref: "ghcr.io/org/app:v1+build", wantErr: "tag"},
+		{ref: "ghcr.io/org/app@sha256:xyz", wantErr: "digest"},
+		{ref: "ghcr.io/org/app v1", wantErr: "whitespace"},
+		{ref: "bad_host.io:x/app", wantErr: "registry"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := parseImageRef(tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseImageRef() error = %v, want containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseImageRef() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseImageRef() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImageRef_WithRegistry(t *testing.T) {
+	tests := []struct {
+		name         string
+		ref          string
+		registry     string
+		wantRegistry string
+		wantType     string
+		wantErr      bool
+	}{
+		{name: "docker hub by default", ref: "my-org/api:1.4", wantRegistry: "docker.io", wantType: deployTypeDockerHub},
+		{name: "registry flag", ref: "org/app:v1", registry: "ghcr.io", wantRegistry: "ghcr.io", wantType: deployTypeRegistry},
+		{name: "registry flag as url", ref: "app", registry: "https://registry.example.com:5000/", wantRegistry: "registry.example.com:5000", wantType: deployTypeRegistry},
+		{name: "same registry in both", ref: "ghcr.io/org/app", registry: "ghcr.io", wantRegistry: "ghcr.io", wantType: deployTypeRegistry},
+		{name: "conflicting registries", ref: "ghcr.io/org/app", registry: "quay.io", wantErr: true},
+		{name: "invalid registry", ref: "app", registry: "not a host", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := parseImageRef(tt.ref)
+			if err != nil {
+				t.Fatal(err)
+			}
+			img, err = img.withRegistry(tt.registry, tt.ref)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("withRegistry() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if img.Registry != tt.wantRegistry || img.deployType() != tt.wantType {
+				t.Errorf("registry = %s, deploy type = %s, want %s, %s", img.Registry, img.deployType(), tt.wantRegistry, tt.wantType)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// jsonLinesWriter writes -o jsonl output: one compact JSON value per line.
+// Each value is encoded in full before it is written, so a failure part way
+// through a listing leaves only whole lines on stdout, while the error
+// itself goes to stderr like any other.
+type jsonLinesWriter struct {
+	w io.Writer
+}
+
+// write prints v as one line
+func (j jsonLinesWriter) write(v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	_, err = j.w.Write(append(data, '\n'))
+	return err
+}
+
+// writeJSONLines prints items as JSON Lines, one item per line
+func writeJSONLines[T any](w io.Writer, items []T) error {
+	out := jsonLinesWriter{w: w}
+	for _, item := range items {
+		if err := out.write(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,8 +1,18 @@
 package cmd
 
 import (
+	"bufio"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/kamui-project/kamui-cli/internal/auth"
+	"github.com/kamui-project/kamui-cli/internal/service"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
 	"github.com/spf13/cobra"
 )
 
@@ -10,6 +20,13 @@ import (
 type LoginCommand struct {
 	root *RootCommand
 	cmd  *cobra.Command
+
+	device       bool
+	callbackPort int
+	noBrowser    bool
+	token        string
+	expiresIn    time.Duration
+	scope        string
 }
 
 // NewLoginCommand creates a new login command
@@ -26,11 +43,39 @@ func NewLoginCommand(root *RootCommand) *LoginCommand {
 This command will open a browser window for you to authenticate with GitHub.
 After successful authentication, your credentials will be stored locally.
 
-Example:
-  kamui login`,
-		RunE: l.Run,
+On machines without a browser (remote servers, containers), use --device
+to get a code you can enter on any other device.
+
+In CI, use --token to store a pre-issued access token instead. Pass
+"--token -" to read it from stdin so it doesn't end up in shell history.
+Token logins have no refresh token and are not refreshed automatically.
+
+Use --scope to request a narrower OAuth scope than the default "full"
+scope. The granted scope is saved and shown by 'kamui config view'.
+
+Examples:
+  kamui login
+  kamui login --device
+  kamui login --no-browser
+  kamui login --callback-port 0
+  kamui login --scope read
+  echo "$KAMUI_TOKEN" | kamui login --token - --expires-in 1h`,
+		// Login waits on the browser or device flow, which has its own expiry
+		Annotations: map[string]string{noDeadlineAnnotation: "true"},
+		RunE:        l.Run,
 	}
 
+	l.cmd.Flags().BoolVar(&l.device, "device", false, "Authenticate with a one-time code instead of a local browser (headless login)")
+	l.cmd.Flags().BoolVar(&l.noBrowser, "no-browser", false, "Print the login URL instead of opening a browser")
+	l.cmd.Flags().IntVar(&l.callbackPort, "callback-port", auth.DefaultCallbackPort, "Port for the local OAuth callback server (0 = pick a free port)")
+	l.cmd.Flags().StringVar(&l.token, "token", "", `Save a pre-issued access token instead of logging in interactively ("-" reads it from stdin)`)
+	l.cmd.Flags().DurationVar(&l.expiresIn, "expires-in", 0, "Lifetime of the --token (e.g. 1h); omit if unknown")
+	l.cmd.Flags().StringVar(&l.scope, "scope", auth.DefaultScope, "Space-separated OAuth scopes to request")
+	l.cmd.MarkFlagsMutuallyExclusive("token", "device")
+	l.cmd.MarkFlagsMutuallyExclusive("token", "no-browser")
+	l.cmd.MarkFlagsMutuallyExclusive("token", "callback-port")
+	l.cmd.MarkFlagsMutuallyExclusive("token", "scope")
+
 	return l
 }
 
@@ -44,11 +89,104 @@ func (l *LoginCommand) Run(cmd *cobra.Command, args []string) error {
 	// Get auth service from DI container
 	authService := l.root.Container().AuthService()
 
+	if cmd.Flags().Changed("token") {
+		return l.runWithToken(cmd, authService)
+	}
+	if cmd.Flags().Changed("expires-in") {
+		return fmt.Errorf("--expires-in can only be used with --token")
+	}
+
+	if err := auth.ValidateScope(l.scope); err != nil {
+		return fmt.Errorf("invalid --scope: %w", err)
+	}
+
+	opts := &iface.LoginOptions{Device: l.device, NoBrowser: l.noBrowser, Scope: strings.Join(strings.Fields(l.scope), " ")}
+	if cmd.Flags().Changed("callback-port") {
+		if l.callbackPort < 0 || l.callbackPort > 65535 {
+			return fmt.Errorf("--callback-port must be between 0 and 65535 (got %d)", l.callbackPort)
+		}
+		opts.CallbackPort = &l.callbackPort
+	}
+
 	// Perform login
-	if err := authService.Login(cmd.Context()); err != nil {
+	if err := authService.Login(cmd.Context(), opts); err != nil {
 		return err
 	}
 
 	fmt.Println("✓ Successfully logged in to Kamui Platform!")
 	return nil
 }
+
+// noReloginAnnotation opts a command out of reloginPrompt, for commands
+// that should report an expired session rather than start a login
+const noReloginAnnotation = "kamui/no-relogin"
+
+// reloginPrompt returns the hook the services call when the session has
+// expired and cannot be refreshed. It is nil unless stdin and stderr are
+// terminals, so scripts keep getting the "session expired" error, and for
+// commands annotated with noReloginAnnotation.
+func (r *RootCommand) reloginPrompt(cmd *cobra.Command) func(ctx context.Context) error {
+	if cmd.Annotations[noReloginAnnotation] != "" || !isStdinTTY() || !isStderrTTY() {
+		return nil
+	}
+	return r.relogin
+}
+
+// relogin asks whether to log in again and, if so, runs the browser login
+// flow so the interrupted operation can continue
+func (r *RootCommand) relogin(ctx context.Context) error {
+	var confirm bool
+	if err := ask(&survey.Confirm{
+		Message: "Your session expired. Log in now?",
+		Default: true,
+	}, &confirm); err != nil {
+		return err
+	}
+	if !confirm {
+		return service.ErrSessionExpired
+	}
+
+	// Login waits on the browser and has its own expiry; don't let the
+	// command deadline cut it short
+	if err := r.Container().AuthService().Login(context.WithoutCancel(ctx), &iface.LoginOptions{}); err != nil {
+		return err
+	}
+	fmt.Fprintln(os.Stderr, "✓ Successfully logged in to Kamui Platform!")
+	return nil
+}
+
+// runWithToken stores a pre-issued token instead of running the OAuth flow
+func (l *LoginCommand) runWithToken(cmd *cobra.Command, authService iface.AuthService) error {
+	token := l.token
+	if token == "-" {
+		var err error
+		token, err = readTokenLine(cmd.InOrStdin())
+		if err != nil {
+			return err
+		}
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return fmt.Errorf("--token must not be empty")
+	}
+	if l.expiresIn < 0 {
+		return fmt.Errorf("--expires-in must not be negative")
+	}
+
+	if err := authService.LoginWithToken(cmd.Context(), token, int(l.expiresIn/time.Second)); err != nil {
+		return err
+	}
+
+	fmt.Println("✓ Successfully logged in to Kamui Platform with an access token!")
+	fmt.Fprintln(os.Stderr, "⚠ Token logins are not refreshed automatically; run 'kamui login --token' again when it expires.")
+	return nil
+}
+
+// readTokenLine reads the first line of r as the token
+func readTokenLine(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read token from stdin: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
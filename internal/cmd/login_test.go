@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestLoginCommand_Token(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		stdin         string
+		wantToken     string
+		wantExpiresIn int
+		wantErrMsg    string
+	}{
+		{
+			name:      "token flag",
+			args:      []string{"--token", "abc"},
+			wantToken: "abc",
+		},
+		{
+			name:          "token from stdin with expiry",
+			args:          []string{"--token", "-", "--expires-in", "1h"},
+			stdin:         "  from-stdin\nignored\n",
+			wantToken:     "from-stdin",
+			wantExpiresIn: 3600,
+		},
+		{
+			name:       "empty stdin",
+			args:       []string{"--token", "-"},
+			stdin:      "\n",
+			wantErrMsg: "must not be empty",
+		},
+		{
+			name:       "expires-in without token",
+			args:       []string{"--expires-in", "1h"},
+			wantErrMsg: "--expires-in can only be used with --token",
+		},
+		{
+			name:       "token with device",
+			args:       []string{"--token", "abc", "--device"},
+			wantErrMsg: "if any flags in the group [token device] are set none of the others can be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotToken string
+			var gotExpiresIn int
+			mockAuth := &MockAuthService{
+				LoginWithTokenFunc: func(ctx context.Context, token string, expiresIn int) error {
+					gotToken = token
+					gotExpiresIn = expiresIn
+					return nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(mockAuth, &MockProjectService{}, &MockAppService{}))
+			root.Command().SetIn(strings.NewReader(tt.stdin))
+			root.Command().SetArgs(append([]string{"login"}, tt.args...))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			err := root.Command().Execute()
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotToken != tt.wantToken {
+				t.Errorf("token = %q, want %q", gotToken, tt.wantToken)
+			}
+			if gotExpiresIn != tt.wantExpiresIn {
+				t.Errorf("expiresIn = %d, want %d", gotExpiresIn, tt.wantExpiresIn)
+			}
+		})
+	}
+}
+
+func TestLoginCommand_Scope(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		wantScope  string
+		wantErrMsg string
+	}{
+		{name: "default", args: nil, wantScope: "full"},
+		{name: "override", args: []string{"--scope", "read"}, wantScope: "read"},
+		{name: "multiple scopes", args: []string{"--scope", " projects:read  apps:read "}, wantScope: "projects:read apps:read"},
+		{name: "empty", args: []string{"--scope", ""}, wantErrMsg: "invalid --scope"},
+		{name: "with token", args: []string{"--scope", "read", "--token", "abc"}, wantErrMsg: "[token scope]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotOpts *iface.LoginOptions
+			mockAuth := &MockAuthService{
+				LoginFunc: func(ctx context.Context, opts *iface.LoginOptions) error {
+					gotOpts = opts
+					return nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAllServices(mockAuth, &MockProjectService{}, &MockAppService{}))
+			root.Command().SetArgs(append([]string{"login"}, tt.args...))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			err := root.Command().Execute()
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if gotOpts == nil || gotOpts.Scope != tt.wantScope {
+				t.Errorf("LoginOptions = %+v, want Scope %q", gotOpts, tt.wantScope)
+			}
+		})
+	}
+}
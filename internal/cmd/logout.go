@@ -2,7 +2,10 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/AlecAivazis/survey/v2"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
 	"github.com/spf13/cobra"
 )
 
@@ -10,6 +13,10 @@ import (
 type LogoutCommand struct {
 	root *RootCommand
 	cmd  *cobra.Command
+
+	localOnly bool
+	all       bool
+	purge     bool
 }
 
 // NewLogoutCommand creates a new logout command
@@ -23,13 +30,29 @@ func NewLogoutCommand(root *RootCommand) *LogoutCommand {
 		Short: "Log out from Kamui Platform",
 		Long: `Log out from the Kamui Platform and clear stored credentials.
 
-This command removes your authentication tokens from local storage.
+This command revokes your tokens on the server and removes them from local
+storage. If the server can't be reached, local credentials are still cleared.
+
+--all clears the tokens of every stored profile and succeeds even if you are
+already logged out. The CLI currently stores a single profile.
+
+--purge additionally removes the OAuth client credentials and settings that
+logout normally keeps for the next login, by deleting the config file. It
+asks for confirmation unless --yes is given.
 
-Example:
-  kamui logout`,
+Examples:
+  kamui logout
+  kamui logout --local-only
+  kamui logout --all
+  kamui logout --purge --yes`,
 		RunE: l.Run,
 	}
 
+	l.cmd.Flags().BoolVar(&l.localOnly, "local-only", false, "Only clear local credentials; skip server-side token revocation")
+	l.cmd.Flags().BoolVar(&l.all, "all", false, "Clear the tokens of every profile, even if already logged out")
+	l.cmd.Flags().BoolVar(&l.purge, "purge", false, "Also remove client credentials and delete the config file")
+	l.cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt for --purge")
+
 	return l
 }
 
@@ -43,11 +66,51 @@ func (l *LogoutCommand) Run(cmd *cobra.Command, args []string) error {
 	// Get auth service from DI container
 	authService := l.root.Container().AuthService()
 
+	if l.purge {
+		return l.runPurge(cmd, authService)
+	}
+
+	if l.all && !authService.IsLoggedIn() {
+		fmt.Println("✓ Already logged out; no profile has stored tokens.")
+		return nil
+	}
+
 	// Perform logout
-	if err := authService.Logout(cmd.Context()); err != nil {
+	if err := authService.Logout(cmd.Context(), &iface.LogoutOptions{LocalOnly: l.localOnly}); err != nil {
 		return err
 	}
 
 	fmt.Println("✓ Successfully logged out from Kamui Platform!")
 	return nil
 }
+
+// runPurge logs out and deletes the config file after confirmation
+func (l *LogoutCommand) runPurge(cmd *cobra.Command, authService iface.AuthService) error {
+	configPath := l.root.Container().ConfigManager().ConfigPath()
+
+	skipConfirm, _ := cmd.Flags().GetBool("yes")
+	if !skipConfirm {
+		fmt.Fprintf(os.Stderr, "\n⚠️  This deletes %s, including your tokens,\n", configPath)
+		fmt.Fprintln(os.Stderr, "   OAuth client credentials, and saved settings.")
+		fmt.Fprintln(os.Stderr)
+
+		var confirm bool
+		if err := ask(&survey.Confirm{
+			Message: "Log out and delete the Kamui config file?",
+			Default: false,
+		}, &confirm); err != nil {
+			return err
+		}
+		if !confirm {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	if err := authService.Logout(cmd.Context(), &iface.LogoutOptions{LocalOnly: l.localOnly, Purge: true}); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Logged out and removed %s\n", configPath)
+	return nil
+}
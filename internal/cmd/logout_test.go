@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	"github.com/kamui-project/kamui-cli/internal/service"
+)
+
+func TestLogoutCommand_Run(t *testing.T) {
+	loggedIn := &config.Config{
+		AccessToken:  "access",
+		RefreshToken: "refresh",
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+	}
+	loggedOut := &config.Config{ClientID: "client-id", ClientSecret: "client-secret"}
+
+	tests := []struct {
+		name         string
+		config       *config.Config
+		args         []string
+		wantErrMsg   string
+		wantDeleted  bool
+		wantClientID string
+		wantOutput   string
+	}{
+		{
+			name:         "clears tokens and keeps client credentials",
+			config:       loggedIn,
+			wantClientID: "client-id",
+			wantOutput:   "Successfully logged out",
+		},
+		{
+			name:       "fails when already logged out",
+			config:     loggedOut,
+			wantErrMsg: "not logged in",
+		},
+		{
+			name:         "all clears tokens",
+			config:       loggedIn,
+			args:         []string{"--all"},
+			wantClientID: "client-id",
+			wantOutput:   "Successfully logged out",
+		},
+		{
+			name:         "all succeeds when already logged out",
+			config:       loggedOut,
+			args:         []string{"--all"},
+			wantClientID: "client-id",
+			wantOutput:   "Already logged out",
+		},
+		{
+			name:        "purge deletes the config file",
+			config:      loggedIn,
+			args:        []string{"--purge", "--yes"},
+			wantDeleted: true,
+			wantOutput:  "Logged out and removed",
+		},
+		{
+			name:        "purge removes lingering client credentials",
+			config:      loggedOut,
+			args:        []string{"--purge", "-y"},
+			wantDeleted: true,
+		},
+		{
+			name:        "all and purge",
+			config:      loggedIn,
+			args:        []string{"--all", "--purge", "--yes"},
+			wantDeleted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.json")
+			m := config.NewManagerWithPath(path)
+			if err := m.Save(tt.config); err != nil {
+				t.Fatal(err)
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithAuthService(m, service.NewAuthService(m, service.HTTPOptions{})))
+			root.Command().SilenceErrors = true
+			root.Command().SilenceUsage = true
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			// --local-only keeps the test off the network
+			root.Command().SetArgs(append([]string{"logout", "--local-only"}, tt.args...))
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var out bytes.Buffer
+			io.Copy(&out, r)
+
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("Execute() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !strings.Contains(out.String(), tt.wantOutput) {
+				t.Errorf("output = %q, want containing %q", out.String(), tt.wantOutput)
+			}
+
+			_, statErr := os.Stat(path)
+			if tt.wantDeleted {
+				if !os.IsNotExist(statErr) {
+					t.Errorf("config file still exists (stat error %v)", statErr)
+				}
+				return
+			}
+			cfg, err := m.Load()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.AccessToken != "" || cfg.RefreshToken != "" {
+				t.Errorf("tokens not cleared: %+v", cfg)
+			}
+			if cfg.ClientID != tt.wantClientID {
+				t.Errorf("ClientID = %q, want %q", cfg.ClientID, tt.wantClientID)
+			}
+		})
+	}
+}
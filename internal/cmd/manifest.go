@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"gopkg.in/yaml.v3"
+)
+
+// manifest is the document read by `kamui apply`: one project and the apps
+// it should contain. Each app uses the keys of an `apps create --file` spec,
+// without "project".
+//
+//	project:
+//	  name: my-project        # required, matched by name
+//	  description: Demo       # optional, updated in place
+//	  plan_type: free         # a plan the server offers, default free; only used on create
+//	  region: tokyo           # a region the server offers, default tokyo; only used on create
+//	apps:
+//	  - name: web
+//	    language: go
+//	    deploy_type: docker_hub
+//	    start_command: ./server
+type manifest struct {
+	Project manifestProject `yaml:"project"`
+	Apps    []appSpec       `yaml:"apps"`
+}
+
+// manifestProject is the project section of a manifest
+type manifestProject struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	PlanType    string `yaml:"plan_type"`
+	Region      string `yaml:"region"`
+
+	// lines maps each key to the line it appears on, for errors
+	lines map[string]int
+}
+
+// parseManifest decodes a YAML or JSON manifest, rejecting unknown fields,
+// and validates the project and every app. The project's plan and region
+// depend on the server and are checked by validateOffered.
+func parseManifest(data []byte) (*manifest, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, errors.New("document is empty")
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected a mapping of fields", root.Line)
+	}
+
+	var m manifest
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&m); err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	rootLines := keyLines(root)
+	projectLines := map[string]int{}
+	var appNodes []*yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch root.Content[i].Value {
+		case "project":
+			projectLines = keyLines(root.Content[i+1])
+		case "apps":
+			appNodes = root.Content[i+1].Content
+		}
+	}
+
+	p := &m.Project
+	if strings.TrimSpace(p.Name) == "" {
+		errs = append(errs, lineError(rootLines["project"], `field "project.name": is required`))
+	}
+	if len(p.Description) > maxProjectDescriptionLen {
+		errs = append(errs, lineError(projectLines["description"], fmt.Sprintf(`field "project.description": must be at most %d characters`, maxProjectDescriptionLen)))
+	}
+	p.lines = projectLines
+
+	seen := make(map[string]bool, len(m.Apps))
+	for i := range m.Apps {
+		app := &m.Apps[i]
+		if i < len(appNodes) {
+			app.lines = keyLines(appNodes[i])
+		}
+		label := fmt.Sprintf("apps[%d]", i)
+		if app.Name != "" {
+			label = fmt.Sprintf("apps[%d] (%s)", i, app.Name)
+		}
+
+		if app.Project != "" {
+			errs = append(errs, fmt.Errorf("%s: %w", label, app.fieldError("project", "is not allowed in a manifest; apps belong to the manifest's project")))
+		}
+		app.Project = p.Name
+		if err := app.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", label, err))
+		}
+		if app.Name != "" {
+			if seen[app.Name] {
+				errs = append(errs, fmt.Errorf("%s: %w", label, app.fieldError("name", "duplicate app name")))
+			}
+			seen[app.Name] = true
+		}
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// validateOffered checks the project's plan_type and region against the
+// plans and regions the server offers, like projects create does, and
+// replaces them with the offered IDs' spelling
+func (p *manifestProject) validateOffered(plans []iface.Plan, regions []iface.Region) error {
+	planIDs := make([]string, len(plans))
+	for i, plan := range plans {
+		planIDs[i] = plan.ID
+	}
+	regionIDs := make([]string, len(regions))
+	for i, region := range regions {
+		regionIDs[i] = region.ID
+	}
+
+	var errs []error
+	if p.PlanType != "" {
+		planType, err := pickOption(`field "project.plan_type":`, p.PlanType, planIDs)
+		if err != nil {
+			errs = append(errs, lineError(p.lines["plan_type"], fmt.Sprintf("%s (got %q)", err, p.PlanType)))
+		}
+		p.PlanType = planType
+	}
+	if p.Region != "" {
+		region, err := pickOption(`field "project.region":`, p.Region, regionIDs)
+		if err != nil {
+			errs = append(errs, lineError(p.lines["region"], fmt.Sprintf("%s (got %q)", err, p.Region)))
+		}
+		p.Region = region
+	}
+	return errors.Join(errs...)
+}
+
+// lineError prefixes msg with its line when line is known
+func lineError(line int, msg string) error {
+	if line > 0 {
+		return fmt.Errorf("line %d: %s", line, msg)
+	}
+	return errors.New(msg)
+}
@@ -444,13 +444,14 @@ func defaultPATName() string {
 }
 
 // resolveOutputFormat reads the persistent --output flag from the root command.
-// Returns "" when the flag isn't reachable (e.g. tests with detached commands).
+// Returns "" for the default text format ("text" or "table") and when the
+// flag isn't reachable (e.g. tests with detached commands).
 func resolveOutputFormat(cmd *cobra.Command) string {
-	if v, _ := cmd.Flags().GetString("output"); v != "" && v != "text" {
+	if v, _ := cmd.Flags().GetString("output"); v != "" && v != "text" && v != "table" {
 		return v
 	}
 	for p := cmd.Parent(); p != nil; p = p.Parent() {
-		if v, _ := p.PersistentFlags().GetString("output"); v != "" && v != "text" {
+		if v, _ := p.PersistentFlags().GetString("output"); v != "" && v != "text" && v != "table" {
 			return v
 		}
 	}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+// OrgsCommand groups the `orgs` subcommands
+type OrgsCommand struct {
+	root *RootCommand
+	cmd  *cobra.Command
+
+	listCmd *OrgsListCommand
+}
+
+// NewOrgsCommand creates the orgs command group
+func NewOrgsCommand(root *RootCommand) *OrgsCommand {
+	o := &OrgsCommand{root: root}
+
+	o.cmd = &cobra.Command{
+		Use:     "orgs",
+		Aliases: []string{"org"},
+		Short:   "Manage organizations",
+		Long: `Manage the organizations you belong to.
+
+Projects and apps belong to an organization. Commands act in your default
+organization unless another one is selected, either for a single command with
+--org or persistently with 'kamui config set org <id>'.
+
+Examples:
+  kamui orgs list
+  kamui config set org <org-id>
+  kamui projects list --org <org-id>`,
+	}
+
+	o.listCmd = NewOrgsListCommand(o)
+	o.cmd.AddCommand(o.listCmd.Command())
+
+	return o
+}
+
+// Command returns the underlying cobra command
+func (o *OrgsCommand) Command() *cobra.Command { return o.cmd }
+
+// Root returns the root command
+func (o *OrgsCommand) Root() *RootCommand { return o.root }
+
+// OrgsListCommand represents the orgs list command
+type OrgsListCommand struct {
+	parent *OrgsCommand
+	cmd    *cobra.Command
+}
+
+// NewOrgsListCommand creates a new orgs list command
+func NewOrgsListCommand(parent *OrgsCommand) *OrgsListCommand {
+	l := &OrgsListCommand{parent: parent}
+	l.cmd = &cobra.Command{
+		Use:   "list",
+		Short: "List your organizations",
+		Long: `List the organizations you belong to. The active organization is
+marked with *.
+
+Examples:
+  kamui orgs list
+  kamui orgs list -o json`,
+		Args: cobra.NoArgs,
+		RunE: l.Run,
+	}
+	return l
+}
+
+// Command returns the underlying cobra command
+func (l *OrgsListCommand) Command() *cobra.Command { return l.cmd }
+
+// Run executes the orgs list command
+func (l *OrgsListCommand) Run(cmd *cobra.Command, _ []string) error {
+	container := l.parent.Root().Container()
+
+	orgs, err := container.OrgService().ListOrgs(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to list organizations: %w", err)
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(orgs)
+	}
+
+	if len(orgs) == 0 {
+		fmt.Println("No organizations found.")
+		return nil
+	}
+
+	active := ""
+	if m := container.ConfigManager(); m != nil {
+		active, _ = m.GetOrg()
+	}
+
+	rows := make([][]string, len(orgs))
+	for i, o := range orgs {
+		rows[i] = []string{activeOrgMarker(o, active), o.ID, o.Name, o.Role}
+	}
+	printTable(os.Stdout, "", []string{"", "ID", "NAME", "ROLE"}, rows)
+	return nil
+}
+
+// activeOrgMarker returns "*" for the org commands act in: the selected
+// one, or the user's default when none is selected
+func activeOrgMarker(o iface.Org, active string) string {
+	if o.ID == active || (active == "" && o.Default) {
+		return "*"
+	}
+	return ""
+}
@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// MockOrgService is a mock implementation of OrgService
+type MockOrgService struct {
+	ListOrgsFunc func(ctx context.Context) ([]iface.Org, error)
+}
+
+func (m *MockOrgService) ListOrgs(ctx context.Context) ([]iface.Org, error) {
+	if m.ListOrgsFunc != nil {
+		return m.ListOrgsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func TestOrgsListCommand(t *testing.T) {
+	orgs := []iface.Org{
+		{ID: "org-1", Name: "Personal", Role: "owner", Default: true},
+		{ID: "org-2", Name: "Acme", Role: "member"},
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		configured string
+		wantActive string
+		wantOutput []string
+	}{
+		{
+			name:       "default org is active",
+			args:       []string{"orgs", "list"},
+			wantActive: "org-1",
+			wantOutput: []string{"ID", "NAME", "ROLE", "Personal", "Acme", "member"},
+		},
+		{
+			name:       "configured org is active",
+			args:       []string{"orgs", "list"},
+			configured: "org-2",
+			wantActive: "org-2",
+		},
+		{
+			name:       "--org overrides the configured org",
+			args:       []string{"orgs", "list", "--org", "org-1"},
+			configured: "org-2",
+			wantActive: "org-1",
+		},
+		{
+			name:       "json output",
+			args:       []string{"orgs", "list", "-o", "json"},
+			wantOutput: []string{`"id": "org-2"`, `"role": "member"`, `"default": true`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configManager := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := configManager.Save(&config.Config{Org: tt.configured}); err != nil {
+				t.Fatal(err)
+			}
+			if org := flagValue(tt.args, "--org"); org != "" {
+				// NewContainer applies --org; the test container is built by hand
+				configManager.SetOrgOverride(org)
+			}
+			mockOrg := &MockOrgService{
+				ListOrgsFunc: func(ctx context.Context) ([]iface.Org, error) {
+					return orgs, nil
+				},
+			}
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithConfigManager(configManager).WithOrgService(mockOrg))
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			root.Command().SetArgs(tt.args)
+			err := root.Command().Execute()
+
+			w.Close()
+			os.Stdout = oldStdout
+			var buf bytes.Buffer
+			io.Copy(&buf, r)
+
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			output := buf.String()
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(output, want) {
+					t.Errorf("output missing %q:\n%s", want, output)
+				}
+			}
+			if tt.wantActive != "" {
+				for _, line := range strings.Split(strings.TrimSpace(output), "\n")[1:] {
+					fields := strings.Fields(line)
+					active := fields[0] == "*"
+					id := fields[0]
+					if active {
+						id = fields[1]
+					}
+					if active != (id == tt.wantActive) {
+						t.Errorf("row %q: active = %v, want active org %s", line, active, tt.wantActive)
+					}
+				}
+			}
+		})
+	}
+}
+
+// flagValue returns the value following flag in args, or ""
+func flagValue(args []string, flag string) string {
+	for i, a := range args {
+		if a == flag && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// checkPlanLimits validates the replicas and app spec of a new app against
+// the limits of the project's plan, so a request the API would reject fails
+// before it is sent. When the limits cannot be fetched the check is skipped
+// and the API has the final say.
+func checkPlanLimits(ctx context.Context, projectService iface.ProjectService, project iface.Project, replicas int, appSpecType string) error {
+	usage, err := projectService.GetUsage(ctx, project.ID)
+	if err != nil || usage == nil || usage.Limits == nil {
+		return nil
+	}
+	limits := usage.Limits
+
+	plan := usage.PlanType
+	if plan == "" {
+		plan = project.PlanType
+	}
+	if plan == "" {
+		plan = "this project's"
+	}
+
+	if limits.MaxReplicas > 0 && replicas > limits.MaxReplicas {
+		noun := "replicas"
+		if limits.MaxReplicas == 1 {
+			noun = "replica"
+		}
+		return fmt.Errorf("%s plan allows max %d %s (requested %d)", plan, limits.MaxReplicas, noun, replicas)
+	}
+
+	if appSpecType == "" {
+		appSpecType = "nano"
+	}
+	if len(limits.AppSpecTypes) > 0 && !slices.Contains(limits.AppSpecTypes, appSpecType) {
+		return fmt.Errorf("%s plan does not offer the %s app spec; choose from: %s", plan, appSpecType, strings.Join(limits.AppSpecTypes, ", "))
+	}
+
+	return nil
+}
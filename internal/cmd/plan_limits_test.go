@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestCheckPlanLimits(t *testing.T) {
+	freeLimits := &iface.ProjectUsage{
+		PlanType: "free",
+		Limits:   &iface.PlanLimits{MaxReplicas: 1, AppSpecTypes: []string{"nano"}},
+	}
+
+	tests := []struct {
+		name       string
+		usage      *iface.ProjectUsage
+		usageErr   error
+		replicas   int
+		spec       string
+		wantErrMsg string
+	}{
+		{name: "within limits", usage: freeLimits, replicas: 1, spec: "nano"},
+		{name: "empty spec means nano", usage: freeLimits, replicas: 1},
+		{name: "too many replicas", usage: freeLimits, replicas: 3, spec: "nano", wantErrMsg: "free plan allows max 1 replica (requested 3)"},
+		{name: "spec not offered", usage: freeLimits, replicas: 1, spec: "large", wantErrMsg: "free plan does not offer the large app spec; choose from: nano"},
+		{name: "no limits reported", usage: &iface.ProjectUsage{PlanType: "pro"}, replicas: 10, spec: "large"},
+		{name: "zero limits mean unlimited", usage: &iface.ProjectUsage{Limits: &iface.PlanLimits{}}, replicas: 10, spec: "large"},
+		{name: "usage unavailable", usageErr: errors.New("not found"), replicas: 10, spec: "large"},
+		{
+			name:       "falls back to the project plan name",
+			usage:      &iface.ProjectUsage{Limits: &iface.PlanLimits{MaxReplicas: 2}},
+			replicas:   3,
+			wantErrMsg: "hobby plan allows max 2 replicas",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projectService := &MockProjectService{
+				GetUsageFunc: func(ctx context.Context, id string) (*iface.ProjectUsage, error) {
+					return tt.usage, tt.usageErr
+				},
+			}
+			project := iface.Project{ID: "proj-1", Name: "my-project", PlanType: "hobby"}
+
+			err := checkPlanLimits(context.Background(), projectService, project, tt.replicas, tt.spec)
+			if tt.wantErrMsg == "" {
+				if err != nil {
+					t.Errorf("checkPlanLimits() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+				t.Errorf("checkPlanLimits() error = %v, want containing %q", err, tt.wantErrMsg)
+			}
+		})
+	}
+}
+
+func TestAppsCreateCommand_PlanLimits(t *testing.T) {
+	created := false
+	mockProject := &MockProjectService{
+		ListProjectsFunc: func(ctx context.Context) ([]iface.Project, error) {
+			return []iface.Project{{ID: "proj-1", Name: "my-project", PlanType: "free"}}, nil
+		},
+		GetUsageFunc: func(ctx context.Context, id string) (*iface.ProjectUsage, error) {
+			return &iface.ProjectUsage{PlanType: "free", Limits: &iface.PlanLimits{MaxReplicas: 1}}, nil
+		},
+	}
+	mockApp := &MockAppService{
+		CreateAppFunc: func(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+			created = true
+			return &iface.CreateAppOutput{ID: "app-1", Name: input.AppName}, nil
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, mockApp))
+	root.Command().SilenceErrors = true
+	root.Command().SilenceUsage = true
+
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stdout, os.Stderr = w, w
+
+	root.Command().SetArgs([]string{"apps", "create", "-p", "my-project", "--name", "web", "--language", "go",
+		"--deploy-type", "docker_hub", "--start-command", "./server", "--replicas", "2"})
+	err := root.Command().Execute()
+
+	w.Close()
+	os.Stdout, os.Stderr = oldStdout, oldStderr
+	io.Copy(io.Discard, r)
+
+	if err == nil || !strings.Contains(err.Error(), "free plan allows max 1 replica") {
+		t.Fatalf("Execute() error = %v, want the plan limit error", err)
+	}
+	if created {
+		t.Error("CreateApp was called despite exceeding the plan limit")
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// defaultWaitTimeout bounds apps create --wait, apps deploy-static and
+// apps watch --until unless --wait-timeout is given
+const defaultWaitTimeout = 5 * time.Minute
+
+// errWaitTimedOut is returned when a wait on a deployment or app status runs
+// past its --wait-timeout
+var errWaitTimedOut = errors.New("wait timed out")
+
+// pollBackoff spaces out status polls: the first wait is initial, and each
+// one after that is half again as long, up to max
+type pollBackoff struct {
+	initial time.Duration
+	max     time.Duration
+}
+
+// statusPollBackoff is how apps create --wait, apps deploy-static and
+// apps watch space out their polls; tests shorten it
+var statusPollBackoff = pollBackoff{initial: 2 * time.Second, max: 15 * time.Second}
+
+// fixedBackoff polls every interval
+func fixedBackoff(interval time.Duration) pollBackoff {
+	return pollBackoff{initial: interval, max: interval}
+}
+
+// next returns the wait that follows one of prev; a zero prev gives the
+// first wait
+func (b pollBackoff) next(prev time.Duration) time.Duration {
+	if prev <= 0 {
+		return b.initial
+	}
+	return min(prev+prev/2, b.max)
+}
+
+// poll calls check until it reports done or fails, waiting between calls as
+// backoff says. If ctx ends first, poll returns ctx.Err(), even when that
+// is what made check fail.
+func poll(ctx context.Context, backoff pollBackoff, check func(ctx context.Context) (bool, error)) error {
+	var wait time.Duration
+	for {
+		done, err := check(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		wait = backoff.next(wait)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
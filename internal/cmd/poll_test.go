@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPollBackoff_Next(t *testing.T) {
+	want := []time.Duration{
+		2 * time.Second,
+		3 * time.Second,
+		4500 * time.Millisecond,
+		6750 * time.Millisecond,
+		10125 * time.Millisecond,
+		15 * time.Second,
+		15 * time.Second,
+	}
+
+	var wait time.Duration
+	for i, w := range want {
+		wait = statusPollBackoff.next(wait)
+		if wait != w {
+			t.Errorf("wait %d = %s, want %s", i+1, wait, w)
+		}
+	}
+}
+
+func TestPoll(t *testing.T) {
+	errCheck := errors.New("boom")
+
+	tests := []struct {
+		name      string
+		doneAt    int
+		failAt    int
+		timeout   time.Duration
+		wantErr   error
+		wantCalls int
+	}{
+		{name: "done", doneAt: 3, timeout: time.Minute, wantCalls: 3},
+		{name: "check fails", failAt: 2, timeout: time.Minute, wantErr: errCheck, wantCalls: 2},
+		{name: "context ends", timeout: 20 * time.Millisecond, wantErr: context.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), tt.timeout)
+			defer cancel()
+
+			calls := 0
+			err := poll(ctx, fixedBackoff(time.Millisecond), func(ctx context.Context) (bool, error) {
+				calls++
+				if calls == tt.failAt {
+					return false, errCheck
+				}
+				return calls == tt.doneAt, nil
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("poll() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantCalls != 0 && calls != tt.wantCalls {
+				t.Errorf("check called %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	// progressRedrawInterval throttles in-place redraws on a terminal
+	progressRedrawInterval = 100 * time.Millisecond
+
+	// progressLogInterval is how often byte counts are printed when the
+	// output is not a terminal (CI logs, redirected stderr)
+	progressLogInterval = 5 * time.Second
+)
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// uploadProgress renders upload progress to w. On a terminal it redraws a
+// single spinner/percentage line; otherwise it prints a byte count line
+// periodically so logs stay readable.
+type uploadProgress struct {
+	w     io.Writer
+	tty   bool
+	now   func() time.Time
+	last  time.Time
+	frame int
+	done  bool
+}
+
+// newUploadProgress creates a progress renderer writing to w
+func newUploadProgress(w io.Writer, tty bool) *uploadProgress {
+	return &uploadProgress{w: w, tty: tty, now: time.Now}
+}
+
+// Update is an api.ProgressFunc-compatible callback
+func (p *uploadProgress) Update(sent, total int64) {
+	if p.done {
+		return
+	}
+	finished := total > 0 && sent >= total
+
+	now := p.now()
+	interval := progressLogInterval
+	if p.tty {
+		interval = progressRedrawInterval
+	}
+	if !finished && !p.last.IsZero() && now.Sub(p.last) < interval {
+		return
+	}
+	p.last = now
+
+	pct := 0
+	if total > 0 {
+		pct = int(sent * 100 / total)
+	}
+
+	if p.tty {
+		fmt.Fprintf(p.w, "\r%s Uploading... %3d%% (%s / %s)", spinnerFrames[p.frame%len(spinnerFrames)], pct, formatBytes(sent), formatBytes(total))
+		p.frame++
+		if finished {
+			fmt.Fprintln(p.w)
+		}
+	} else {
+		fmt.Fprintf(p.w, "Uploaded %s / %s (%d%%)\n", formatBytes(sent), formatBytes(total), pct)
+	}
+
+	if finished {
+		p.done = true
+	}
+}
+
+// formatBytes renders a byte count with a binary unit suffix
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
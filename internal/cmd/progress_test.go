@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUploadProgress_NonTTYPrintsPeriodicByteCounts(t *testing.T) {
+	var buf bytes.Buffer
+	p := newUploadProgress(&buf, false)
+
+	clock := time.Unix(0, 0)
+	p.now = func() time.Time { return clock }
+
+	p.Update(100, 1000) // first update always prints
+	clock = clock.Add(time.Second)
+	p.Update(200, 1000) // throttled
+	clock = clock.Add(progressLogInterval)
+	p.Update(500, 1000)
+	p.Update(1000, 1000) // completion always prints
+	p.Update(1000, 1000) // ignored after completion
+
+	want := []string{
+		"Uploaded 100 B / 1000 B (10%)",
+		"Uploaded 500 B / 1000 B (50%)",
+		"Uploaded 1000 B / 1000 B (100%)",
+	}
+	got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(got) != len(want) {
+		t.Fatalf("got %d lines %q, want %d", len(got), got, len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("line %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestUploadProgress_TTYRedrawsInPlace(t *testing.T) {
+	var buf bytes.Buffer
+	p := newUploadProgress(&buf, true)
+
+	p.Update(512, 2048)
+	p.Update(2048, 2048)
+
+	out := buf.String()
+	if strings.Count(out, "\r") != 2 {
+		t.Errorf("expected 2 carriage returns, got %q", out)
+	}
+	if !strings.Contains(out, " 25% (512 B / 2.0 KiB)") || !strings.HasSuffix(out, "100% (2.0 KiB / 2.0 KiB)\n") {
+		t.Errorf("unexpected output %q", out)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in   int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+	}
+	for _, tt := range tests {
+		if got := formatBytes(tt.in); got != tt.want {
+			t.Errorf("formatBytes(%d) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
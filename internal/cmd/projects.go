@@ -3,24 +3,37 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
+	"github.com/kamui-project/kamui-cli/internal/api"
 	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
 	"github.com/spf13/cobra"
 )
 
+// maxProjectDescriptionLen is the longest description the API accepts
+const maxProjectDescriptionLen = 80
+
 // ProjectsCommand represents the projects command group
 type ProjectsCommand struct {
 	root *RootCommand
 	cmd  *cobra.Command
 
 	// Subcommands
-	listCmd   *ProjectsListCommand
-	getCmd    *ProjectsGetCommand
-	createCmd *ProjectsCreateCommand
-	deleteCmd *ProjectsDeleteCommand
+	listCmd     *ProjectsListCommand
+	getCmd      *ProjectsGetCommand
+	describeCmd *ProjectsDescribeCommand
+	createCmd   *ProjectsCreateCommand
+	updateCmd   *ProjectsUpdateCommand
+	deleteCmd   *ProjectsDeleteCommand
 }
 
 // NewProjectsCommand creates a new projects command
@@ -41,13 +54,17 @@ Use subcommands to list, create, or manage your projects.`,
 	// Initialize subcommands
 	p.listCmd = NewProjectsListCommand(p)
 	p.getCmd = NewProjectsGetCommand(p)
+	p.describeCmd = NewProjectsDescribeCommand(p)
 	p.createCmd = NewProjectsCreateCommand(p)
+	p.updateCmd = NewProjectsUpdateCommand(p)
 	p.deleteCmd = NewProjectsDeleteCommand(p)
 
 	// Add subcommands
 	p.cmd.AddCommand(p.listCmd.Command())
 	p.cmd.AddCommand(p.getCmd.Command())
+	p.cmd.AddCommand(p.describeCmd.Command())
 	p.cmd.AddCommand(p.createCmd.Command())
+	p.cmd.AddCommand(p.updateCmd.Command())
 	p.cmd.AddCommand(p.deleteCmd.Command())
 
 	return p
@@ -67,8 +84,21 @@ func (p *ProjectsCommand) Root() *RootCommand {
 type ProjectsListCommand struct {
 	parent *ProjectsCommand
 	cmd    *cobra.Command
+
+	limit   int
+	all     bool
+	regions string
+	plans   string
+	sortBy  string
+	reverse bool
+	fields  string
+	mine    bool
+	shared  bool
 }
 
+// defaultProjectsListLimit caps `projects list` unless --limit or --all is given
+const defaultProjectsListLimit = 100
+
 // NewProjectsListCommand creates a new projects list command
 func NewProjectsListCommand(parent *ProjectsCommand) *ProjectsListCommand {
 	l := &ProjectsListCommand{
@@ -81,13 +111,43 @@ func NewProjectsListCommand(parent *ProjectsCommand) *ProjectsListCommand {
 		Long: `List all projects associated with your Kamui account.
 
 This command displays a table of your projects with their IDs, names, plans, and regions.
+At most 100 projects are shown by default; use --limit to change the cap or
+--all to fetch every page. --region and --plan filter the list; they take
+comma-separated values and match case-insensitively. --mine keeps the
+projects you own and --shared the ones shared with you; both need a server
+that reports your role in each project. Projects are sorted by
+name unless --sort selects created, updated, or plan; --reverse flips the order.
+-o jsonl prints one project per line as each page arrives, unsorted, so
+large accounts can be processed as a stream.
+--fields picks and orders the table columns: ` + projectFieldNames() + `.
+The ROLE column is shown by default when the server reports roles.
+-o csv and -o tsv print the same columns as delimited text with a header row.
 
 Examples:
   kamui projects list
-  kamui projects list -o json`,
+  kamui projects list --limit 10
+  kamui projects list --all -o json
+  kamui projects list --region tokyo,singapore --plan pro
+  kamui projects list --shared
+  kamui projects list --sort created --reverse
+  kamui projects list --fields id,name,region
+  kamui projects list --all -o csv > projects.csv
+  kamui projects list --all -o jsonl | jq -r .id`,
 		RunE: l.Run,
 	}
 
+	l.cmd.Flags().IntVar(&l.limit, "limit", defaultProjectsListLimit, "Maximum number of projects to list")
+	l.cmd.Flags().BoolVar(&l.all, "all", false, "List all projects, fetching every page")
+	l.cmd.Flags().StringVar(&l.regions, "region", "", "Only list projects in these regions (comma-separated)")
+	l.cmd.Flags().StringVar(&l.plans, "plan", "", "Only list projects on these plans (comma-separated)")
+	l.cmd.Flags().StringVar(&l.sortBy, "sort", "name", "Sort by: name, created, updated, or plan")
+	l.cmd.Flags().BoolVar(&l.reverse, "reverse", false, "Reverse the sort order")
+	l.cmd.Flags().StringVar(&l.fields, "fields", "", "Comma-separated table columns to show, in order")
+	l.cmd.Flags().BoolVar(&l.mine, "mine", false, "Only list projects you own")
+	l.cmd.Flags().BoolVar(&l.shared, "shared", false, "Only list projects shared with you")
+	l.cmd.MarkFlagsMutuallyExclusive("limit", "all")
+	l.cmd.MarkFlagsMutuallyExclusive("mine", "shared")
+
 	return l
 }
 
@@ -101,25 +161,199 @@ func (l *ProjectsListCommand) Run(cmd *cobra.Command, args []string) error {
 	// Get project service from DI container
 	projectService := l.parent.Root().Container().ProjectService()
 
-	// Fetch projects (service will ensure authentication)
-	projects, err := projectService.ListProjects(cmd.Context())
+	if l.limit < 1 {
+		return fmt.Errorf("--limit must be at least 1 (got %d)", l.limit)
+	}
+	less, ok := projectSortKeys[strings.ToLower(l.sortBy)]
+	if !ok {
+		return fmt.Errorf("invalid --sort %q (must be one of: name, created, updated, plan)", l.sortBy)
+	}
+	columns, err := selectColumns(l.fields, projectColumns, defaultProjectFields)
 	if err != nil {
 		return err
 	}
+	regions := splitFilterValues(l.regions)
+	plans := splitFilterValues(l.plans)
+	ownership := l.mine || l.shared
+	filtered := len(regions) > 0 || len(plans) > 0 || ownership
+
+	opts := &iface.ListProjectsOptions{Limit: l.limit}
+	// Filtering happens client-side, so a default cap could hide matches
+	if l.all || (filtered && !cmd.Flags().Changed("limit")) {
+		opts.Limit = 0
+	}
 
 	// Get output format
 	outputFormat, _ := cmd.Flags().GetString("output")
 	if outputFormat == "" {
 		outputFormat, _ = cmd.Parent().Parent().PersistentFlags().GetString("output")
 	}
+	if outputFormat == "jsonl" {
+		if cmd.Flags().Changed("sort") || l.reverse {
+			return fmt.Errorf("--sort and --reverse do not apply to -o jsonl, which prints projects in the order they are fetched")
+		}
+		return l.streamJSONLines(cmd, opts, regions, plans)
+	}
+
+	// Fetch projects (service will ensure authentication)
+	list, err := projectService.ListProjectsWithOptions(cmd.Context(), opts)
+	if err != nil {
+		return err
+	}
+	projects := list.Projects
+	if list.HasMore {
+		fmt.Fprintf(os.Stderr, "⚠ showing the first %d projects; use --limit or --all to see more\n", len(projects))
+	}
+	if ownership {
+		if projects, err = filterProjectsByOwnership(projects, l.mine); err != nil {
+			return err
+		}
+	}
+	if filtered {
+		projects = filterProjects(projects, regions, plans)
+	}
+	if strings.TrimSpace(l.fields) == "" && hasProjectRoles(projects) {
+		columns = append(columns, projectRoleColumn)
+	}
+	sortProjects(projects, less, l.reverse)
 
 	// Output based on format
 	switch outputFormat {
 	case "json":
 		return l.outputJSON(projects)
+	case "csv", "tsv":
+		return writeDelimited(os.Stdout, delimitedFormats[outputFormat], columns, projects)
 	default:
-		return l.outputTable(projects)
+		if len(projects) == 0 && filtered {
+			if ownership {
+				fmt.Println("No projects match the given filters.")
+			} else {
+				fmt.Println("No projects match the given --region/--plan filters.")
+			}
+			return nil
+		}
+		return l.outputTable(projects, columns)
+	}
+}
+
+// projectSortKeys maps --sort values to an ascending comparison. Equal
+// values fall through to the ID in sortProjects.
+var projectSortKeys = map[string]func(a, b iface.Project) int{
+	"name": func(a, b iface.Project) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	},
+	"created": func(a, b iface.Project) int { return a.CreatedAt.Compare(b.CreatedAt) },
+	"updated": func(a, b iface.Project) int { return a.UpdatedAt.Compare(b.UpdatedAt) },
+	"plan": func(a, b iface.Project) int {
+		return strings.Compare(strings.ToLower(a.PlanType), strings.ToLower(b.PlanType))
+	},
+}
+
+// sortProjects sorts projects in place by key, breaking ties by ID so the
+// output is deterministic
+func sortProjects(projects []iface.Project, key func(a, b iface.Project) int, reverse bool) {
+	sort.SliceStable(projects, func(i, j int) bool {
+		c := key(projects[i], projects[j])
+		if c == 0 {
+			c = strings.Compare(projects[i].ID, projects[j].ID)
+		}
+		if reverse {
+			return c > 0
+		}
+		return c < 0
+	})
+}
+
+// splitFilterValues parses a comma-separated filter flag into lowercase values
+func splitFilterValues(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.ToLower(strings.TrimSpace(v)); v != "" {
+			values = append(values, v)
+		}
 	}
+	return values
+}
+
+// filterProjects keeps the projects whose region and plan are in the given
+// lists. An empty list matches everything.
+func filterProjects(projects []iface.Project, regions, plans []string) []iface.Project {
+	matches := func(values []string, s string) bool {
+		if len(values) == 0 {
+			return true
+		}
+		for _, v := range values {
+			if strings.EqualFold(v, s) {
+				return true
+			}
+		}
+		return false
+	}
+
+	result := make([]iface.Project, 0, len(projects))
+	for _, p := range projects {
+		if matches(regions, p.Region) && matches(plans, p.PlanType) {
+			result = append(result, p)
+		}
+	}
+	return result
+}
+
+// filterProjectsByOwnership keeps the projects the user owns, or with mine
+// false the ones shared with them. It fails if any project lacks a role,
+// since the server then cannot say which is which.
+func filterProjectsByOwnership(projects []iface.Project, mine bool) ([]iface.Project, error) {
+	result := make([]iface.Project, 0, len(projects))
+	for _, p := range projects {
+		if p.Role == "" {
+			return nil, fmt.Errorf("ownership info not available from server; --mine and --shared need each project's role")
+		}
+		if strings.EqualFold(p.Role, iface.ProjectRoleOwner) == mine {
+			result = append(result, p)
+		}
+	}
+	return result, nil
+}
+
+// hasProjectRoles reports whether the server sent a role for any project
+func hasProjectRoles(projects []iface.Project) bool {
+	for _, p := range projects {
+		if p.Role != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// streamJSONLines prints projects as JSON Lines a page at a time, as each
+// page is fetched, applying the filters to every page
+func (l *ProjectsListCommand) streamJSONLines(cmd *cobra.Command, opts *iface.ListProjectsOptions, regions, plans []string) error {
+	projectService := l.parent.Root().Container().ProjectService()
+	out := jsonLinesWriter{w: os.Stdout}
+
+	opts.OnPage = func(page []iface.Project) error {
+		if l.mine || l.shared {
+			var err error
+			if page, err = filterProjectsByOwnership(page, l.mine); err != nil {
+				return err
+			}
+		}
+		for _, p := range filterProjects(page, regions, plans) {
+			if err := out.write(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	list, err := projectService.ListProjectsWithOptions(cmd.Context(), opts)
+	if err != nil {
+		return err
+	}
+	if list.HasMore {
+		fmt.Fprintf(os.Stderr, "⚠ stopped after the first %d projects; use --limit or --all to see more\n", opts.Limit)
+	}
+	return nil
 }
 
 // outputJSON outputs projects in JSON format
@@ -130,28 +364,55 @@ func (l *ProjectsListCommand) outputJSON(projects []iface.Project) error {
 }
 
 // outputTable outputs projects in table format
-func (l *ProjectsListCommand) outputTable(projects []iface.Project) error {
+func (l *ProjectsListCommand) outputTable(projects []iface.Project, columns []tableColumn[iface.Project]) error {
 	if len(projects) == 0 {
 		fmt.Println("No projects found.")
 		fmt.Println("\nCreate a new project with: kamui projects create")
 		return nil
 	}
 
-	rows := make([][]string, 0, len(projects))
-	for _, p := range projects {
-		rows = append(rows, []string{
-			p.ID,
-			p.Name,
-			p.PlanType,
-			p.Region,
-			fmt.Sprintf("%d", len(p.Apps)),
-			fmt.Sprintf("%d", len(p.Databases)),
-		})
-	}
-	printTable(os.Stdout, "", []string{"ID", "NAME", "PLAN", "REGION", "APPS", "DATABASES"}, rows)
+	printColumns(os.Stdout, "", columns, projects)
 	return nil
 }
 
+// projectColumns are the columns `projects list --fields` can select
+var projectColumns = []tableColumn[iface.Project]{
+	{"ID", func(p iface.Project) string { return p.ID }},
+	{"NAME", func(p iface.Project) string { return p.Name }},
+	{"DESCRIPTION", func(p iface.Project) string { return p.Description }},
+	{"PLAN", func(p iface.Project) string { return p.PlanType }},
+	{"REGION", func(p iface.Project) string { return p.Region }},
+	projectRoleColumn,
+	{"APPS", func(p iface.Project) string { return strconv.Itoa(len(p.Apps)) }},
+	{"DATABASES", func(p iface.Project) string { return strconv.Itoa(len(p.Databases)) }},
+	{"CREATED", func(p iface.Project) string { return formatListTime(p.CreatedAt) }},
+	{"UPDATED", func(p iface.Project) string { return formatListTime(p.UpdatedAt) }},
+}
+
+// projectRoleColumn shows the user's role in a project, e.g. owner
+var projectRoleColumn = tableColumn[iface.Project]{"ROLE", func(p iface.Project) string { return p.Role }}
+
+// defaultProjectFields are the columns shown without --fields
+var defaultProjectFields = []string{"ID", "NAME", "PLAN", "REGION", "APPS", "DATABASES"}
+
+// projectFieldNames lists the selectable project columns for help text
+func projectFieldNames() string {
+	names := make([]string, len(projectColumns))
+	for i, c := range projectColumns {
+		names[i] = c.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// formatListTime formats a timestamp for a table cell, leaving unset
+// times blank
+func formatListTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
 // ProjectsGetCommand represents the projects get command
 type ProjectsGetCommand struct {
 	parent *ProjectsCommand
@@ -165,15 +426,16 @@ func NewProjectsGetCommand(parent *ProjectsCommand) *ProjectsGetCommand {
 	}
 
 	g.cmd = &cobra.Command{
-		Use:   "get <project-id>",
-		Short: "Get a project by ID",
+		Use:   "get <name-or-id>",
+		Short: "Get a project by name or ID",
 		Long: `Get detailed information about a specific project.
 
 This command displays the project details including its apps and databases.
+The project can be given by ID or by name.
 
 Examples:
   kamui projects get 5f809f2f-0787-40ca-9a43-a3a59edb5400
-  kamui projects get 5f809f2f-0787-40ca-9a43-a3a59edb5400 -o json`,
+  kamui projects get my-project -o json`,
 		Args: cobra.ExactArgs(1),
 		RunE: g.Run,
 	}
@@ -188,13 +450,13 @@ func (g *ProjectsGetCommand) Command() *cobra.Command {
 
 // Run executes the projects get command
 func (g *ProjectsGetCommand) Run(cmd *cobra.Command, args []string) error {
-	projectID := args[0]
+	nameOrID := args[0]
 
 	// Get project service from DI container
 	projectService := g.parent.Root().Container().ProjectService()
 
 	// Fetch project (service will ensure authentication)
-	project, err := projectService.GetProject(cmd.Context(), projectID)
+	project, err := getProjectByNameOrID(cmd.Context(), projectService, nameOrID)
 	if err != nil {
 		return err
 	}
@@ -210,10 +472,35 @@ func (g *ProjectsGetCommand) Run(cmd *cobra.Command, args []string) error {
 	case "json":
 		return g.outputJSON(project)
 	default:
-		return g.outputDetail(project)
+		printProjectDetail(project)
+		return nil
 	}
 }
 
+// getProjectByNameOrID tries nameOrID as an ID first, which needs a single
+// request. If the API doesn't know it as an ID, the project list is fetched
+// and searched by name.
+func getProjectByNameOrID(ctx context.Context, projectService iface.ProjectService, nameOrID string) (*iface.Project, error) {
+	project, err := projectService.GetProject(ctx, nameOrID)
+	if err == nil {
+		return project, nil
+	}
+	var apiErr *api.APIError
+	if !errors.As(err, &apiErr) || (!apiErr.IsNotFound() && apiErr.StatusCode != http.StatusBadRequest) {
+		return nil, err
+	}
+
+	projects, listErr := projectService.ListProjects(ctx)
+	if listErr != nil {
+		return nil, fmt.Errorf("failed to fetch projects: %w", listErr)
+	}
+	match, err := findProject(projects, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	return projectService.GetProject(ctx, match.ID)
+}
+
 // outputJSON outputs project in JSON format
 func (g *ProjectsGetCommand) outputJSON(project *iface.Project) error {
 	encoder := json.NewEncoder(os.Stdout)
@@ -221,8 +508,8 @@ func (g *ProjectsGetCommand) outputJSON(project *iface.Project) error {
 	return encoder.Encode(project)
 }
 
-// outputDetail outputs project details in human-readable format
-func (g *ProjectsGetCommand) outputDetail(project *iface.Project) error {
+// printProjectDetail outputs project details in human-readable format
+func printProjectDetail(project *iface.Project) {
 	fmt.Printf("Project: %s\n", project.Name)
 	fmt.Printf("ID:      %s\n", project.ID)
 	fmt.Printf("Plan:    %s\n", project.PlanType)
@@ -262,10 +549,114 @@ func (g *ProjectsGetCommand) outputDetail(project *iface.Project) error {
 		}
 		printTable(os.Stdout, "  ", []string{"ID", "NAME", "TYPE", "STATUS"}, rows)
 	}
+}
+
+// ProjectsDescribeCommand represents the projects describe command
+type ProjectsDescribeCommand struct {
+	parent *ProjectsCommand
+	cmd    *cobra.Command
+}
+
+// NewProjectsDescribeCommand creates a new projects describe command
+func NewProjectsDescribeCommand(parent *ProjectsCommand) *ProjectsDescribeCommand {
+	d := &ProjectsDescribeCommand{
+		parent: parent,
+	}
+
+	d.cmd = &cobra.Command{
+		Use:   "describe <name-or-id>",
+		Short: "Show project details with resource usage",
+		Long: `Show everything 'projects get' does, plus the project's CPU, memory,
+and storage usage against the limits of its plan.
+
+Plans that do not report usage show the project details only.
 
+Examples:
+  kamui projects describe my-project
+  kamui projects describe 5f809f2f-0787-40ca-9a43-a3a59edb5400 -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: d.Run,
+	}
+
+	return d
+}
+
+// Command returns the underlying cobra command
+func (d *ProjectsDescribeCommand) Command() *cobra.Command {
+	return d.cmd
+}
+
+// projectDescription is the JSON output of projects describe
+type projectDescription struct {
+	*iface.Project
+	Usage *iface.ProjectUsage `json:"usage,omitempty"`
+}
+
+// Run executes the projects describe command
+func (d *ProjectsDescribeCommand) Run(cmd *cobra.Command, args []string) error {
+	projectService := d.parent.Root().Container().ProjectService()
+
+	project, err := getProjectByNameOrID(cmd.Context(), projectService, args[0])
+	if err != nil {
+		return err
+	}
+
+	usage, err := projectService.GetUsage(cmd.Context(), project.ID)
+	if err != nil {
+		// Plans without usage reporting answer 404
+		var apiErr *api.APIError
+		if !errors.As(err, &apiErr) || !apiErr.IsNotFound() {
+			return err
+		}
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(projectDescription{Project: project, Usage: usage})
+	}
+
+	printProjectDetail(project)
+	if usage != nil {
+		fmt.Println("\nUsage:")
+		rows := [][]string{
+			usageRow("CPU", usage.CPU),
+			usageRow("Memory", usage.Memory),
+			usageRow("Storage", usage.Storage),
+		}
+		printTable(os.Stdout, "  ", []string{"RESOURCE", "USED", "LIMIT", "%"}, rows)
+
+		if limits := usage.Limits; limits != nil {
+			fmt.Println("\nApp limits:")
+			if limits.MaxReplicas > 0 {
+				fmt.Printf("  Max replicas: %d\n", limits.MaxReplicas)
+			}
+			if len(limits.AppSpecTypes) > 0 {
+				fmt.Printf("  App specs:    %s\n", strings.Join(limits.AppSpecTypes, ", "))
+			}
+		}
+	}
 	return nil
 }
 
+// usageRow formats one resource for the usage table
+func usageRow(name string, r iface.ResourceUsage) []string {
+	used := formatQuantity(r.Used, r.Unit)
+	if r.Limit <= 0 {
+		return []string{name, used, "unlimited", "-"}
+	}
+	return []string{name, used, formatQuantity(r.Limit, r.Unit), fmt.Sprintf("%.0f%%", r.Used/r.Limit*100)}
+}
+
+// formatQuantity renders a value with its unit, dropping needless decimals
+func formatQuantity(v float64, unit string) string {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if unit == "" {
+		return s
+	}
+	return s + " " + unit
+}
+
 // ProjectsCreateCommand represents the projects create command
 type ProjectsCreateCommand struct {
 	parent *ProjectsCommand
@@ -275,6 +666,7 @@ type ProjectsCreateCommand struct {
 	description    string
 	planType       string
 	region         string
+	from           string
 	nonInteractive bool
 }
 
@@ -292,15 +684,24 @@ func NewProjectsCreateCommand(parent *ProjectsCommand) *ProjectsCreateCommand {
 This command will guide you through the process of creating a new project,
 including selecting the plan type and region.
 
+With --from, the description, plan and region of an existing project are
+used as defaults: the wizard offers them pre-selected, and without the wizard
+they apply unless overridden by --description, --plan or --region. Apps and
+databases are not copied.
+
 Examples:
-  kamui projects create`,
+  kamui projects create
+  kamui projects create --name my-project --plan free -o json
+  kamui projects create --from my-project
+  kamui projects create --name my-project-2 --from my-project --region osaka`,
 		RunE: c.Run,
 	}
 
 	c.cmd.Flags().StringVar(&c.name, "name", "", "Project name")
 	c.cmd.Flags().StringVar(&c.description, "description", "", "Project description (optional, max 80 chars)")
-	c.cmd.Flags().StringVar(&c.planType, "plan", "", "Plan type: free or pro")
-	c.cmd.Flags().StringVar(&c.region, "region", "", "Region (currently only tokyo)")
+	c.cmd.Flags().StringVar(&c.planType, "plan", "", "Plan type, e.g. free or pro (default: free, or the first plan offered without it)")
+	c.cmd.Flags().StringVar(&c.region, "region", "", "Region, e.g. tokyo (default: tokyo, or the first region offered without it)")
+	c.cmd.Flags().StringVar(&c.from, "from", "", "Existing project (name or ID) whose description, plan and region are the defaults")
 	c.cmd.Flags().BoolVar(&c.nonInteractive, "non-interactive", false, "Fail instead of prompting when required flags are missing")
 
 	return c
@@ -317,13 +718,19 @@ func (c *ProjectsCreateCommand) Run(cmd *cobra.Command, args []string) error {
 
 	projectService := c.parent.Root().Container().ProjectService()
 
+	if c.from != "" {
+		if err := c.applySource(cmd, projectService); err != nil {
+			return err
+		}
+	}
+
 	if c.name != "" || c.nonInteractive {
-		return c.runWithFlags(ctx, projectService)
+		return c.runWithFlags(cmd, projectService)
 	}
 
 	// Step 1: Project name
 	var name string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Project name:",
 	}, &name, survey.WithValidator(survey.Required)); err != nil {
 		return err
@@ -331,41 +738,65 @@ func (c *ProjectsCreateCommand) Run(cmd *cobra.Command, args []string) error {
 
 	// Step 2: Description (optional)
 	var description string
-	if err := survey.AskOne(&survey.Input{
+	if err := ask(&survey.Input{
 		Message: "Description (optional, max 80 chars):",
+		Default: c.description,
 	}, &description); err != nil {
 		return err
 	}
 
 	// Truncate description if too long
-	if len(description) > 80 {
-		description = description[:80]
+	if len(description) > maxProjectDescriptionLen {
+		description = description[:maxProjectDescriptionLen]
 	}
 
 	// Step 3: Plan type
-	planTypes := []string{"Free", "Pro"}
-	planTypeMap := map[string]string{
-		"Free": "free",
-		"Pro":  "pro",
+	plans := availablePlans(ctx, projectService)
+	planNames := make([]string, len(plans))
+	for i, p := range plans {
+		planNames[i] = p.Name
 	}
 
-	var selectedPlan string
-	if err := survey.AskOne(&survey.Select{
+	planType := c.planType
+	if planType == "" {
+		planType = defaultPlan(plans)
+	}
+	var selectedPlan int
+	if err := ask(&survey.Select{
 		Message: "Plan type:",
-		Options: planTypes,
-		Default: "Free",
+		Options: planNames,
+		Default: planNames[defaultOption(plans, planType, func(p iface.Plan) string { return p.ID })],
 	}, &selectedPlan); err != nil {
 		return err
 	}
+	planType = plans[selectedPlan].ID
 
-	planType := planTypeMap[selectedPlan]
-
-	// Step 4: Region (Tokyo only)
-	region := "tokyo"
-	fmt.Println("Region: Tokyo")
+	// Step 4: Region
+	regions := availableRegions(ctx, projectService)
+	regionID := c.region
+	if regionID == "" {
+		regionID = defaultRegion(regions)
+	}
+	selectedRegion := defaultOption(regions, regionID, func(r iface.Region) string { return r.ID })
+	if len(regions) == 1 {
+		fmt.Fprintf(os.Stderr, "Region: %s\n", regions[0].Name)
+	} else {
+		regionNames := make([]string, len(regions))
+		for i, r := range regions {
+			regionNames[i] = r.Name
+		}
+		if err := ask(&survey.Select{
+			Message: "Region:",
+			Options: regionNames,
+			Default: regionNames[selectedRegion],
+		}, &selectedRegion); err != nil {
+			return err
+		}
+	}
+	region := regions[selectedRegion].ID
 
 	// Create the project
-	fmt.Println("\nCreating project...")
+	fmt.Fprintln(os.Stderr, "\nCreating project...")
 
 	input := &iface.CreateProjectInput{
 		Name:        name,
@@ -378,43 +809,50 @@ func (c *ProjectsCreateCommand) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	fmt.Printf("\n✓ Project \"%s\" created successfully!\n", name)
-	fmt.Printf("  Plan:   %s\n", planType)
-	fmt.Printf("  Region: %s\n", region)
-	fmt.Println("\nNext steps:")
-	fmt.Printf("  kamui projects list          - View your projects\n")
-	fmt.Printf("  kamui apps create            - Create an app in this project\n")
-
-	return nil
+	return printCreatedProject(cmd, input)
 }
 
-func (c *ProjectsCreateCommand) runWithFlags(ctx context.Context, projectService iface.ProjectService) error {
+func (c *ProjectsCreateCommand) runWithFlags(cmd *cobra.Command, projectService iface.ProjectService) error {
+	ctx := cmd.Context()
+
 	if c.name == "" {
 		return fmt.Errorf("--name is required in non-interactive project creation")
 	}
 
 	description := c.description
-	if len(description) > 80 {
-		description = description[:80]
+	if len(description) > maxProjectDescriptionLen {
+		description = description[:maxProjectDescriptionLen]
 	}
 
-	planType := c.planType
-	if planType == "" {
-		planType = "free"
+	plans := availablePlans(ctx, projectService)
+	planIDs := make([]string, len(plans))
+	for i, p := range plans {
+		planIDs[i] = p.ID
 	}
-	if planType != "free" && planType != "pro" {
-		return fmt.Errorf("--plan must be free or pro")
+	planValue := c.planType
+	if planValue == "" {
+		planValue = defaultPlan(plans)
+	}
+	planType, err := pickOption("--plan", planValue, planIDs)
+	if err != nil {
+		return err
 	}
 
-	region := c.region
-	if region == "" {
-		region = "tokyo"
+	regions := availableRegions(ctx, projectService)
+	regionIDs := make([]string, len(regions))
+	for i, r := range regions {
+		regionIDs[i] = r.ID
 	}
-	if region != "tokyo" {
-		return fmt.Errorf("--region must be tokyo")
+	regionValue := c.region
+	if regionValue == "" {
+		regionValue = defaultRegion(regions)
+	}
+	region, err := pickOption("--region", regionValue, regionIDs)
+	if err != nil {
+		return err
 	}
 
-	fmt.Println("\nCreating project...")
+	fmt.Fprintln(os.Stderr, "\nCreating project...")
 
 	input := &iface.CreateProjectInput{
 		Name:        c.name,
@@ -427,9 +865,141 @@ func (c *ProjectsCreateCommand) runWithFlags(ctx context.Context, projectService
 		return err
 	}
 
-	fmt.Printf("\n✓ Project \"%s\" created successfully!\n", c.name)
-	fmt.Printf("  Plan:   %s\n", planType)
-	fmt.Printf("  Region: %s\n", region)
+	return printCreatedProject(cmd, input)
+}
+
+// applySource resolves --from and uses the source project's description,
+// plan and region for the flags that were not set explicitly
+func (c *ProjectsCreateCommand) applySource(cmd *cobra.Command, projectService iface.ProjectService) error {
+	projects, err := projectService.ListProjects(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	source, err := findProject(projects, c.from)
+	if err != nil {
+		return err
+	}
+
+	if !cmd.Flags().Changed("description") {
+		c.description = source.Description
+	}
+	if !cmd.Flags().Changed("plan") {
+		c.planType = source.PlanType
+	}
+	if !cmd.Flags().Changed("region") {
+		c.region = source.Region
+	}
+	fmt.Fprintf(os.Stderr, "Using settings from project: %s\n", source.Name)
+	return nil
+}
+
+// defaultOption returns the index of the option whose ID matches id
+// case-insensitively, or 0
+func defaultOption[T any](options []T, id string, idOf func(T) string) int {
+	for i, o := range options {
+		if strings.EqualFold(idOf(o), id) {
+			return i
+		}
+	}
+	return 0
+}
+
+// fallbackPlans and fallbackRegions are offered when the server does not
+// list its plans or regions
+var (
+	fallbackPlans   = []iface.Plan{{ID: "free", Name: "Free"}, {ID: "pro", Name: "Pro"}}
+	fallbackRegions = []iface.Region{{ID: "tokyo", Name: "Tokyo"}}
+)
+
+// defaultPlanID is the plan a new project gets unless --plan says otherwise
+const defaultPlanID = "free"
+
+// defaultPlan returns defaultPlanID when plans offers it, else the first
+// plan, so an unset --plan never picks a paid plan the server happens to
+// list first
+func defaultPlan(plans []iface.Plan) string {
+	for _, p := range plans {
+		if strings.EqualFold(p.ID, defaultPlanID) {
+			return p.ID
+		}
+	}
+	return plans[0].ID
+}
+
+// defaultRegionID is the region a new project gets unless --region says
+// otherwise
+const defaultRegionID = "tokyo"
+
+// defaultRegion returns defaultRegionID when regions offers it, else the
+// first region
+func defaultRegion(regions []iface.Region) string {
+	for _, r := range regions {
+		if strings.EqualFold(r.ID, defaultRegionID) {
+			return r.ID
+		}
+	}
+	return regions[0].ID
+}
+
+// availablePlans returns the plans the server offers, or fallbackPlans
+// when they cannot be fetched
+func availablePlans(ctx context.Context, projectService iface.ProjectService) []iface.Plan {
+	plans, err := projectService.GetPlans(ctx)
+	if err != nil || len(plans) == 0 {
+		return fallbackPlans
+	}
+	return plans
+}
+
+// availableRegions returns the regions the server offers, or
+// fallbackRegions when they cannot be fetched
+func availableRegions(ctx context.Context, projectService iface.ProjectService) []iface.Region {
+	regions, err := projectService.GetRegions(ctx)
+	if err != nil || len(regions) == 0 {
+		return fallbackRegions
+	}
+	return regions
+}
+
+// pickOption validates value of flag against the allowed IDs, matching
+// case-insensitively. An empty value picks the first allowed ID.
+func pickOption(flag, value string, allowed []string) (string, error) {
+	if value == "" {
+		return allowed[0], nil
+	}
+	for _, id := range allowed {
+		if strings.EqualFold(id, value) {
+			return id, nil
+		}
+	}
+	return "", fmt.Errorf("%s must be one of: %s", flag, strings.Join(allowed, ", "))
+}
+
+// createdProject is the JSON output of `projects create`
+type createdProject struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	PlanType    string `json:"plan_type"`
+	Region      string `json:"region"`
+}
+
+// printCreatedProject reports a newly created project, as JSON when
+// --output json is set
+func printCreatedProject(cmd *cobra.Command, input *iface.CreateProjectInput) error {
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(createdProject{
+			Name:        input.Name,
+			Description: input.Description,
+			PlanType:    input.PlanType,
+			Region:      input.Region,
+		})
+	}
+
+	fmt.Printf("\n✓ Project \"%s\" created successfully!\n", input.Name)
+	fmt.Printf("  Plan:   %s\n", input.PlanType)
+	fmt.Printf("  Region: %s\n", input.Region)
 	fmt.Println("\nNext steps:")
 	fmt.Printf("  kamui projects list          - View your projects\n")
 	fmt.Printf("  kamui apps create            - Create an app in this project\n")
@@ -437,6 +1007,105 @@ func (c *ProjectsCreateCommand) runWithFlags(ctx context.Context, projectService
 	return nil
 }
 
+// ProjectsUpdateCommand represents the projects update command
+type ProjectsUpdateCommand struct {
+	parent *ProjectsCommand
+	cmd    *cobra.Command
+
+	name        string
+	description string
+}
+
+// NewProjectsUpdateCommand creates a new projects update command
+func NewProjectsUpdateCommand(parent *ProjectsCommand) *ProjectsUpdateCommand {
+	u := &ProjectsUpdateCommand{
+		parent: parent,
+	}
+
+	u.cmd = &cobra.Command{
+		Use:   "update <project-name-or-id>",
+		Short: "Update a project's name or description",
+		Long: `Update a project's name or description.
+
+Only the flags you pass are changed. Pass --description "" to clear the
+description.
+
+Examples:
+  kamui projects update my-projcet --name my-project
+  kamui projects update my-project --description "Marketing site"
+  kamui projects update 5f809f2f-0787-40ca-9a43-a3a59edb5400 --name api -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: u.Run,
+	}
+
+	u.cmd.Flags().StringVar(&u.name, "name", "", "New project name")
+	u.cmd.Flags().StringVar(&u.description, "description", "", "New project description (max 80 chars)")
+
+	return u
+}
+
+// Command returns the underlying cobra command
+func (u *ProjectsUpdateCommand) Command() *cobra.Command {
+	return u.cmd
+}
+
+// Run executes the projects update command
+func (u *ProjectsUpdateCommand) Run(cmd *cobra.Command, args []string) error {
+	nameOrID := args[0]
+	ctx := cmd.Context()
+
+	input := &iface.UpdateProjectInput{}
+	if cmd.Flags().Changed("name") {
+		if u.name == "" {
+			return fmt.Errorf("--name must not be empty")
+		}
+		input.Name = &u.name
+	}
+	if cmd.Flags().Changed("description") {
+		if len(u.description) > maxProjectDescriptionLen {
+			return fmt.Errorf("--description must be at most %d characters (got %d)", maxProjectDescriptionLen, len(u.description))
+		}
+		input.Description = &u.description
+	}
+	if input.Name == nil && input.Description == nil {
+		return fmt.Errorf("nothing to update: pass --name and/or --description")
+	}
+
+	projectService := u.parent.Root().Container().ProjectService()
+
+	projects, err := projectService.ListProjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	project, err := findProject(projects, nameOrID)
+	if err != nil {
+		return err
+	}
+
+	updated, err := projectService.UpdateProject(ctx, project.ID, input)
+	if err != nil {
+		return err
+	}
+
+	if resolveOutputFormat(cmd) == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(updated)
+	}
+
+	fmt.Printf("✓ Project \"%s\" updated successfully.\n", updated.Name)
+	fmt.Printf("  ID:          %s\n", updated.ID)
+	if input.Name != nil {
+		fmt.Printf("  Name:        %s\n", updated.Name)
+	}
+	if input.Description != nil {
+		fmt.Printf("  Description: %s\n", updated.Description)
+	}
+
+	return nil
+}
+
 // ProjectsDeleteCommand represents the projects delete command
 type ProjectsDeleteCommand struct {
 	parent *ProjectsCommand
@@ -460,14 +1129,20 @@ a matching project and confirm before deletion.
 WARNING: This action is irreversible. All apps, databases, and other resources
 in the project will be permanently deleted.
 
+A project that still has apps or databases can only be deleted by typing its
+name; an empty one needs a yes/no answer unless --confirm-name is given.
+--yes skips confirmation entirely.
+
 Examples:
   kamui projects delete my-project
-  kamui projects delete 5f809f2f-0787-40ca-9a43-a3a59edb5400`,
+  kamui projects delete 5f809f2f-0787-40ca-9a43-a3a59edb5400
+  kamui projects delete my-project --confirm-name`,
 		Args: cobra.ExactArgs(1),
 		RunE: d.Run,
 	}
 
 	d.cmd.Flags().BoolP("yes", "y", false, "Skip confirmation prompt")
+	d.cmd.Flags().Bool("confirm-name", false, "Require typing the project name to confirm")
 
 	return d
 }
@@ -491,17 +1166,9 @@ func (d *ProjectsDeleteCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	// Find matching project by name or ID
-	var project *iface.Project
-	for i := range projects {
-		p := &projects[i]
-		if p.ID == nameOrID || p.Name == nameOrID {
-			project = p
-			break
-		}
-	}
-
-	if project == nil {
-		return fmt.Errorf("project not found: %s\n\nUse 'kamui projects list' to see available projects", nameOrID)
+	project, err := findProject(projects, nameOrID)
+	if err != nil {
+		return err
 	}
 
 	// Check for --yes flag
@@ -509,29 +1176,28 @@ func (d *ProjectsDeleteCommand) Run(cmd *cobra.Command, args []string) error {
 
 	if !skipConfirm {
 		// Show warning
-		fmt.Printf("\n⚠️  WARNING: You are about to delete the following project:\n\n")
-		fmt.Printf("  Name:   %s\n", project.Name)
-		fmt.Printf("  ID:     %s\n", project.ID)
-		fmt.Printf("  Apps:   %d\n", len(project.Apps))
-		fmt.Printf("  DBs:    %d\n", len(project.Databases))
-		fmt.Println("\n  This action is IRREVERSIBLE. All resources will be permanently deleted.")
-
-		// Confirmation prompt
-		var confirm bool
-		if err := survey.AskOne(&survey.Confirm{
-			Message: fmt.Sprintf("Are you sure you want to delete project \"%s\"?", project.Name),
-			Default: false,
-		}, &confirm); err != nil {
+		fmt.Fprintf(os.Stderr, "\n⚠️  WARNING: You are about to delete the following project:\n\n")
+		fmt.Fprintf(os.Stderr, "  Name:   %s\n", project.Name)
+		fmt.Fprintf(os.Stderr, "  ID:     %s\n", project.ID)
+		printDeletedResources(os.Stderr, project)
+		fmt.Fprintln(os.Stderr, "\n  This action is IRREVERSIBLE. All resources will be permanently deleted.")
+
+		// Projects with resources need the name typed out
+		typeName, _ := cmd.Flags().GetBool("confirm-name")
+		typeName = typeName || len(project.Apps) > 0 || len(project.Databases) > 0
+
+		confirm, err := confirmDeletion("project", project.Name, typeName)
+		if err != nil {
 			return err
 		}
 
 		if !confirm {
-			fmt.Println("Cancelled.")
+			fmt.Fprintln(os.Stderr, "Cancelled.")
 			return nil
 		}
 	}
 
-	fmt.Println("\nDeleting project...")
+	fmt.Fprintln(os.Stderr, "\nDeleting project...")
 
 	if err := projectService.DeleteProject(ctx, project.ID); err != nil {
 		return err
@@ -541,3 +1207,39 @@ func (d *ProjectsDeleteCommand) Run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// maxListedResources caps how many apps or databases the delete warning
+// names before summarizing the rest
+const maxListedResources = 10
+
+// printDeletedResources lists the apps and databases that are deleted
+// along with project
+func printDeletedResources(w io.Writer, project iface.Project) {
+	apps := make([]string, len(project.Apps))
+	for i, a := range project.Apps {
+		apps[i] = fmt.Sprintf("%s (%s)", a.Name, a.ID)
+	}
+	dbs := make([]string, len(project.Databases))
+	for i, db := range project.Databases {
+		dbs[i] = fmt.Sprintf("%s (%s)", db.Name, db.ID)
+	}
+	printResourceList(w, "Apps", apps)
+	printResourceList(w, "DBs", dbs)
+}
+
+// printResourceList prints a labeled list of names, truncated after
+// maxListedResources entries
+func printResourceList(w io.Writer, label string, names []string) {
+	if len(names) == 0 {
+		fmt.Fprintf(w, "  %-7s none\n", label+":")
+		return
+	}
+	fmt.Fprintf(w, "  %-7s %d\n", label+":", len(names))
+	for i, name := range names {
+		if i == maxListedResources {
+			fmt.Fprintf(w, "    ... and %d more\n", len(names)-i)
+			break
+		}
+		fmt.Fprintf(w, "    - %s\n", name)
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+)
+
+// ask runs a survey prompt on stderr, keeping stdout free for command
+// output such as JSON
+func ask(p survey.Prompt, response interface{}, opts ...survey.AskOpt) error {
+	return survey.AskOne(p, response, append(opts, survey.WithStdio(os.Stdin, os.Stderr, os.Stderr))...)
+}
+
+// askInput prompts for a line of text. It is a variable so tests can supply
+// the answer without a terminal.
+var askInput = func(message string) (string, error) {
+	var answer string
+	err := ask(&survey.Input{Message: message}, &answer)
+	return answer, err
+}
+
+// askConfirm asks a yes/no question. It is a variable so tests can supply
+// the answer without a terminal.
+var askConfirm = func(message string, def bool) (bool, error) {
+	answer := def
+	err := ask(&survey.Confirm{Message: message, Default: def}, &answer)
+	return answer, err
+}
+
+// confirmDeletion asks the user to approve deleting the named resource. With
+// typeName set they must type the name exactly, as GitHub does for
+// repository deletion, and a mismatch is an error; otherwise a yes/no prompt
+// is enough. It returns false if the user declined.
+func confirmDeletion(kind, name string, typeName bool) (bool, error) {
+	if !typeName {
+		var confirm bool
+		if err := ask(&survey.Confirm{
+			Message: fmt.Sprintf("Are you sure you want to delete %s \"%s\"?", kind, name),
+			Default: false,
+		}, &confirm); err != nil {
+			return false, err
+		}
+		return confirm, nil
+	}
+
+	typed, err := askInput(fmt.Sprintf("Type the %s name \"%s\" to confirm deletion:", kind, name))
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(typed) != name {
+		return false, fmt.Errorf("typed name %q does not match %s \"%s\"; nothing was deleted", typed, kind, name)
+	}
+	return true, nil
+}
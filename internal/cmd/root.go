@@ -3,10 +3,19 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/kamui-project/kamui-cli/internal/api"
 	"github.com/kamui-project/kamui-cli/internal/di"
+	"github.com/kamui-project/kamui-cli/internal/redact"
+	"github.com/kamui-project/kamui-cli/internal/service"
 	"github.com/spf13/cobra"
 )
 
@@ -15,24 +24,60 @@ var (
 	Version = "dev"
 )
 
+const (
+	// envTimeout overrides the API request timeout when --timeout is not set
+	envTimeout = "KAMUI_TIMEOUT"
+
+	// envUploadTimeout overrides the upload timeout when --upload-timeout is not set
+	envUploadTimeout = "KAMUI_UPLOAD_TIMEOUT"
+
+	// envDebug enables HTTP debug logging: "1"/"true", or "body" to include bodies
+	envDebug = "KAMUI_DEBUG"
+
+	// envProxy overrides HTTPS_PROXY/HTTP_PROXY when --proxy is not set
+	envProxy = "KAMUI_PROXY"
+
+	// envCACert names a PEM bundle of extra CAs when --ca-cert is not set
+	envCACert = "KAMUI_CA_CERT"
+)
+
 // RootCommand represents the root CLI command
 type RootCommand struct {
 	container *di.Container
 	cmd       *cobra.Command
 
+	// cancel releases the overall command deadline set in PersistentPreRunE
+	cancel context.CancelFunc
+
+	// timings collects request durations for --timings; nil when disabled
+	timings *api.Timings
+
+	// transport holds the proxy and TLS settings resolved in initialize,
+	// for commands that make requests outside the container (doctor)
+	transport api.TransportOptions
+
 	// Subcommands
-	loginCmd    *LoginCommand
-	logoutCmd   *LogoutCommand
-	projectsCmd *ProjectsCommand
-	appsCmd     *AppsCommand
-	tokensCmd   *TokensCommand
-	mcpCmd      *McpCommand
+	loginCmd     *LoginCommand
+	logoutCmd    *LogoutCommand
+	authCmd      *AuthCommand
+	projectsCmd  *ProjectsCommand
+	appsCmd      *AppsCommand
+	databasesCmd *DatabasesCommand
+	tokensCmd    *TokensCommand
+	mcpCmd       *McpCommand
+	configCmd    *ConfigCommand
+	doctorCmd    *DoctorCommand
+	orgsCmd      *OrgsCommand
+	applyCmd     *ApplyCommand
 }
 
 // NewRootCommand creates a new root command
 func NewRootCommand() *RootCommand {
 	r := &RootCommand{}
 
+	// Report the build version in the API User-Agent
+	api.SetVersion(Version)
+
 	r.cmd = &cobra.Command{
 		Use:   "kamui",
 		Short: "Kamui CLI - Command line interface for Kamui Platform",
@@ -45,51 +90,265 @@ To get started, run:
   kamui login    - Authenticate with your Kamui account
   kamui projects list - View your projects`,
 		Version: Version,
+		// Execute prints errors itself, as text or as JSON
+		SilenceErrors: true,
+		SilenceUsage:  true,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			return r.initialize()
+			jsonErrors = jsonErrorsEnabled(cmd)
+			tableWidth = resolveTableWidth(cmd)
+			if err := r.initialize(cmd); err != nil {
+				return err
+			}
+			if err := r.applyDefaultProject(cmd); err != nil {
+				return err
+			}
+			cancel, err := applyDeadline(cmd)
+			if err != nil {
+				return err
+			}
+			r.cancel = cancel
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return r.saveDefaultProject(cmd)
 		},
 	}
 
 	// Global flags
-	r.cmd.PersistentFlags().StringP("output", "o", "text", "Output format (text, json)")
+	r.cmd.PersistentFlags().StringP("output", "o", "text", "Output format (text, json; list commands also take csv, tsv and jsonl)")
+	r.cmd.PersistentFlags().Bool("json-errors", false, "Print errors to stderr as JSON (implied by -o json)")
+	r.cmd.PersistentFlags().Bool("no-color", false, "Disable colored output (env "+envNoColor+")")
+	r.cmd.PersistentFlags().Bool("wide", false, "Show full values in tables instead of truncating IDs and URLs to the terminal width")
+	r.cmd.PersistentFlags().Duration("timeout", 0, "Timeout for API requests, e.g. 10s or 2m (default 30s, env "+envTimeout+")")
+	r.cmd.PersistentFlags().Duration("upload-timeout", 0, "Timeout for file uploads (default 10m, env "+envUploadTimeout+")")
+	r.cmd.PersistentFlags().String("api-url", "", "Kamui API URL for this invocation, overriding the config file")
+	r.cmd.PersistentFlags().String("org", "", "Organization ID for this invocation, overriding the config file")
+	r.cmd.PersistentFlags().String("proxy", "", "Proxy URL for API and login requests, overriding HTTPS_PROXY (env "+envProxy+")")
+	r.cmd.PersistentFlags().String("ca-cert", "", "PEM file of CA certificates to trust in addition to the system ones (env "+envCACert+")")
+	r.cmd.PersistentFlags().Bool("insecure-skip-verify", false, "Do not verify the API's TLS certificate (development only)")
+	r.cmd.PersistentFlags().Bool("insecure-allow-http", false, "Allow a plain http API URL for hosts other than localhost, sending tokens unencrypted (development only)")
+	r.cmd.PersistentFlags().Bool("no-cache", false, "Fetch the project list from the API even if a cached copy is fresh")
+	r.cmd.PersistentFlags().Bool("debug", false, "Log HTTP requests to stderr (env "+envDebug+"=1)")
+	r.cmd.PersistentFlags().Bool("debug-body", false, "Also log HTTP request/response bodies, secrets redacted (env "+envDebug+"=body)")
+	r.cmd.PersistentFlags().Bool("timings", false, "Print the count and total time of API requests per endpoint to stderr when the command ends")
 
 	// Initialize subcommands (will be wired after container init)
 	r.loginCmd = NewLoginCommand(r)
 	r.logoutCmd = NewLogoutCommand(r)
+	r.authCmd = NewAuthCommand(r)
 	r.projectsCmd = NewProjectsCommand(r)
 	r.appsCmd = NewAppsCommand(r)
+	r.databasesCmd = NewDatabasesCommand(r)
 	r.tokensCmd = NewTokensCommand(r)
 	r.mcpCmd = NewMcpCommand(r)
+	r.configCmd = NewConfigCommand(r)
+	r.applyCmd = NewApplyCommand(r)
+	r.doctorCmd = NewDoctorCommand(r)
+	r.orgsCmd = NewOrgsCommand(r)
 
 	// Add subcommands
 	r.cmd.AddCommand(r.loginCmd.Command())
 	r.cmd.AddCommand(r.logoutCmd.Command())
+	r.cmd.AddCommand(r.authCmd.Command())
 	r.cmd.AddCommand(r.projectsCmd.Command())
 	r.cmd.AddCommand(r.appsCmd.Command())
+	r.cmd.AddCommand(r.databasesCmd.Command())
 	r.cmd.AddCommand(r.tokensCmd.Command())
 	r.cmd.AddCommand(r.mcpCmd.Command())
+	r.cmd.AddCommand(r.configCmd.Command())
+	r.cmd.AddCommand(r.applyCmd.Command())
+	r.cmd.AddCommand(r.doctorCmd.Command())
+	r.cmd.AddCommand(r.orgsCmd.Command())
+
+	addSetDefaultFlags(r.cmd)
+	r.registerCompletions()
+	markUsageErrors(r.cmd)
+	markDeadlineErrors(r.cmd)
 
 	return r
 }
 
-// initialize sets up the DI container
-func (r *RootCommand) initialize() error {
+// initialize resolves the transport settings and sets up the DI container
+func (r *RootCommand) initialize(cmd *cobra.Command) error {
+	// Resolved once here, so warnings such as the one for
+	// --insecure-skip-verify are printed once per command
+	transport, err := resolveTransport(cmd)
+	if err != nil {
+		return err
+	}
+	r.transport = transport
+
 	// Skip if container is already set (e.g., for testing)
 	if r.container != nil {
 		return nil
 	}
 
-	var err error
-	r.container, err = di.NewContainer()
+	timeout, err := resolveDuration(cmd, "timeout", envTimeout)
+	if err != nil {
+		return err
+	}
+	uploadTimeout, err := resolveDuration(cmd, "upload-timeout", envUploadTimeout)
+	if err != nil {
+		return err
+	}
+
+	debug, debugBodies := resolveDebug(cmd)
+	apiURL, _ := cmd.Flags().GetString("api-url")
+	org, _ := cmd.Flags().GetString("org")
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	if timings, _ := cmd.Flags().GetBool("timings"); timings {
+		r.timings = api.NewTimings()
+	}
+
+	r.container, err = di.NewContainer(service.HTTPOptions{
+		Timeout:           timeout,
+		UploadTimeout:     uploadTimeout,
+		Debug:             debug,
+		DebugBodies:       debugBodies,
+		Timings:           r.timings,
+		Transport:         transport,
+		AllowInsecureHTTP: resolveAllowHTTP(cmd),
+		Relogin:           r.reloginPrompt(cmd),
+	}, apiURL, org, noCache)
 	if err != nil {
 		return fmt.Errorf("failed to initialize: %w", err)
 	}
 	return nil
 }
 
+// resolveAllowHTTP reports whether --insecure-allow-http is set, warning
+// that tokens may then be sent in cleartext
+func resolveAllowHTTP(cmd *cobra.Command) bool {
+	allow, _ := cmd.Flags().GetBool("insecure-allow-http")
+	if allow {
+		fmt.Fprintln(os.Stderr, "⚠ WARNING: plain http API URLs are allowed (--insecure-allow-http).")
+		fmt.Fprintln(os.Stderr, "⚠ Your access token is sent unencrypted to non-https API URLs. Never use this outside a development environment.")
+	}
+	return allow
+}
+
+// resolveDebug reports whether HTTP debug logging is enabled and whether
+// bodies should be included, from --debug/--debug-body or KAMUI_DEBUG.
+func resolveDebug(cmd *cobra.Command) (debug, bodies bool) {
+	debug, _ = cmd.Flags().GetBool("debug")
+	bodies, _ = cmd.Flags().GetBool("debug-body")
+	if debug || bodies {
+		return true, bodies
+	}
+
+	v := strings.TrimSpace(os.Getenv(envDebug))
+	if strings.EqualFold(v, "body") {
+		return true, true
+	}
+	on, _ := strconv.ParseBool(v)
+	return on, false
+}
+
+// resolveTransport collects the proxy and TLS settings for API and OAuth
+// requests from --proxy, --ca-cert, and --insecure-skip-verify
+func resolveTransport(cmd *cobra.Command) (api.TransportOptions, error) {
+	var opts api.TransportOptions
+
+	proxy, err := resolveProxy(cmd)
+	if err != nil {
+		return opts, err
+	}
+	opts.Proxy = proxy
+
+	caCert, _ := cmd.Flags().GetString("ca-cert")
+	if caCert == "" {
+		caCert = strings.TrimSpace(os.Getenv(envCACert))
+	}
+	if caCert != "" {
+		if opts.RootCAs, err = api.LoadCertPool(caCert); err != nil {
+			return opts, err
+		}
+	}
+
+	opts.InsecureSkipVerify, _ = cmd.Flags().GetBool("insecure-skip-verify")
+	if opts.InsecureSkipVerify {
+		fmt.Fprintln(os.Stderr, "⚠ WARNING: TLS certificate verification is disabled (--insecure-skip-verify).")
+		fmt.Fprintln(os.Stderr, "⚠ Credentials and data can be intercepted. Never use this outside a development environment.")
+	}
+	return opts, nil
+}
+
+// resolveProxy returns the proxy from --proxy or KAMUI_PROXY, or nil when
+// neither is set and the standard proxy environment variables apply. A
+// bare host:port is taken as an HTTP proxy.
+func resolveProxy(cmd *cobra.Command) (*url.URL, error) {
+	source := "--proxy"
+	v, _ := cmd.Flags().GetString("proxy")
+	if v == "" {
+		source = envProxy
+		v = strings.TrimSpace(os.Getenv(envProxy))
+	}
+	if v == "" {
+		return nil, nil
+	}
+
+	if !strings.Contains(v, "://") {
+		v = "http://" + v
+	}
+	u, err := url.Parse(v)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", source, err)
+	}
+	switch u.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("invalid %s %q: scheme must be http, https, or socks5", source, v)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("invalid %s %q: missing host", source, v)
+	}
+	return u, nil
+}
+
+// resolveDuration returns the value of a duration flag, falling back to the
+// given environment variable when the flag was not set. Zero means "use the
+// default".
+func resolveDuration(cmd *cobra.Command, flag, env string) (time.Duration, error) {
+	if f := cmd.Flags().Lookup(flag); f != nil && f.Changed {
+		d, err := cmd.Flags().GetDuration(flag)
+		if err != nil {
+			return 0, err
+		}
+		if d < 0 {
+			return 0, fmt.Errorf("--%s must not be negative", flag)
+		}
+		return d, nil
+	}
+
+	v := os.Getenv(env)
+	if v == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %w", env, v, err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("%s must not be negative", env)
+	}
+	return d, nil
+}
+
 // Execute runs the root command
 func (r *RootCommand) Execute() error {
-	return r.cmd.Execute()
+	defer func() {
+		if r.cancel != nil {
+			r.cancel()
+		}
+	}()
+	cmd, err := r.cmd.ExecuteC()
+	if r.timings != nil {
+		printTimings(os.Stderr, r.timings.Summary())
+	}
+	if err != nil {
+		printCommandError(cmd, err)
+	}
+	return err
 }
 
 // Command returns the underlying cobra command
@@ -107,18 +366,31 @@ func (r *RootCommand) SetContainer(c *di.Container) {
 	r.container = c
 }
 
-// Execute is the main entry point for the CLI
-func Execute() error {
+// Execute is the main entry point for the CLI. It returns the process
+// exit code; see ExitCode for how errors map to codes.
+func Execute() int {
 	root := NewRootCommand()
-	return root.Execute()
+	return ExitCode(root.Execute())
 }
 
-// ExitWithError prints an error message and exits with code 1
+// ExitWithError prints an error message and exits with the code ExitCode
+// picks for err, or ExitError when err is nil. The message is written as
+// JSON when the command line asked for JSON errors.
 func ExitWithError(msg string, err error) {
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", msg, err)
+	code := ExitError
+	if jsonErrors {
+		if err != nil {
+			err = fmt.Errorf("%s: %w", msg, err)
+			code = ExitCode(err)
+		} else {
+			err = errors.New(msg)
+		}
+		writeJSONError(os.Stderr, err)
+	} else if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %s: %v\n", msg, redact.Error(err))
+		code = ExitCode(err)
 	} else {
 		fmt.Fprintf(os.Stderr, "Error: %s\n", msg)
 	}
-	os.Exit(1)
+	os.Exit(code)
 }
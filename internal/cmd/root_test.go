@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+	"github.com/spf13/cobra"
+)
+
+func TestResolveDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "unset uses default", want: 0},
+		{name: "env only", env: "45s", want: 45 * time.Second},
+		{name: "flag overrides env", args: []string{"--timeout", "2m"}, env: "45s", want: 2 * time.Minute},
+		{name: "invalid env", env: "soon", wantErr: true},
+		{name: "negative flag", args: []string{"--timeout=-1s"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envTimeout, tt.env)
+
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().Duration("timeout", 0, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+
+			got, err := resolveDuration(cmd, "timeout", envTimeout)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveDuration() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("resolveDuration() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveDebug(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       []string
+		env        string
+		wantDebug  bool
+		wantBodies bool
+	}{
+		{name: "off by default"},
+		{name: "flag", args: []string{"--debug"}, wantDebug: true},
+		{name: "body flag implies debug", args: []string{"--debug-body"}, wantDebug: true, wantBodies: true},
+		{name: "env 1", env: "1", wantDebug: true},
+		{name: "env body", env: "body", wantDebug: true, wantBodies: true},
+		{name: "env garbage", env: "maybe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envDebug, tt.env)
+
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().Bool("debug", false, "")
+			cmd.Flags().Bool("debug-body", false, "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+
+			debug, bodies := resolveDebug(cmd)
+			if debug != tt.wantDebug || bodies != tt.wantBodies {
+				t.Errorf("resolveDebug() = (%v, %v), want (%v, %v)", debug, bodies, tt.wantDebug, tt.wantBodies)
+			}
+		})
+	}
+}
+
+func TestCommandDeadline(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       time.Duration
+		uploadTimeout time.Duration
+		want          time.Duration
+	}{
+		{name: "defaults use upload timeout", want: 10 * time.Minute},
+		{name: "scaled request timeout", timeout: 2 * time.Minute, want: 20 * time.Minute},
+		{name: "upload timeout floor", timeout: 5 * time.Second, uploadTimeout: 3 * time.Minute, want: 3 * time.Minute},
+		{name: "both short", timeout: time.Second, uploadTimeout: time.Second, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := commandDeadline(tt.timeout, tt.uploadTimeout); got != tt.want {
+				t.Errorf("commandDeadline(%v, %v) = %v, want %v", tt.timeout, tt.uploadTimeout, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRootCommand_DeadlineTimesOut(t *testing.T) {
+	mockProject := &MockProjectService{
+		ListProjectsWithOptionsFunc: func(ctx context.Context, opts *iface.ListProjectsOptions) (*iface.ProjectList, error) {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		},
+	}
+
+	root := NewRootCommand()
+	root.SetContainer(di.NewContainerWithAllServices(&MockAuthService{}, mockProject, nil))
+	root.Command().SetArgs([]string{"projects", "list", "--timeout", "1ms", "--upload-timeout", "1ms"})
+	root.Command().SilenceErrors = true
+	root.Command().SilenceUsage = true
+
+	err := root.Execute()
+	if !errors.Is(err, errOperationTimedOut) {
+		t.Fatalf("Execute() error = %v, want operation timed out", err)
+	}
+	if !strings.Contains(err.Error(), "operation timed out after 10ms") {
+		t.Errorf("error %q does not mention the deadline", err)
+	}
+	if got := ExitCode(err); got != ExitNetwork {
+		t.Errorf("ExitCode() = %d, want %d", got, ExitNetwork)
+	}
+}
+
+func TestResolveProxy(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		env     string
+		want    string
+		wantErr bool
+	}{
+		{name: "unset uses environment", want: ""},
+		{name: "env only", env: "http://proxy.corp:3128", want: "http://proxy.corp:3128"},
+		{name: "flag overrides env", args: []string{"--proxy", "https://flag.corp:443"}, env: "http://proxy.corp:3128", want: "https://flag.corp:443"},
+		{name: "bare host and port", args: []string{"--proxy", "proxy.corp:8080"}, want: "http://proxy.corp:8080"},
+		{name: "socks5", env: "socks5://127.0.0.1:1080", want: "socks5://127.0.0.1:1080"},
+		{name: "unsupported scheme", args: []string{"--proxy", "ftp://proxy.corp"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(envProxy, tt.env)
+
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().String("proxy", "", "")
+			if err := cmd.ParseFlags(tt.args); err != nil {
+				t.Fatalf("ParseFlags: %v", err)
+			}
+
+			got, err := resolveProxy(cmd)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveProxy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			gotStr := ""
+			if got != nil {
+				gotStr = got.String()
+			}
+			if gotStr != tt.want {
+				t.Errorf("resolveProxy() = %q, want %q", gotStr, tt.want)
+			}
+		})
+	}
+}
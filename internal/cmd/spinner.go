@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// spinnerInterval is the delay between spinner frames
+const spinnerInterval = 100 * time.Millisecond
+
+// spinner animates a single line on a terminal while a blocking call runs.
+// When the output is not a terminal it does nothing, so piped output and
+// CI logs stay free of control characters.
+type spinner struct {
+	w    io.Writer
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// startSpinner starts a spinner on stderr if stderr is a terminal
+func startSpinner() *spinner {
+	return newSpinner(os.Stderr, isStderrTTY())
+}
+
+// newSpinner starts a spinner writing to w; it is a no-op unless tty is set
+func newSpinner(w io.Writer, tty bool) *spinner {
+	s := &spinner{w: w}
+	if !tty {
+		return s
+	}
+
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.run()
+	return s
+}
+
+func (s *spinner) run() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+
+	for frame := 0; ; frame++ {
+		fmt.Fprintf(s.w, "\r%s ", spinnerFrames[frame%len(spinnerFrames)])
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Stop halts the animation and clears the spinner line. Call it before
+// printing anything else, including errors. It is safe to call more than
+// once.
+func (s *spinner) Stop() {
+	if s.stop == nil {
+		return
+	}
+	s.once.Do(func() {
+		close(s.stop)
+		<-s.done
+		fmt.Fprint(s.w, "\r\033[K")
+	})
+}
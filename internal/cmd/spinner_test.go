@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// lockedBuffer is a bytes.Buffer safe for the spinner goroutine
+type lockedBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestSpinner_NoTTYIsNoop(t *testing.T) {
+	var buf lockedBuffer
+	s := newSpinner(&buf, false)
+	s.Stop()
+	s.Stop()
+
+	if buf.String() != "" {
+		t.Errorf("non-TTY spinner wrote %q, want nothing", buf.String())
+	}
+}
+
+func TestSpinner_TTYClearsOnStop(t *testing.T) {
+	var buf lockedBuffer
+	s := newSpinner(&buf, true)
+	s.Stop()
+	s.Stop()
+
+	out := buf.String()
+	if !strings.HasPrefix(out, "\r"+spinnerFrames[0]) {
+		t.Errorf("output = %q, want it to start with the first frame", out)
+	}
+	if !strings.HasSuffix(out, "\r\033[K") {
+		t.Errorf("output = %q, want the line cleared on stop", out)
+	}
+	if strings.Count(out, "\033[K") != 1 {
+		t.Errorf("output = %q, want exactly one clear after repeated Stop", out)
+	}
+}
@@ -1,18 +1,60 @@
 package cmd
 
 import (
+	"encoding/csv"
 	"fmt"
 	"io"
 	"strings"
 
 	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
 )
 
+// defaultTableWidth is the width tables are fitted to when stdout is a
+// terminal whose width is unknown
+const defaultTableWidth = 80
+
+// minTruncatedWidth is the narrowest an ID or URL column is cut to, so a
+// truncated value is still recognizable
+const minTruncatedWidth = 12
+
+// tableWidth is the number of terminal columns printTable fits tables into
+// by truncating ID and URL cells; 0 means no limit. It is set from --wide
+// and the terminal size before each command runs.
+var tableWidth int
+
+// stdoutTerminal reports whether stdout is a terminal and, if so, its width
+// in columns (0 when unknown); tests replace it
+var stdoutTerminal = func() (isTTY bool, width int) {
+	if !isStdoutTTY() {
+		return false, 0
+	}
+	return true, stdoutWidth()
+}
+
+// resolveTableWidth returns the width tables are fitted to: no limit with
+// --wide or when stdout is piped or redirected, so scripts get whole IDs,
+// else the terminal width, or defaultTableWidth when that is unknown
+func resolveTableWidth(cmd *cobra.Command) int {
+	if wide, _ := cmd.Flags().GetBool("wide"); wide {
+		return 0
+	}
+	isTTY, width := stdoutTerminal()
+	switch {
+	case !isTTY:
+		return 0
+	case width > 0:
+		return width
+	}
+	return defaultTableWidth
+}
+
 // printTable writes a column-aligned table using display width, so cells
-// containing east-asian / full-width characters align correctly.
+// containing east-asian / full-width characters or color codes align correctly.
 //
 // indent is prepended to every line. Header underline (---) is generated from
-// header text width. Columns are separated by two spaces.
+// header text width. Columns are separated by two spaces. Tables wider than
+// tableWidth have their ID and URL cells truncated to fit.
 func printTable(w io.Writer, indent string, header []string, rows [][]string) {
 	if len(header) == 0 {
 		return
@@ -24,19 +66,23 @@ func printTable(w io.Writer, indent string, header []string, rows [][]string) {
 	}
 	for _, row := range rows {
 		for i := 0; i < cols && i < len(row); i++ {
-			if rw := runewidth.StringWidth(row[i]); rw > widths[i] {
+			if rw := displayWidth(row[i]); rw > widths[i] {
 				widths[i] = rw
 			}
 		}
 	}
+	fitWidths(indent, header, widths, tableWidth)
 
 	write := func(cells []string) {
 		var sb strings.Builder
 		sb.WriteString(indent)
 		for i, c := range cells {
+			if displayWidth(c) > widths[i] {
+				c = truncateString(c, widths[i])
+			}
 			sb.WriteString(c)
 			if i < cols-1 {
-				if pad := widths[i] - runewidth.StringWidth(c); pad > 0 {
+				if pad := widths[i] - displayWidth(c); pad > 0 {
 					sb.WriteString(strings.Repeat(" ", pad))
 				}
 				sb.WriteString("  ")
@@ -64,3 +110,136 @@ func printTable(w io.Writer, indent string, header []string, rows [][]string) {
 		write(padded)
 	}
 }
+
+// truncatableColumn reports whether a column may be narrowed to fit the
+// table width. Only IDs and URLs are: they are the long values, and the
+// other columns are short enough to keep whole.
+func truncatableColumn(header string) bool {
+	h := strings.ToUpper(header)
+	return h == "ID" || strings.HasSuffix(h, "_ID") || strings.HasSuffix(h, " ID") || strings.Contains(h, "URL")
+}
+
+// fitWidths narrows the widest truncatable column one cell at a time until
+// the table fits in limit columns or none can shrink past minTruncatedWidth
+// or its header. A limit of 0 leaves widths as they are.
+func fitWidths(indent string, header []string, widths []int, limit int) {
+	if limit <= 0 {
+		return
+	}
+	total := runewidth.StringWidth(indent) + 2*(len(widths)-1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > limit {
+		widest := -1
+		for i, h := range header {
+			floor := max(minTruncatedWidth, runewidth.StringWidth(h))
+			if truncatableColumn(h) && widths[i] > floor && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// displayWidth returns the number of terminal columns s occupies
+func displayWidth(s string) int {
+	return runewidth.StringWidth(stripANSI(s))
+}
+
+// tableColumn is a column a list command can show, selectable by name
+// with --fields
+type tableColumn[T any] struct {
+	name  string
+	value func(T) string
+}
+
+// selectColumns resolves a comma-separated --fields value against columns,
+// case-insensitively and in the order given. An empty value selects the
+// columns named in defaults.
+func selectColumns[T any](fields string, columns []tableColumn[T], defaults []string) ([]tableColumn[T], error) {
+	names := defaults
+	if strings.TrimSpace(fields) != "" {
+		names = strings.Split(fields, ",")
+	}
+
+	selected := make([]tableColumn[T], 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, c := range columns {
+			if strings.EqualFold(c.name, name) {
+				selected = append(selected, c)
+				found = true
+				break
+			}
+		}
+		if !found {
+			valid := make([]string, len(columns))
+			for i, c := range columns {
+				valid[i] = c.name
+			}
+			return nil, fmt.Errorf("unknown field %q in --fields (valid fields: %s)", name, strings.Join(valid, ", "))
+		}
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("--fields must name at least one field")
+	}
+	return selected, nil
+}
+
+// printColumns writes items as a table of the given columns
+func printColumns[T any](w io.Writer, indent string, columns []tableColumn[T], items []T) {
+	header := make([]string, len(columns))
+	for i, c := range columns {
+		header[i] = c.name
+	}
+	rows := make([][]string, 0, len(items))
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, c := range columns {
+			row[i] = c.value(item)
+		}
+		rows = append(rows, row)
+	}
+	printTable(w, indent, header, rows)
+}
+
+// delimitedFormats maps the --output values that print list commands as
+// delimited text to their field separator
+var delimitedFormats = map[string]rune{
+	"csv": ',',
+	"tsv": '\t',
+}
+
+// writeDelimited writes items as RFC 4180 records of the given columns with
+// a header row, quoting fields that contain the separator, quotes or newlines
+func writeDelimited[T any](w io.Writer, comma rune, columns []tableColumn[T], items []T) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = comma
+
+	record := make([]string, len(columns))
+	for i, c := range columns {
+		record[i] = c.name
+	}
+	if err := cw.Write(record); err != nil {
+		return err
+	}
+	for _, item := range items {
+		for i, c := range columns {
+			record[i] = c.value(item)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,214 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/kamui-project/kamui-cli/internal/di"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestSelectColumns(t *testing.T) {
+	columns := []tableColumn[string]{
+		{"ID", func(s string) string { return "id-" + s }},
+		{"NAME", func(s string) string { return s }},
+		{"REGION", func(s string) string { return "tokyo" }},
+	}
+	defaults := []string{"ID", "NAME"}
+
+	tests := []struct {
+		name       string
+		fields     string
+		wantHeader string
+		wantErrMsg string
+	}{
+		{name: "defaults", wantHeader: "ID      NAME"},
+		{name: "selection and order", fields: "region,ID", wantHeader: "REGION  ID"},
+		{name: "spaces and empty entries", fields: " name , ,id", wantHeader: "NAME  ID"},
+		{name: "unknown field", fields: "ID,REGON", wantErrMsg: `unknown field "REGON" in --fields (valid fields: ID, NAME, REGION)`},
+		{name: "only separators", fields: ",", wantErrMsg: "at least one field"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := selectColumns(tt.fields, columns, defaults)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("selectColumns() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectColumns() error = %v", err)
+			}
+
+			var buf bytes.Buffer
+			printColumns(&buf, "", selected, []string{"web"})
+			header := strings.TrimRight(strings.SplitN(buf.String(), "\n", 2)[0], " ")
+			if header != tt.wantHeader {
+				t.Errorf("header = %q, want %q", header, tt.wantHeader)
+			}
+		})
+	}
+}
+
+func TestWriteDelimited(t *testing.T) {
+	columns := []tableColumn[[2]string]{
+		{"NAME", func(r [2]string) string { return r[0] }},
+		{"DESCRIPTION", func(r [2]string) string { return r[1] }},
+	}
+	items := [][2]string{
+		{"web", "plain"},
+		{"api", "reads, writes"},
+		{"worker", "line one\nline two"},
+		{"cron", `says "hi"`},
+	}
+
+	tests := []struct {
+		name  string
+		comma rune
+		want  string
+	}{
+		{
+			name:  "csv",
+			comma: ',',
+			want: "NAME,DESCRIPTION\n" +
+				"web,plain\n" +
+				"api,\"reads, writes\"\n" +
+				"worker,\"line one\nline two\"\n" +
+				"cron,\"says \"\"hi\"\"\"\n",
+		},
+		{
+			name:  "tsv",
+			comma: '\t',
+			want: "NAME\tDESCRIPTION\n" +
+				"web\tplain\n" +
+				"api\treads, writes\n" +
+				"worker\t\"line one\nline two\"\n" +
+				"cron\t\"says \"\"hi\"\"\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := writeDelimited(&buf, tt.comma, columns, items); err != nil {
+				t.Fatalf("writeDelimited() error = %v", err)
+			}
+			if buf.String() != tt.want {
+				t.Errorf("writeDelimited() =\n%s\nwant\n%s", buf.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestPrintTable_Truncation(t *testing.T) {
+	id := "app-0123456789abcdef"
+	url := "https://web.example.kamui.app/"
+
+	tests := []struct {
+		name    string
+		width   int
+		wantRow string
+	}{
+		{name: "no limit", width: 0, wantRow: "web   " + id + "  " + url},
+		{name: "exactly fits", width: 58, wantRow: "web   " + id + "  " + url},
+		{name: "one column over", width: 57, wantRow: "web   " + id + "  https://web.example.kamui...."},
+		{name: "widest column first", width: 40, wantRow: "web   app-012345678...  https://web.e..."},
+		{name: "never below the minimum", width: 10, wantRow: "web   app-01234...  https://w..."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := tableWidth
+			tableWidth = tt.width
+			defer func() { tableWidth = old }()
+
+			var buf bytes.Buffer
+			printTable(&buf, "", []string{"NAME", "ID", "URL"}, [][]string{{"web", id, url}})
+
+			lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+			if len(lines) != 3 {
+				t.Fatalf("expected header, separator and row, got: %q", buf.String())
+			}
+			if lines[2] != tt.wantRow {
+				t.Errorf("row = %q, want %q", lines[2], tt.wantRow)
+			}
+		})
+	}
+}
+
+func TestWideFlag(t *testing.T) {
+	longID := "proj-" + strings.Repeat("0123456789", 9)
+
+	tests := []struct {
+		name     string
+		tty      bool
+		wide     bool
+		wantFull bool
+	}{
+		// The terminal size cannot be read in tests, so tables fit the
+		// default width
+		{name: "terminal", tty: true},
+		{name: "terminal with --wide", tty: true, wide: true, wantFull: true},
+		{name: "piped", wantFull: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockProject := &MockProjectService{
+				ListProjectsWithOptionsFunc: func(ctx context.Context, opts *iface.ListProjectsOptions) (*iface.ProjectList, error) {
+					return &iface.ProjectList{Projects: []iface.Project{{ID: longID, Name: "web", PlanType: "pro", Region: "tokyo"}}}, nil
+				},
+			}
+
+			oldTerminal := stdoutTerminal
+			stdoutTerminal = func() (bool, int) { return tt.tty, 0 }
+			defer func() {
+				stdoutTerminal = oldTerminal
+				tableWidth = 0
+			}()
+
+			root := NewRootCommand()
+			root.SetContainer(di.NewContainerWithServices(&MockAuthService{}, mockProject))
+
+			for _, format := range []string{"text", "json"} {
+				oldStdout := os.Stdout
+				r, w, _ := os.Pipe()
+				os.Stdout = w
+
+				args := []string{"projects", "list", "-o", format}
+				if tt.wide {
+					args = append(args, "--wide")
+				}
+				root.Command().SetArgs(args)
+				err := root.Command().Execute()
+
+				w.Close()
+				os.Stdout = oldStdout
+				var buf bytes.Buffer
+				io.Copy(&buf, r)
+
+				if err != nil {
+					t.Fatalf("Execute() error = %v", err)
+				}
+				// JSON is never truncated
+				full := tt.wantFull || format == "json"
+				if got := strings.Contains(buf.String(), longID); got != full {
+					t.Errorf("-o %s: full ID shown = %v, want %v; output: %s", format, got, full, buf.String())
+				}
+				if format == "text" && !full {
+					for _, line := range strings.Split(strings.TrimRight(buf.String(), "\n"), "\n") {
+						if displayWidth(line) > defaultTableWidth {
+							t.Errorf("line wider than %d columns: %q", defaultTableWidth, line)
+						}
+					}
+				}
+			}
+		})
+	}
+}
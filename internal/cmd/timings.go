@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+)
+
+// printTimings writes the --timings summary: one row per endpoint, slowest
+// in total first, and a final row for all requests
+func printTimings(w io.Writer, summary []api.EndpointTiming) {
+	if len(summary) == 0 {
+		fmt.Fprintln(w, "\nRequest timings: no API requests were made")
+		return
+	}
+
+	var calls int
+	var total time.Duration
+	rows := make([][]string, 0, len(summary)+1)
+	for _, e := range summary {
+		calls += e.Calls
+		total += e.Total
+		rows = append(rows, []string{
+			e.Endpoint,
+			strconv.Itoa(e.Calls),
+			e.Total.Round(time.Millisecond).String(),
+			(e.Total / time.Duration(e.Calls)).Round(time.Millisecond).String(),
+		})
+	}
+	rows = append(rows, []string{"All requests", strconv.Itoa(calls), total.Round(time.Millisecond).String(), ""})
+
+	fmt.Fprintln(w, "\nRequest timings:")
+	printTable(w, "  ", []string{"ENDPOINT", "CALLS", "TOTAL", "AVERAGE"}, rows)
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+)
+
+func TestPrintTimings(t *testing.T) {
+	var out bytes.Buffer
+	printTimings(&out, []api.EndpointTiming{
+		{Endpoint: "GET /api/apps/{id}", Calls: 12, Total: 3400 * time.Millisecond},
+		{Endpoint: "GET /api/projects", Calls: 1, Total: 250 * time.Millisecond},
+	})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	want := [][]string{
+		{"Request timings:"},
+		{"ENDPOINT", "CALLS", "TOTAL", "AVERAGE"},
+		{"--------", "-----", "-----", "-------"},
+		{"GET", "/api/apps/{id}", "12", "3.4s", "283ms"},
+		{"GET", "/api/projects", "1", "250ms", "250ms"},
+		{"All", "requests", "13", "3.65s"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(want), out.String())
+	}
+	for i, fields := range want {
+		if got := strings.Fields(lines[i]); strings.Join(got, " ") != strings.Join(fields, " ") {
+			t.Errorf("line %d = %q, want fields %v", i, lines[i], fields)
+		}
+	}
+}
+
+func TestPrintTimings_NoRequests(t *testing.T) {
+	var out bytes.Buffer
+	printTimings(&out, nil)
+	if !strings.Contains(out.String(), "no API requests were made") {
+		t.Errorf("output = %q", out.String())
+	}
+}
@@ -263,25 +263,25 @@ func (d *TokensDeleteCommand) Run(cmd *cobra.Command, args []string) error {
 		// Include OAuth session tokens so the user gets an explicit warning
 		// if they're about to delete their own active CLI session.
 		name, isOAuth := lookupPATName(cmd.Context(), tokens, id)
-		fmt.Println("About to delete PAT:")
-		fmt.Printf("  ID:   %s\n", id)
+		fmt.Fprintln(os.Stderr, "About to delete PAT:")
+		fmt.Fprintf(os.Stderr, "  ID:   %s\n", id)
 		if name != "" {
-			fmt.Printf("  Name: %s\n", name)
+			fmt.Fprintf(os.Stderr, "  Name: %s\n", name)
 		}
 		if isOAuth {
-			fmt.Println("  ⚠️  This is an internal OAuth session token. Deleting it will")
-			fmt.Println("      log out the CLI process that owns it. Run 'kamui login' to recover.")
+			fmt.Fprintln(os.Stderr, "  ⚠️  This is an internal OAuth session token. Deleting it will")
+			fmt.Fprintln(os.Stderr, "      log out the CLI process that owns it. Run 'kamui login' to recover.")
 		}
 
 		var confirm bool
-		if err := survey.AskOne(&survey.Confirm{
+		if err := ask(&survey.Confirm{
 			Message: "Confirm deletion?",
 			Default: false,
 		}, &confirm); err != nil {
 			return err
 		}
 		if !confirm {
-			fmt.Println("Cancelled.")
+			fmt.Fprintln(os.Stderr, "Cancelled.")
 			return nil
 		}
 	}
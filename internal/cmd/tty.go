@@ -4,6 +4,7 @@ import (
 	"os"
 
 	"github.com/mattn/go-isatty"
+	"golang.org/x/term"
 )
 
 // isStdinTTY reports whether stdin is connected to an interactive terminal.
@@ -20,3 +21,21 @@ func isStdoutTTY() bool {
 	fd := os.Stdout.Fd()
 	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
 }
+
+// isStderrTTY reports whether stderr is connected to an interactive terminal.
+// Used to decide whether in-place progress rendering (carriage returns) is
+// appropriate.
+func isStderrTTY() bool {
+	fd := os.Stderr.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
+
+// stdoutWidth returns the width in columns of the terminal stdout is
+// connected to, or 0 when it is not a terminal or the size is unknown
+func stdoutWidth() int {
+	width, _, err := term.GetSize(int(os.Stdout.Fd()))
+	if err != nil {
+		return 0
+	}
+	return width
+}
@@ -23,6 +23,23 @@ const (
 
 	// ConfigFileName is the name of the config file
 	ConfigFileName = "config.json"
+
+	// CacheFileName is the name of the project list cache, next to the
+	// config file
+	CacheFileName = "cache.json"
+
+	// DefaultProjectCacheTTL is how long a cached project list stays fresh
+	// when project_cache is "on"
+	DefaultProjectCacheTTL = 30 * time.Second
+)
+
+var (
+	// ErrNoAccessToken is returned by GetAccessToken when no token is stored
+	ErrNoAccessToken = errors.New("not logged in")
+
+	// ErrAccessTokenExpired is returned by GetAccessToken when the stored
+	// token has expired
+	ErrAccessTokenExpired = errors.New("token expired")
 )
 
 // Config represents the CLI configuration stored on disk
@@ -36,6 +53,9 @@ type Config struct {
 	// ExpiresAt is the expiration time of the access token
 	ExpiresAt time.Time `json:"expires_at,omitempty"`
 
+	// Scope is the OAuth scope granted to the access token
+	Scope string `json:"scope,omitempty"`
+
 	// APIURL is the base URL of the Kamui API
 	APIURL string `json:"api_url,omitempty"`
 
@@ -44,11 +64,64 @@ type Config struct {
 
 	// ClientSecret is the OAuth client secret from dynamic registration
 	ClientSecret string `json:"client_secret,omitempty"`
+
+	// ProjectCache enables the on-disk project list cache: "on" for the
+	// default TTL, a duration such as "1m", or empty/"off" to disable it
+	ProjectCache string `json:"project_cache,omitempty"`
+
+	// DefaultProject is the project name or ID used when --project is
+	// omitted. It is resolved when used, so it may name a project that
+	// has since been renamed or deleted.
+	DefaultProject string `json:"default_project,omitempty"`
+
+	// Org is the ID of the active organization; projects and apps are
+	// listed within it. Empty uses the account's default organization.
+	Org string `json:"org,omitempty"`
+}
+
+// ProjectCacheTTL returns how long a cached project list stays fresh, or 0
+// when the cache is disabled
+func (c *Config) ProjectCacheTTL() time.Duration {
+	switch c.ProjectCache {
+	case "", "off":
+		return 0
+	case "on":
+		return DefaultProjectCacheTTL
+	}
+	ttl, err := time.ParseDuration(c.ProjectCache)
+	if err != nil || ttl < 0 {
+		return 0
+	}
+	return ttl
 }
 
 // Manager handles configuration file operations
 type Manager struct {
 	configPath string
+
+	// apiURLOverride, if set, replaces the stored api_url for this process
+	apiURLOverride string
+
+	// orgOverride, if set, replaces the stored org for this process
+	orgOverride string
+
+	// allowInsecureHTTP accepts plain http API URLs for hosts other than
+	// the local machine
+	allowInsecureHTTP bool
+
+	// mu guards cached
+	mu sync.Mutex
+
+	// cached is the config as last read from disk, so repeated loads
+	// during a command skip reading and parsing an unchanged file
+	cached *cachedConfig
+}
+
+// cachedConfig is a loaded config and the state of the file it was read from
+type cachedConfig struct {
+	config  Config
+	modTime time.Time
+	size    int64
 }
 
 // NewManager creates a new configuration manager
@@ -70,9 +143,17 @@ func NewManagerWithPath(configPath string) *Manager {
 
 // Load reads the configuration from disk
 // Returns an empty config if the file doesn't exist
+//
+// The file is parsed again only when its modification time or size has
+// changed since the last load, so a change made by another process, such
+// as a token refresh, is always seen. Each call returns a fresh copy.
 func (m *Manager) Load() (*Config, error) {
-	data, err := os.ReadFile(m.configPath)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	info, err := os.Stat(m.configPath)
 	if err != nil {
+		m.cached = nil
 		if errors.Is(err, os.ErrNotExist) {
 			// Return default config if file doesn't exist
 			return &Config{
@@ -81,6 +162,15 @@ func (m *Manager) Load() (*Config, error) {
 		}
 		return nil, err
 	}
+	if c := m.cached; c != nil && c.modTime.Equal(info.ModTime()) && c.size == info.Size() {
+		config := c.config
+		return &config, nil
+	}
+
+	data, err := os.ReadFile(m.configPath)
+	if err != nil {
+		return nil, err
+	}
 
 	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
@@ -92,6 +182,7 @@ func (m *Manager) Load() (*Config, error) {
 		config.APIURL = DefaultAPIURL
 	}
 
+	m.cached = &cachedConfig{config: config, modTime: info.ModTime(), size: info.Size()}
 	return &config, nil
 }
 
@@ -108,6 +199,10 @@ func (m *Manager) Save(config *Config) error {
 		return err
 	}
 
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cached = nil
+
 	// Write with restricted permissions (owner read/write only)
 	return os.WriteFile(m.configPath, data, 0600)
 }
@@ -123,12 +218,17 @@ func (m *Manager) Clear() error {
 	config.AccessToken = ""
 	config.RefreshToken = ""
 	config.ExpiresAt = time.Time{}
+	config.Scope = ""
+
+	// The cached project list belongs to the account being logged out
+	_ = os.Remove(m.CachePath())
 
 	return m.Save(config)
 }
 
-// Delete removes the config file entirely
+// Delete removes the config file and the project list cache entirely
 func (m *Manager) Delete() error {
+	_ = os.Remove(m.CachePath())
 	err := os.Remove(m.configPath)
 	if errors.Is(err, os.ErrNotExist) {
 		return nil
@@ -164,12 +264,12 @@ func (m *Manager) GetAccessToken() (string, error) {
 	}
 
 	if config.AccessToken == "" {
-		return "", errors.New("not logged in")
+		return "", ErrNoAccessToken
 	}
 
 	// Check if token is expired
 	if !config.ExpiresAt.IsZero() && time.Now().After(config.ExpiresAt) {
-		return "", errors.New("token expired")
+		return "", ErrAccessTokenExpired
 	}
 
 	return config.AccessToken, nil
@@ -183,42 +283,101 @@ var apiURLWarnOnce sync.Once
 // validateAPIURL enforces the constraints required to keep apiURL safe
 // for use in subprocess argv (registerClaudeCode used to embed it via
 // `--header "Authorization: Bearer ..."`) and for header construction:
-//   - https only — http/file/javascript schemes are rejected to prevent
-//     downgrade or scheme-confusion attacks
+//   - https only — file/javascript schemes are rejected to prevent
+//     scheme-confusion attacks, and plain http, which would send the
+//     access token in cleartext, is only accepted for the local machine
+//     unless allowHTTP is set
 //   - non-empty Host
 //   - no leading "-" — defense in depth against argv injection if the
 //     URL is ever passed to a child process expecting flag-style args
 //   - no embedded userinfo — credentials in the URL would round-trip to
 //     anywhere we use the URL, including logs
-func validateAPIURL(s string) error {
+func validateAPIURL(s string, allowHTTP bool) error {
+	_, err := normalizeAPIURL(s, allowHTTP)
+	return err
+}
+
+// normalizeAPIURL validates s (see validateAPIURL) and returns it in the
+// form api.Client expects: surrounding whitespace and trailing slashes are
+// removed so request paths can be appended directly. Query strings and
+// fragments are rejected because appending a path after them would
+// silently change the request.
+func normalizeAPIURL(s string, allowHTTP bool) (string, error) {
+	s = strings.TrimSpace(s)
 	if s == "" {
-		return errors.New("api url is empty")
+		return "", errors.New("api url is empty")
 	}
 	if strings.HasPrefix(s, "-") {
-		return fmt.Errorf("api url must not start with '-': %q", s)
+		return "", fmt.Errorf("api url must not start with '-': %q", s)
+	}
+	// Without "://", url.Parse would read "localhost:8080" as scheme
+	// "localhost"
+	if !strings.Contains(s, "://") {
+		return "", fmt.Errorf("api url %q is missing a scheme (did you mean https://%s?)", s, s)
 	}
 	u, err := url.Parse(s)
 	if err != nil {
-		return fmt.Errorf("api url is not a valid URL: %w", err)
+		return "", fmt.Errorf("api url is not a valid URL: %w", err)
 	}
-	if u.Scheme != "https" {
-		return fmt.Errorf("api url must use https scheme (got %q)", u.Scheme)
+	switch {
+	case u.Scheme == "https":
+	case u.Scheme == "http" && (allowHTTP || isLoopbackHost(u.Hostname())):
+	case u.Scheme == "http":
+		return "", fmt.Errorf("api url %q uses plain http, which would send your access token unencrypted; use https, or pass --insecure-allow-http to proceed anyway", s)
+	default:
+		return "", fmt.Errorf("api url must use https scheme (got %q)", u.Scheme)
 	}
 	if u.Host == "" {
-		return errors.New("api url must have a host")
+		return "", errors.New("api url must have a host")
 	}
 	if u.User != nil {
-		return errors.New("api url must not embed userinfo")
+		return "", errors.New("api url must not embed userinfo")
+	}
+	if u.RawQuery != "" || u.Fragment != "" || strings.ContainsAny(s, "?#") {
+		return "", errors.New("api url must not contain a query or fragment")
 	}
+	return strings.TrimRight(s, "/"), nil
+}
+
+// isLoopbackHost reports whether host names the local machine, where plain
+// http never leaves it
+func isLoopbackHost(host string) bool {
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	}
+	return false
+}
+
+// SetAllowInsecureHTTP makes the API URL checks of this Manager accept plain
+// http for any host, as --insecure-allow-http does
+func (m *Manager) SetAllowInsecureHTTP(allow bool) {
+	m.allowInsecureHTTP = allow
+}
+
+// SetAPIURLOverride makes GetAPIURL return apiURL instead of the stored
+// api_url for the lifetime of this Manager. The config file is not
+// modified. apiURL is validated and normalized like a stored value.
+func (m *Manager) SetAPIURLOverride(apiURL string) error {
+	normalized, err := normalizeAPIURL(apiURL, m.allowInsecureHTTP)
+	if err != nil {
+		return err
+	}
+	m.apiURLOverride = normalized
 	return nil
 }
 
-// GetAPIURL returns the configured API URL. A stored value that fails
+// GetAPIURL returns the API URL override if one is set, otherwise the
+// configured API URL. A stored value that fails
 // validation falls back to DefaultAPIURL with a one-shot stderr
 // warning, which preserves CLI behavior on the happy path while
 // closing the SSRF / argv-injection vector when the on-disk config
 // has been tampered with.
 func (m *Manager) GetAPIURL() (string, error) {
+	if m.apiURLOverride != "" {
+		return m.apiURLOverride, nil
+	}
+
 	config, err := m.Load()
 	if err != nil {
 		return "", err
@@ -228,14 +387,34 @@ func (m *Manager) GetAPIURL() (string, error) {
 		return DefaultAPIURL, nil
 	}
 
-	if err := validateAPIURL(config.APIURL); err != nil {
+	apiURL, err := normalizeAPIURL(config.APIURL, m.allowInsecureHTTP)
+	if err != nil {
 		apiURLWarnOnce.Do(func() {
 			fmt.Fprintf(os.Stderr, "⚠ ignoring invalid api_url in config (%v); using default %s\n", err, DefaultAPIURL)
 		})
 		return DefaultAPIURL, nil
 	}
 
-	return config.APIURL, nil
+	return apiURL, nil
+}
+
+// SetOrgOverride makes GetOrg return org instead of the stored org for
+// the lifetime of this Manager. The config file is not modified.
+func (m *Manager) SetOrgOverride(org string) {
+	m.orgOverride = strings.TrimSpace(org)
+}
+
+// GetOrg returns the org override if one is set, otherwise the configured
+// org. An empty result means the account's default organization.
+func (m *Manager) GetOrg() (string, error) {
+	if m.orgOverride != "" {
+		return m.orgOverride, nil
+	}
+	config, err := m.Load()
+	if err != nil {
+		return "", err
+	}
+	return config.Org, nil
 }
 
 // GetClientCredentials returns the stored OAuth client credentials
@@ -272,6 +451,8 @@ func (m *Manager) SaveTokens(accessToken, refreshToken string, expiresIn int) er
 	config.AccessToken = accessToken
 	config.RefreshToken = refreshToken
 
+	// A token without a lifetime must not inherit a previous session's expiry
+	config.ExpiresAt = time.Time{}
 	if expiresIn > 0 {
 		config.ExpiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
 	}
@@ -279,7 +460,24 @@ func (m *Manager) SaveTokens(accessToken, refreshToken string, expiresIn int) er
 	return m.Save(config)
 }
 
+// SaveScope records the OAuth scope granted to the stored tokens
+func (m *Manager) SaveScope(scope string) error {
+	config, err := m.Load()
+	if err != nil {
+		return err
+	}
+
+	config.Scope = scope
+
+	return m.Save(config)
+}
+
 // ConfigPath returns the path to the config file
 func (m *Manager) ConfigPath() string {
 	return m.configPath
 }
+
+// CachePath returns the path to the project list cache
+func (m *Manager) CachePath() string {
+	return filepath.Join(filepath.Dir(m.configPath), CacheFileName)
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// redacted replaces secret values in Redacted output
+const redacted = "[REDACTED]"
+
+// setting describes a user-editable configuration key
+type setting struct {
+	description string
+	get         func(c *Config) string
+	set         func(m *Manager, c *Config, value string) error
+}
+
+// settings lists the keys accepted by `kamui config get/set`
+var settings = map[string]setting{
+	"api_url": {
+		description: "Base URL of the Kamui API (https, or http for localhost)",
+		get:         func(c *Config) string { return c.APIURL },
+		set: func(m *Manager, c *Config, value string) error {
+			apiURL, err := normalizeAPIURL(value, m.allowInsecureHTTP)
+			if err != nil {
+				return err
+			}
+			c.APIURL = apiURL
+			return nil
+		},
+	},
+	"default_project": {
+		description: "Project name or ID used when --project is omitted; empty to unset",
+		get:         func(c *Config) string { return c.DefaultProject },
+		set: func(_ *Manager, c *Config, value string) error {
+			c.DefaultProject = strings.TrimSpace(value)
+			return nil
+		},
+	},
+	"org": {
+		description: "ID of the organization whose projects and apps are used; empty for your default",
+		get:         func(c *Config) string { return c.Org },
+		set: func(_ *Manager, c *Config, value string) error {
+			c.Org = strings.TrimSpace(value)
+			return nil
+		},
+	},
+	"project_cache": {
+		description: "Cache the project list on disk: on (30s), off, or a duration such as 1m",
+		get:         func(c *Config) string { return c.ProjectCache },
+		set: func(_ *Manager, c *Config, value string) error {
+			switch value {
+			case "on", "off":
+			default:
+				ttl, err := time.ParseDuration(value)
+				if err != nil || ttl < 0 {
+					return fmt.Errorf("must be on, off, or a duration such as 1m")
+				}
+			}
+			c.ProjectCache = value
+			return nil
+		},
+	},
+}
+
+// SettingKeys returns the configuration keys that can be read and written,
+// sorted alphabetically
+func SettingKeys() []string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// SettingDescription returns a one-line description of a configuration key
+func SettingDescription(key string) string {
+	return settings[key].description
+}
+
+func lookupSetting(key string) (setting, error) {
+	s, ok := settings[key]
+	if !ok {
+		return setting{}, fmt.Errorf("unknown config key %q (valid keys: %s)", key, strings.Join(SettingKeys(), ", "))
+	}
+	return s, nil
+}
+
+// GetSetting returns the value of a configuration key
+func (m *Manager) GetSetting(key string) (string, error) {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return "", err
+	}
+	config, err := m.Load()
+	if err != nil {
+		return "", err
+	}
+	return s.get(config), nil
+}
+
+// SetSetting validates and persists a configuration key
+func (m *Manager) SetSetting(key, value string) error {
+	s, err := lookupSetting(key)
+	if err != nil {
+		return err
+	}
+	config, err := m.Load()
+	if err != nil {
+		return err
+	}
+	if err := s.set(m, config, value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+	return m.Save(config)
+}
+
+// Redacted returns a copy of the config with tokens and the client secret
+// replaced by a placeholder, suitable for display
+func (c *Config) Redacted() *Config {
+	out := *c
+	for _, field := range []*string{&out.AccessToken, &out.RefreshToken, &out.ClientSecret} {
+		if *field != "" {
+			*field = redacted
+		}
+	}
+	return &out
+}
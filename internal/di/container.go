@@ -3,6 +3,9 @@
 package di
 
 import (
+	"fmt"
+	"time"
+
 	"github.com/kamui-project/kamui-cli/internal/config"
 	"github.com/kamui-project/kamui-cli/internal/service"
 	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
@@ -11,27 +14,45 @@ import (
 // Container holds all service dependencies for the CLI.
 // Services are accessed via interfaces to enable mocking in tests.
 type Container struct {
-	configManager  *config.Manager
-	authService    iface.AuthService
-	projectService iface.ProjectService
-	appService     iface.AppService
-	tokensService  iface.TokensService
+	configManager   *config.Manager
+	authService     iface.AuthService
+	projectService  iface.ProjectService
+	appService      iface.AppService
+	tokensService   iface.TokensService
+	databaseService iface.DatabaseService
+	orgService      iface.OrgService
 }
 
-// NewContainer creates a new dependency container with default implementations
-func NewContainer() (*Container, error) {
+// NewContainer creates a new dependency container with default implementations.
+// httpOptions controls the API client timeouts; the zero value uses the defaults.
+// A non-empty apiURL overrides the configured API URL for every service, and
+// a non-empty org the configured organization.
+// noCache bypasses the on-disk project list cache for this invocation.
+func NewContainer(httpOptions service.HTTPOptions, apiURL, org string, noCache bool) (*Container, error) {
 	configManager, err := config.NewManager()
 	if err != nil {
 		return nil, err
 	}
+	configManager.SetAllowInsecureHTTP(httpOptions.AllowInsecureHTTP)
+	if apiURL != "" {
+		if err := configManager.SetAPIURLOverride(apiURL); err != nil {
+			return nil, fmt.Errorf("invalid API URL override: %w", err)
+		}
+	}
+	if org != "" {
+		configManager.SetOrgOverride(org)
+	}
 
-	authService := service.NewAuthService(configManager)
+	authService := service.NewAuthService(configManager, httpOptions)
+	cache := projectCache(configManager, noCache)
 	return &Container{
-		configManager:  configManager,
-		authService:    authService,
-		projectService: service.NewProjectService(configManager, authService),
-		appService:     service.NewAppService(configManager, authService),
-		tokensService:  service.NewTokensService(configManager, authService),
+		configManager:   configManager,
+		authService:     authService,
+		projectService:  service.NewDiskCachedProjectService(service.NewProjectService(configManager, authService, httpOptions), cache),
+		appService:      service.NewCacheInvalidatingAppService(service.NewAppService(configManager, authService, httpOptions), cache),
+		tokensService:   service.NewTokensService(configManager, authService, httpOptions),
+		databaseService: service.NewDatabaseService(configManager, authService, httpOptions),
+		orgService:      service.NewOrgService(configManager, authService, httpOptions),
 	}, nil
 }
 
@@ -43,7 +64,7 @@ func NewContainerWithServices(
 ) *Container {
 	return &Container{
 		authService:    authService,
-		projectService: projectService,
+		projectService: cacheProjects(projectService),
 	}
 }
 
@@ -56,11 +77,91 @@ func NewContainerWithAllServices(
 ) *Container {
 	return &Container{
 		authService:    authService,
-		projectService: projectService,
+		projectService: cacheProjects(projectService),
 		appService:     appService,
 	}
 }
 
+// NewContainerWithDatabaseService creates a container with custom project and
+// database service implementations.
+// This is useful for testing database commands with mock services.
+func NewContainerWithDatabaseService(
+	authService iface.AuthService,
+	projectService iface.ProjectService,
+	databaseService iface.DatabaseService,
+) *Container {
+	return &Container{
+		authService:     authService,
+		projectService:  cacheProjects(projectService),
+		databaseService: databaseService,
+	}
+}
+
+// NewContainerWithConfigManager creates a container that only carries a
+// config manager. This is useful for testing commands that read or write
+// configuration without calling the API.
+func NewContainerWithConfigManager(configManager *config.Manager) *Container {
+	return &Container{
+		configManager: configManager,
+	}
+}
+
+// NewContainerWithAuthService creates a container with a config manager and
+// a custom auth service. This is useful for testing commands that act on
+// local credentials, such as logout.
+func NewContainerWithAuthService(configManager *config.Manager, authService iface.AuthService) *Container {
+	return &Container{
+		configManager: configManager,
+		authService:   authService,
+	}
+}
+
+// WithConfigManager sets the config manager of a container built from
+// custom services and returns the container. This is useful for testing
+// commands that read settings as well as calling mock services.
+func (c *Container) WithConfigManager(configManager *config.Manager) *Container {
+	c.configManager = configManager
+	return c
+}
+
+// WithOrgService sets the org service of a container built from custom
+// services and returns the container. This is useful for testing the orgs
+// commands with a mock service.
+func (c *Container) WithOrgService(orgService iface.OrgService) *Container {
+	c.orgService = orgService
+	return c
+}
+
+// projectCache returns the on-disk project list cache. With the cache
+// disabled in the config, with noCache, or when the config cannot be read,
+// it is only used to invalidate entries left by earlier invocations.
+func projectCache(configManager *config.Manager, noCache bool) *service.ProjectCache {
+	var ttl time.Duration
+	cfg, err := configManager.Load()
+	if err == nil && !noCache {
+		ttl = cfg.ProjectCacheTTL()
+	}
+	apiURL, err := configManager.GetAPIURL()
+	if err != nil {
+		ttl = 0
+	}
+	org, err := configManager.GetOrg()
+	if err != nil {
+		ttl = 0
+	}
+	// The CLI has a single profile
+	return service.NewProjectCache(configManager.CachePath(), "default", apiURL, ttl).WithOrg(org)
+}
+
+// cacheProjects wraps projectService so the project list is fetched at most
+// once per invocation, as NewContainer does for the real service
+func cacheProjects(projectService iface.ProjectService) iface.ProjectService {
+	if projectService == nil {
+		return nil
+	}
+	return service.NewCachedProjectService(projectService)
+}
+
 // AuthService returns the authentication service
 func (c *Container) AuthService() iface.AuthService {
 	return c.authService
@@ -81,6 +182,16 @@ func (c *Container) TokensService() iface.TokensService {
 	return c.tokensService
 }
 
+// DatabaseService returns the database service
+func (c *Container) DatabaseService() iface.DatabaseService {
+	return c.databaseService
+}
+
+// OrgService returns the organization service
+func (c *Container) OrgService() iface.OrgService {
+	return c.orgService
+}
+
 // ConfigManager returns the config manager
 func (c *Container) ConfigManager() *config.Manager {
 	return c.configManager
@@ -0,0 +1,54 @@
+// Package redact masks credentials in text that may end up in logs or error
+// output, such as debug traces and errors from the OAuth flows.
+package redact
+
+import "regexp"
+
+// Mask replaces every redacted value
+const Mask = "[REDACTED]"
+
+var (
+	// authSchemePattern matches bearer and basic credentials wherever they
+	// appear, e.g. in an Authorization header. "Basic" is matched
+	// case-sensitively so prose like "basic setup" is left alone.
+	authSchemePattern = regexp.MustCompile(`\b((?i:bearer)\s+|Basic\s+)[A-Za-z0-9._~+/=-]+`)
+
+	// jsonFieldPattern matches JSON string fields that carry secrets
+	jsonFieldPattern = regexp.MustCompile(`("(?:access_token|refresh_token|client_secret|token|code_verifier|device_code|password|registry_password)"\s*:\s*)"(?:[^"\\]|\\.)*"`)
+
+	// formFieldPattern matches secret parameters of form bodies and query
+	// strings. "code" is the OAuth authorization code; it is only masked
+	// here because the API uses a JSON "code" field for error codes.
+	formFieldPattern = regexp.MustCompile(`\b((?:access_token|refresh_token|client_secret|token|code|code_verifier|device_code|password|registry_password)=)[^&\s"']+`)
+)
+
+// String masks bearer and basic credentials, secret JSON fields, and secret
+// form or query parameters in s
+func String(s string) string {
+	s = authSchemePattern.ReplaceAllString(s, "${1}"+Mask)
+	s = jsonFieldPattern.ReplaceAllString(s, `${1}"`+Mask+`"`)
+	return formFieldPattern.ReplaceAllString(s, "${1}"+Mask)
+}
+
+// Error returns err with its message passed through String. The original
+// error stays reachable through errors.Is and errors.As. A nil err
+// returns nil.
+func Error(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err}
+}
+
+// redactedError is an error whose message has its secrets masked
+type redactedError struct {
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return String(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
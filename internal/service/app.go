@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/kamui-project/kamui-cli/internal/api"
 	"github.com/kamui-project/kamui-cli/internal/config"
@@ -13,33 +15,21 @@ import (
 type appService struct {
 	configManager *config.Manager
 	authService   iface.AuthService
+	httpOptions   HTTPOptions
 }
 
 // NewAppService creates a new app service
-func NewAppService(configManager *config.Manager, authService iface.AuthService) iface.AppService {
+func NewAppService(configManager *config.Manager, authService iface.AuthService, httpOptions HTTPOptions) iface.AppService {
 	return &appService{
 		configManager: configManager,
 		authService:   authService,
+		httpOptions:   httpOptions,
 	}
 }
 
 // getAPIClient creates an API client with the current credentials
 func (s *appService) getAPIClient(ctx context.Context) (*api.Client, error) {
-	if err := s.authService.EnsureAuthenticated(ctx); err != nil {
-		return nil, err
-	}
-
-	token, err := s.configManager.GetAccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
-
-	apiURL, err := s.configManager.GetAPIURL()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get API URL: %w", err)
-	}
-
-	return api.NewClient(apiURL, token), nil
+	return newAPIClient(ctx, s.configManager, s.authService, s.httpOptions)
 }
 
 // GetInstallations returns all GitHub App installations for the user
@@ -68,6 +58,23 @@ func (s *appService) GetInstallations(ctx context.Context) ([]iface.Installation
 	return result, nil
 }
 
+// GetGithubInstallURL returns the URL that installs the Kamui GitHub App
+func (s *appService) GetGithubInstallURL(ctx context.Context) (string, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	url, err := client.GetGithubInstallURL(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch GitHub App install URL: %w", err)
+	}
+	if url == "" {
+		return "", fmt.Errorf("failed to fetch GitHub App install URL: the API returned none")
+	}
+	return url, nil
+}
+
 // GetBranches returns branches for a repository
 func (s *appService) GetBranches(ctx context.Context, owner, repo string) ([]iface.Branch, error) {
 	client, err := s.getAPIClient(ctx)
@@ -106,10 +113,17 @@ func (s *appService) CreateApp(ctx context.Context, input *iface.CreateAppInput)
 		AppDisplayName:      input.DisplayName,
 		Replicas:            input.Replicas,
 		EnvVars:             input.EnvVars,
+		SecretKeys:          input.SecretKeys,
 		PreCommand:          input.PreCommand,
 		StartCommand:        input.StartCommand,
 		SetupCommand:        input.SetupCommand,
 		HealthCheckEndpoint: input.HealthCheckPath,
+		HealthCheckMethod:   input.HealthCheckMethod,
+		HealthCheckPort:     input.HealthCheckPort,
+		HealthCheckStatus:   input.HealthCheckStatus,
+		HealthCheckInterval: int(input.HealthCheckInterval / time.Second),
+		HealthCheckTimeout:  int(input.HealthCheckTimeout / time.Second),
+		HealthCheckDelay:    int(input.HealthCheckInitialDelay / time.Second),
 		DeployType:          input.DeployType,
 		AppType:             "dynamic",
 		LanguageType:        input.Language,
@@ -118,8 +132,15 @@ func (s *appService) CreateApp(ctx context.Context, input *iface.CreateAppInput)
 		RepositoryName:      input.Repository,
 		RepositoryBranch:    input.Branch,
 		Directory:           input.Directory,
+		ImageName:           input.Image,
+		ImageTag:            input.ImageTag,
+		ImageDigest:         input.ImageDigest,
+		RegistryURL:         input.Registry,
+		RegistryUsername:    input.RegistryUsername,
+		RegistryPassword:    input.RegistryPassword,
 		DatabaseID:          input.DatabaseID,
 		AppSpecType:         input.AppSpecType,
+		SessionAffinity:     input.SessionAffinity,
 		Status: &api.ProjectStatus{
 			StatusRunning: 0,
 			StatusStopped: 0,
@@ -138,6 +159,9 @@ func (s *appService) CreateApp(ctx context.Context, input *iface.CreateAppInput)
 	if req.HealthCheckEndpoint == "" {
 		req.HealthCheckEndpoint = "/health"
 	}
+	if req.HealthCheckMethod == "" {
+		req.HealthCheckMethod = http.MethodGet
+	}
 	if req.AppSpecType == "" {
 		req.AppSpecType = "nano"
 	}
@@ -182,18 +206,40 @@ func (s *appService) GetApp(ctx context.Context, appID string) (*iface.AppDetail
 	}
 
 	return &iface.AppDetail{
-		ID:            appID,
-		DisplayName:   resp.DisplayName,
-		AppType:       resp.AppType,
-		LanguageType:  resp.LanguageType,
-		URL:           resp.URL,
-		CustomDomain:  resp.CustomDomain,
-		GithubOrgRepo: resp.GithubOrgRepo,
-		GithubBranch:  resp.GithubBranch,
-		Status:        (*iface.ProjectStatus)(resp.PodStatus),
+		ID:              appID,
+		DisplayName:     resp.DisplayName,
+		AppType:         resp.AppType,
+		LanguageType:    resp.LanguageType,
+		AppSpec:         resp.AppSpec,
+		Replicas:        resp.Replicas,
+		URL:             resp.URL,
+		CustomDomain:    resp.CustomDomain,
+		GithubOrgRepo:   resp.GithubOrgRepo,
+		GithubBranch:    resp.GithubBranch,
+		SessionAffinity: resp.SessionAffinity,
+		Status:          (*iface.ProjectStatus)(resp.PodStatus),
 	}, nil
 }
 
+// GetAppEnv returns an app's environment variables
+func (s *appService) GetAppEnv(ctx context.Context, appID string) (*iface.AppEnv, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAppEnv(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch environment variables: %w", err)
+	}
+
+	env := &iface.AppEnv{EnvVars: resp.EnvVars, SecretKeys: resp.SecretKeys}
+	if env.EnvVars == nil {
+		env.EnvVars = map[string]string{}
+	}
+	return env, nil
+}
+
 // DeleteApp deletes an app by ID
 func (s *appService) DeleteApp(ctx context.Context, appID string) error {
 	client, err := s.getAPIClient(ctx)
@@ -208,6 +254,212 @@ func (s *appService) DeleteApp(ctx context.Context, appID string) error {
 	return nil
 }
 
+// Redeploy triggers a fresh deployment of an app
+func (s *appService) Redeploy(ctx context.Context, input *iface.RedeployAppInput) (*iface.RedeployAppOutput, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.RedeployApp(ctx, input.AppID, &api.RedeployAppRequest{
+		Branch: input.Branch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to redeploy app: %w", err)
+	}
+
+	return &iface.RedeployAppOutput{
+		AppID:        input.AppID,
+		DeploymentID: resp.DeploymentID,
+	}, nil
+}
+
+// GetDeployStatus returns the current deployment status of an app
+func (s *appService) GetDeployStatus(ctx context.Context, appID string) (*iface.DeployStatus, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetDeployStatus(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deploy status: %w", err)
+	}
+
+	return &iface.DeployStatus{
+		Status:  resp.Status,
+		Message: resp.Message,
+	}, nil
+}
+
+// ListDeployments returns the recent deployments of an app, newest first
+func (s *appService) ListDeployments(ctx context.Context, appID string) ([]iface.Deployment, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.ListDeployments(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list deployments: %w", err)
+	}
+
+	deployments := make([]iface.Deployment, len(resp))
+	for i, d := range resp {
+		deployments[i] = iface.Deployment(d)
+	}
+	return deployments, nil
+}
+
+// Rollback redeploys an app from one of its previous deployments
+func (s *appService) Rollback(ctx context.Context, input *iface.RollbackAppInput) (*iface.RollbackAppOutput, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.RollbackApp(ctx, input.AppID, &api.RollbackAppRequest{
+		DeploymentID: input.DeploymentID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back app: %w", err)
+	}
+
+	return &iface.RollbackAppOutput{
+		AppID:        input.AppID,
+		TargetID:     input.DeploymentID,
+		DeploymentID: resp.DeploymentID,
+	}, nil
+}
+
+// GetDomain returns the custom domain of an app
+func (s *appService) GetDomain(ctx context.Context, appID string) (*iface.AppDomain, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetAppDomain(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch custom domain: %w", err)
+	}
+
+	return toAppDomain(resp), nil
+}
+
+// SetDomain sets the custom domain of an app
+func (s *appService) SetDomain(ctx context.Context, appID, domain string) (*iface.AppDomain, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.SetAppDomain(ctx, appID, &api.SetAppDomainRequest{
+		Domain: domain,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to set custom domain: %w", err)
+	}
+
+	return toAppDomain(resp), nil
+}
+
+// RemoveDomain removes the custom domain of an app
+func (s *appService) RemoveDomain(ctx context.Context, appID string) error {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := client.RemoveAppDomain(ctx, appID); err != nil {
+		return fmt.Errorf("failed to remove custom domain: %w", err)
+	}
+
+	return nil
+}
+
+// TransferApp moves an app to another project
+func (s *appService) TransferApp(ctx context.Context, appID, targetProjectID string) (*iface.TransferAppOutput, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.TransferApp(ctx, appID, &api.TransferAppRequest{
+		ProjectID: targetProjectID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to transfer app: %w", err)
+	}
+
+	projectID := resp.ProjectID
+	if projectID == "" {
+		projectID = targetProjectID
+	}
+	return &iface.TransferAppOutput{
+		AppID:     appID,
+		ProjectID: projectID,
+	}, nil
+}
+
+// UpdateApp changes the settings of an app set in input
+func (s *appService) UpdateApp(ctx context.Context, appID string, input *iface.UpdateAppInput) (*iface.AppDetail, error) {
+	if input.IsEmpty() {
+		return nil, fmt.Errorf("no app settings to update")
+	}
+	if input.Replicas != nil && *input.Replicas < 1 {
+		return nil, fmt.Errorf("replicas must be at least 1")
+	}
+
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateApp(ctx, appID, &api.UpdateAppRequest{
+		DisplayName: input.DisplayName,
+		Replicas:    input.Replicas,
+		AppSpecType: input.AppSpecType,
+		EnvVars:     input.EnvVars,
+		SecretKeys:  input.SecretKeys,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update app: %w", err)
+	}
+
+	// The API may answer with an empty body; report what was asked for
+	if resp.DisplayName == "" && input.DisplayName != nil {
+		resp.DisplayName = *input.DisplayName
+	}
+	return &iface.AppDetail{
+		ID:              appID,
+		DisplayName:     resp.DisplayName,
+		AppType:         resp.AppType,
+		LanguageType:    resp.LanguageType,
+		AppSpec:         resp.AppSpec,
+		Replicas:        resp.Replicas,
+		URL:             resp.URL,
+		CustomDomain:    resp.CustomDomain,
+		GithubOrgRepo:   resp.GithubOrgRepo,
+		GithubBranch:    resp.GithubBranch,
+		SessionAffinity: resp.SessionAffinity,
+		Status:          (*iface.ProjectStatus)(resp.PodStatus),
+	}, nil
+}
+
+// toAppDomain converts an API domain response to the interface type
+func toAppDomain(resp *api.AppDomainResponse) *iface.AppDomain {
+	records := make([]iface.DNSRecord, len(resp.DNSRecords))
+	for i, r := range resp.DNSRecords {
+		records[i] = iface.DNSRecord(r)
+	}
+	return &iface.AppDomain{
+		Domain:     resp.Domain,
+		Status:     resp.Status,
+		DNSRecords: records,
+	}
+}
+
 // CreateStaticApp creates a new static app via GitHub repository
 func (s *appService) CreateStaticApp(ctx context.Context, input *iface.CreateStaticAppInput) (*iface.CreateAppOutput, error) {
 	client, err := s.getAPIClient(ctx)
@@ -265,6 +517,7 @@ func (s *appService) CreateStaticAppUpload(ctx context.Context, input *iface.Cre
 		Replicas:    input.Replicas,
 		AppSpecType: input.AppSpecType,
 		FilePath:    input.FilePath,
+		Progress:    input.Progress,
 	}
 
 	// Set defaults
@@ -285,3 +538,26 @@ func (s *appService) CreateStaticAppUpload(ctx context.Context, input *iface.Cre
 		Name: input.AppName,
 	}, nil
 }
+
+// UpdateStaticUpload uploads a new ZIP build of an existing static app
+func (s *appService) UpdateStaticUpload(ctx context.Context, input *iface.UpdateStaticAppUploadInput) (*iface.UpdateStaticAppUploadOutput, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.UpdateStaticAppUpload(ctx, input.AppID, &api.UpdateStaticAppUploadRequest{
+		Replicas:    input.Replicas,
+		AppSpecType: input.AppSpecType,
+		FilePath:    input.FilePath,
+		Progress:    input.Progress,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload static build: %w", err)
+	}
+
+	return &iface.UpdateStaticAppUploadOutput{
+		AppID:        input.AppID,
+		DeploymentID: resp.DeploymentID,
+	}, nil
+}
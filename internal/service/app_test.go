@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestAppService_CreateAppHealthCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		input iface.CreateAppInput
+		want  map[string]interface{}
+	}{
+		{
+			name: "defaults",
+			want: map[string]interface{}{
+				"health_check_endpoint": "/health",
+				"health_check_method":   "GET",
+			},
+		},
+		{
+			name: "all settings",
+			input: iface.CreateAppInput{
+				HealthCheckPath:         "/ready",
+				HealthCheckMethod:       "HEAD",
+				HealthCheckPort:         9090,
+				HealthCheckStatus:       204,
+				HealthCheckInterval:     30 * time.Second,
+				HealthCheckTimeout:      5 * time.Second,
+				HealthCheckInitialDelay: 2 * time.Minute,
+			},
+			want: map[string]interface{}{
+				"health_check_endpoint":              "/ready",
+				"health_check_method":                "HEAD",
+				"health_check_port":                  float64(9090),
+				"health_check_expected_status":       float64(204),
+				"health_check_interval_seconds":      float64(30),
+				"health_check_timeout_seconds":       float64(5),
+				"health_check_initial_delay_seconds": float64(120),
+			},
+		},
+	}
+
+	healthKeys := []string{
+		"health_check_endpoint", "health_check_method", "health_check_port", "health_check_expected_status",
+		"health_check_interval_seconds", "health_check_timeout_seconds", "health_check_initial_delay_seconds",
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/api/apps" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				data, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(data, &body); err != nil {
+					t.Errorf("request body is not JSON: %v", err)
+				}
+				w.Write([]byte(`{"app_id":"app-1"}`))
+			}))
+			defer srv.Close()
+
+			// The API URL must be https, so trust the test server's certificate
+			roots := x509.NewCertPool()
+			roots.AddCert(srv.Certificate())
+
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.Save(&config.Config{APIURL: srv.URL, AccessToken: "token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			httpOptions := HTTPOptions{Transport: api.TransportOptions{RootCAs: roots}}
+			s := NewAppService(m, NewAuthService(m, httpOptions), httpOptions)
+			input := tt.input
+			input.ProjectID = "proj-1"
+			input.AppName = "web"
+			if _, err := s.CreateApp(context.Background(), &input); err != nil {
+				t.Fatalf("CreateApp() error = %v", err)
+			}
+
+			for _, key := range healthKeys {
+				got, ok := body[key]
+				want, wantOK := tt.want[key]
+				if ok != wantOK || got != want {
+					t.Errorf("%s = %v (present %v), want %v (present %v)", key, got, ok, want, wantOK)
+				}
+			}
+		})
+	}
+}
+
+func TestAppService_UpdateApp(t *testing.T) {
+	name := "Payments API"
+	replicas := 3
+	env := map[string]string{}
+
+	tests := []struct {
+		name    string
+		input   iface.UpdateAppInput
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			// Only the fields being changed are sent, so other settings are kept
+			name:  "display name only",
+			input: iface.UpdateAppInput{DisplayName: &name},
+			want:  map[string]interface{}{"display_name": name},
+		},
+		{
+			name:  "replicas only",
+			input: iface.UpdateAppInput{Replicas: &replicas},
+			want:  map[string]interface{}{"replicas": float64(3)},
+		},
+		{
+			name:  "empty env vars are sent",
+			input: iface.UpdateAppInput{EnvVars: &env},
+			want:  map[string]interface{}{"env_vars": map[string]interface{}{}},
+		},
+		{name: "nothing to update", wantErr: "no app settings to update"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body map[string]interface{}
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch || r.URL.Path != "/api/apps/app-1" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+				data, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(data, &body); err != nil {
+					t.Errorf("request body is not JSON: %v", err)
+				}
+				w.Write([]byte(`{"display_name":"Payments API","app_type":"dynamic"}`))
+			}))
+			defer srv.Close()
+
+			roots := x509.NewCertPool()
+			roots.AddCert(srv.Certificate())
+
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.Save(&config.Config{APIURL: srv.URL, AccessToken: "token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			httpOptions := HTTPOptions{Transport: api.TransportOptions{RootCAs: roots}}
+			s := NewAppService(m, NewAuthService(m, httpOptions), httpOptions)
+			input := tt.input
+			app, err := s.UpdateApp(context.Background(), "app-1", &input)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("UpdateApp() error = %v, want containing %q", err, tt.wantErr)
+				}
+				if body != nil {
+					t.Errorf("request sent for an empty update: %v", body)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateApp() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(body, tt.want) {
+				t.Errorf("request body = %v, want %v", body, tt.want)
+			}
+			if app.ID != "app-1" || app.DisplayName != name || app.AppType != "dynamic" {
+				t.Errorf("UpdateApp() = %+v", app)
+			}
+		})
+	}
+}
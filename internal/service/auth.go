@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"os"
 
+	"github.com/kamui-project/kamui-cli/internal/api"
 	"github.com/kamui-project/kamui-cli/internal/auth"
 	"github.com/kamui-project/kamui-cli/internal/config"
 	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
@@ -13,17 +16,25 @@ import (
 // authService implements iface.AuthService
 type authService struct {
 	configManager *config.Manager
+	refresher     *authRefresher
+	httpOptions   HTTPOptions
 }
 
 // NewAuthService creates a new authentication service
-func NewAuthService(configManager *config.Manager) iface.AuthService {
+func NewAuthService(configManager *config.Manager, httpOptions HTTPOptions) iface.AuthService {
 	return &authService{
 		configManager: configManager,
+		refresher:     newAuthRefresher(configManager, httpOptions),
+		httpOptions:   httpOptions,
 	}
 }
 
 // Login performs OAuth authentication and saves credentials
-func (s *authService) Login(ctx context.Context) error {
+func (s *authService) Login(ctx context.Context, opts *iface.LoginOptions) error {
+	if opts == nil {
+		opts = &iface.LoginOptions{}
+	}
+
 	// Reject only if the current access token is still valid.
 	// Expired sessions are allowed to re-login directly without `kamui logout`.
 	if s.configManager.IsLoggedIn() {
@@ -38,9 +49,19 @@ func (s *authService) Login(ctx context.Context) error {
 
 	// Create OAuth flow. Login() always performs a fresh DCR — we no longer
 	// reuse stored client credentials across logins.
-	oauthFlow := auth.NewOAuthFlow(apiURL)
+	oauthFlow := s.httpOptions.newOAuthFlow(apiURL)
+	if opts.CallbackPort != nil {
+		oauthFlow.SetCallbackPort(*opts.CallbackPort)
+	}
+	oauthFlow.SetNoBrowser(opts.NoBrowser)
+	oauthFlow.SetScope(opts.Scope)
 
-	result, err := oauthFlow.Login(ctx)
+	var result *auth.OAuthResult
+	if opts.Device {
+		result, err = oauthFlow.LoginDevice(ctx)
+	} else {
+		result, err = oauthFlow.Login(ctx)
+	}
 	if err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
@@ -59,42 +80,114 @@ func (s *authService) Login(ctx context.Context) error {
 		return fmt.Errorf("failed to save credentials: %w", err)
 	}
 
+	// The server may grant less than was requested; record what it granted
+	scope := result.Scope
+	if scope == "" {
+		scope = opts.Scope
+		if scope == "" {
+			scope = auth.DefaultScope
+		}
+	}
+	if err := s.configManager.SaveScope(scope); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
+	return nil
+}
+
+// LoginWithToken stores a pre-issued access token (e.g. a CI service token)
+// after checking it against GET /api/me. No refresh token is stored, so the
+// session ends when the token expires.
+func (s *authService) LoginWithToken(ctx context.Context, token string, expiresIn int) error {
+	if token == "" {
+		return fmt.Errorf("token is empty")
+	}
+
+	if s.configManager.IsLoggedIn() {
+		return fmt.Errorf("already logged in. Use 'kamui logout' first to log out")
+	}
+
+	apiURL, err := s.configManager.GetAPIURL()
+	if err != nil {
+		return fmt.Errorf("failed to get API URL: %w", err)
+	}
+
+	client := s.httpOptions.newClient(apiURL, token)
+	if _, err := client.GetMe(ctx); err != nil {
+		var apiErr *api.APIError
+		switch {
+		case errors.As(err, &apiErr) && (apiErr.IsUnauthorized() || apiErr.StatusCode == http.StatusForbidden):
+			return fmt.Errorf("token was rejected by the API: %w", err)
+		case errors.As(err, &apiErr) && apiErr.IsNotFound():
+			// Older API versions have no /api/me; save the token unverified
+			fmt.Fprintln(os.Stderr, "⚠ could not verify the token (GET /api/me not available); saving it anyway")
+		default:
+			return fmt.Errorf("failed to verify token: %w", err)
+		}
+	}
+
+	if err := s.configManager.SaveTokens(token, "", expiresIn); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+	// The scope of a pre-issued token is unknown, so don't keep the one
+	// granted to an earlier, expired session
+	if err := s.configManager.SaveScope(""); err != nil {
+		return fmt.Errorf("failed to save credentials: %w", err)
+	}
+
 	return nil
 }
 
 // Logout revokes server-side tokens (RFC 7009) then clears local credentials.
 // Server-side revoke is best-effort: if the network or server is unavailable,
 // local credentials are still cleared (logout MUST work offline).
-func (s *authService) Logout(ctx context.Context) error {
+// opts.LocalOnly skips the revoke calls entirely.
+func (s *authService) Logout(ctx context.Context, opts *iface.LogoutOptions) error {
+	if opts == nil {
+		opts = &iface.LogoutOptions{}
+	}
+
 	cfg, err := s.configManager.Load()
 	if err != nil {
 		return fmt.Errorf("failed to load config: %w", err)
 	}
 
-	if cfg.AccessToken == "" && cfg.RefreshToken == "" {
-		return fmt.Errorf("not logged in")
+	loggedIn := cfg.AccessToken != "" || cfg.RefreshToken != ""
+	if !loggedIn && !opts.Purge {
+		return ErrNotLoggedIn
 	}
 
 	// Best-effort server-side revocation. We need client credentials to
 	// authenticate the revoke call; if they're missing (e.g. partially
 	// corrupted config) just skip and clear local state.
-	if cfg.ClientID != "" && cfg.ClientSecret != "" {
-		oauthFlow := auth.NewOAuthFlow(cfg.APIURL)
+	if loggedIn && !opts.LocalOnly && cfg.ClientID != "" && cfg.ClientSecret != "" {
+		apiURL, err := s.configManager.GetAPIURL()
+		if err != nil {
+			return fmt.Errorf("failed to get API URL: %w", err)
+		}
+		oauthFlow := s.httpOptions.newOAuthFlow(apiURL)
 		oauthFlow.SetClientCredentials(cfg.ClientID, cfg.ClientSecret)
 
 		if cfg.AccessToken != "" {
 			if err := oauthFlow.Revoke(ctx, cfg.AccessToken, "access_token"); err != nil {
 				// Don't abort logout; just inform the user.
-				fmt.Printf("Warning: server-side access token revoke failed (%v). Local credentials will still be cleared.\n", err)
+				fmt.Fprintf(os.Stderr, "Warning: server-side access token revoke failed (%v). Local credentials will still be cleared.\n", err)
 			}
 		}
 		if cfg.RefreshToken != "" {
 			if err := oauthFlow.Revoke(ctx, cfg.RefreshToken, "refresh_token"); err != nil {
-				fmt.Printf("Warning: server-side refresh token revoke failed (%v). Local credentials will still be cleared.\n", err)
+				fmt.Fprintf(os.Stderr, "Warning: server-side refresh token revoke failed (%v). Local credentials will still be cleared.\n", err)
 			}
 		}
 	}
 
+	if opts.Purge {
+		if err := s.configManager.Delete(); err != nil {
+			return fmt.Errorf("failed to delete config: %w", err)
+		}
+		return nil
+	}
+
 	if err := s.configManager.Clear(); err != nil {
 		return fmt.Errorf("failed to clear credentials: %w", err)
 	}
@@ -102,6 +195,22 @@ func (s *authService) Logout(ctx context.Context) error {
 	return nil
 }
 
+// GetCurrentUser checks the stored credentials against GET /api/me,
+// refreshing the access token if needed
+func (s *authService) GetCurrentUser(ctx context.Context) (*iface.User, error) {
+	client, err := newAPIClient(ctx, s.configManager, s, s.httpOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	me, err := client.GetMe(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify credentials: %w", err)
+	}
+
+	return &iface.User{ID: me.ID, Name: me.Name, Email: me.Email}, nil
+}
+
 // IsLoggedIn checks if the user is currently authenticated
 // Note: This only checks if tokens exist, not if they're valid
 func (s *authService) IsLoggedIn() bool {
@@ -114,55 +223,16 @@ func (s *authService) IsLoggedIn() bool {
 	return cfg.AccessToken != "" || cfg.RefreshToken != ""
 }
 
-// EnsureAuthenticated checks login status and refreshes token if needed
+// EnsureAuthenticated checks login status and refreshes the access token
+// when it has expired or is about to
 func (s *authService) EnsureAuthenticated(ctx context.Context) error {
-	cfg, err := s.configManager.Load()
-	if err != nil {
-		return fmt.Errorf("failed to load config: %w", err)
-	}
-
-	// Check if we have any tokens
-	if cfg.AccessToken == "" && cfg.RefreshToken == "" {
-		return fmt.Errorf("not logged in. Please run 'kamui login' first")
-	}
-
-	// Check if access token is still valid
-	if s.configManager.IsLoggedIn() {
-		return nil // Token is valid
-	}
-
-	// Token expired, try to refresh
-	if cfg.RefreshToken == "" {
-		return fmt.Errorf("session expired. Please run 'kamui login' again")
-	}
-
-	apiURL, err := s.configManager.GetAPIURL()
-	if err != nil {
-		return fmt.Errorf("failed to get API URL: %w", err)
-	}
-
-	oauthFlow := auth.NewOAuthFlow(apiURL)
-	oauthFlow.SetClientCredentials(cfg.ClientID, cfg.ClientSecret)
-
-	result, err := oauthFlow.RefreshTokens(ctx, cfg.RefreshToken)
-	if err != nil {
-		if errors.Is(err, auth.ErrRefreshTokenInvalid) {
-			// Refresh token was rejected by the server: drop local tokens
-			// so the user can simply run `kamui login` again.
-			if clearErr := s.configManager.Clear(); clearErr != nil {
-				return fmt.Errorf("session expired and failed to clear local credentials: %w", clearErr)
-			}
-			return fmt.Errorf("session expired. Please run 'kamui login' again")
-		}
-		return fmt.Errorf("failed to refresh token: %w", err)
-	}
-
-	// Save new tokens
-	if err := s.configManager.SaveTokens(result.AccessToken, result.RefreshToken, result.ExpiresIn); err != nil {
-		return fmt.Errorf("failed to save refreshed tokens: %w", err)
-	}
+	return s.refresher.ensureAuthenticated(ctx)
+}
 
-	return nil
+// RefreshAccessToken refreshes the access token unconditionally, e.g. after
+// the API rejected it with 401, and returns the new token
+func (s *authService) RefreshAccessToken(ctx context.Context) (string, error) {
+	return s.refresher.refreshAccessToken(ctx)
 }
 
 // GetAccessToken returns the current access token, refreshing if needed
@@ -171,5 +241,5 @@ func (s *authService) GetAccessToken(ctx context.Context) (string, error) {
 		return "", err
 	}
 
-	return s.configManager.GetAccessToken()
+	return accessToken(s.configManager)
 }
@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/auth"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	"golang.org/x/sync/singleflight"
+)
+
+var (
+	// ErrNotLoggedIn is returned when no credentials are stored
+	ErrNotLoggedIn = errors.New("not logged in. Please run 'kamui login' first")
+
+	// ErrSessionExpired is returned when the stored credentials can no
+	// longer be used or refreshed
+	ErrSessionExpired = errors.New("session expired. Please run 'kamui login' again")
+)
+
+// accessToken returns the stored access token, reporting a missing or
+// expired one as ErrNotLoggedIn or ErrSessionExpired
+func accessToken(configManager *config.Manager) (string, error) {
+	token, err := configManager.GetAccessToken()
+	switch {
+	case errors.Is(err, config.ErrNoAccessToken):
+		return "", ErrNotLoggedIn
+	case errors.Is(err, config.ErrAccessTokenExpired):
+		return "", ErrSessionExpired
+	case err != nil:
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	return token, nil
+}
+
+// refreshGroup makes concurrent refreshes of the same refresh token share
+// one request, across every authRefresher in the process. A refresh token
+// may be single-use, so sending it twice could fail and log the user out.
+var refreshGroup singleflight.Group
+
+// tokenRefreshWindow is how close to expiry an access token may get before
+// it is refreshed proactively, so long-running requests don't cross expiry
+const tokenRefreshWindow = 2 * time.Minute
+
+// authRefresher is the single place where stored credentials are checked
+// and the access token is refreshed. Every service reaches it through
+// AuthService, so refresh behavior is defined once.
+type authRefresher struct {
+	configManager *config.Manager
+
+	// now and refreshTokens are replaced in tests
+	now           func() time.Time
+	refreshTokens func(ctx context.Context, apiURL, clientID, clientSecret, refreshToken string) (*auth.OAuthResult, error)
+
+	// relogin logs in again once the session has expired; see
+	// HTTPOptions.Relogin. reloginMu serializes it with concurrent callers
+	// and reloginTried stops it from being offered twice.
+	relogin      func(ctx context.Context) error
+	reloginMu    sync.Mutex
+	reloginTried bool
+}
+
+// newAuthRefresher creates an authRefresher for the given config. OAuth
+// requests use the transport from httpOptions.
+func newAuthRefresher(configManager *config.Manager, httpOptions HTTPOptions) *authRefresher {
+	return &authRefresher{
+		configManager: configManager,
+		now:           time.Now,
+		refreshTokens: httpOptions.oauthRefreshTokens,
+		relogin:       httpOptions.Relogin,
+	}
+}
+
+// ensureAuthenticated checks login status and refreshes the access token
+// when it has expired or expires within tokenRefreshWindow. An expired
+// session is handed to relogin, if set.
+func (r *authRefresher) ensureAuthenticated(ctx context.Context) error {
+	err := r.checkAuthenticated(ctx)
+	if errors.Is(err, ErrSessionExpired) && r.tryRelogin(ctx) {
+		return r.checkAuthenticated(ctx)
+	}
+	return err
+}
+
+// checkAuthenticated does the work of ensureAuthenticated without offering
+// to log in again
+func (r *authRefresher) checkAuthenticated(ctx context.Context) error {
+	cfg, err := r.configManager.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	// Check if we have any tokens
+	if cfg.AccessToken == "" && cfg.RefreshToken == "" {
+		return ErrNotLoggedIn
+	}
+
+	// Check if access token is still valid for a while
+	now := r.now()
+	if cfg.AccessToken != "" && (cfg.ExpiresAt.IsZero() || now.Add(tokenRefreshWindow).Before(cfg.ExpiresAt)) {
+		return nil
+	}
+
+	// Token expired or expiring soon, try to refresh
+	if cfg.RefreshToken == "" {
+		if cfg.AccessToken != "" && now.Before(cfg.ExpiresAt) {
+			return nil // can't refresh; use it while it lasts
+		}
+		return ErrSessionExpired
+	}
+
+	_, err = r.refresh(ctx, cfg)
+	return err
+}
+
+// refreshAccessToken refreshes the access token unconditionally and
+// returns the new one
+func (r *authRefresher) refreshAccessToken(ctx context.Context) (string, error) {
+	cfg, err := r.configManager.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if cfg.RefreshToken == "" {
+		err = ErrSessionExpired
+	} else {
+		var token string
+		if token, err = r.refresh(ctx, cfg); err == nil {
+			return token, nil
+		}
+	}
+
+	if errors.Is(err, ErrSessionExpired) && r.tryRelogin(ctx) {
+		return accessToken(r.configManager)
+	}
+	return "", err
+}
+
+// tryRelogin runs relogin after the session has expired and reports
+// whether it succeeded. It is tried at most once, so a failed or declined
+// login is not offered again. Callers arriving while it runs wait for it
+// and then see its outcome.
+func (r *authRefresher) tryRelogin(ctx context.Context) bool {
+	if r.relogin == nil {
+		return false
+	}
+	r.reloginMu.Lock()
+	defer r.reloginMu.Unlock()
+
+	if r.reloginTried {
+		// Another caller already logged in again, or gave up
+		_, err := accessToken(r.configManager)
+		return err == nil
+	}
+	r.reloginTried = true
+	return r.relogin(ctx) == nil
+}
+
+// refresh exchanges the stored refresh token for new tokens and saves them.
+// Callers refreshing the same token at once share the first one's result.
+func (r *authRefresher) refresh(ctx context.Context, cfg *config.Config) (string, error) {
+	token, err, _ := refreshGroup.Do(cfg.RefreshToken, func() (interface{}, error) {
+		return r.refreshOnce(ctx, cfg)
+	})
+	if err != nil {
+		return "", err
+	}
+	return token.(string), nil
+}
+
+// refreshOnce does the work of refresh for the caller leading a refreshGroup
+// call
+func (r *authRefresher) refreshOnce(ctx context.Context, cfg *config.Config) (string, error) {
+	// A refresh that finished after cfg was loaded has already replaced the
+	// refresh token; use its result rather than sending the old token again
+	if current, err := r.configManager.Load(); err == nil && current.RefreshToken != cfg.RefreshToken && current.AccessToken != "" {
+		return current.AccessToken, nil
+	}
+
+	apiURL, err := r.configManager.GetAPIURL()
+	if err != nil {
+		return "", fmt.Errorf("failed to get API URL: %w", err)
+	}
+
+	result, err := r.refreshTokens(ctx, apiURL, cfg.ClientID, cfg.ClientSecret, cfg.RefreshToken)
+	if err != nil {
+		if errors.Is(err, auth.ErrRefreshTokenInvalid) {
+			// Refresh token was rejected by the server: drop local tokens
+			// so the user can simply run `kamui login` again.
+			if clearErr := r.configManager.Clear(); clearErr != nil {
+				return "", fmt.Errorf("session expired and failed to clear local credentials: %w", clearErr)
+			}
+			return "", ErrSessionExpired
+		}
+		return "", fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	// Save new tokens
+	if err := r.configManager.SaveTokens(result.AccessToken, result.RefreshToken, result.ExpiresIn); err != nil {
+		return "", fmt.Errorf("failed to save refreshed tokens: %w", err)
+	}
+
+	return result.AccessToken, nil
+}
+
+// oauthRefreshTokens performs the refresh_token grant against the API
+func (o HTTPOptions) oauthRefreshTokens(ctx context.Context, apiURL, clientID, clientSecret, refreshToken string) (*auth.OAuthResult, error) {
+	oauthFlow := o.newOAuthFlow(apiURL)
+	oauthFlow.SetClientCredentials(clientID, clientSecret)
+	return oauthFlow.RefreshTokens(ctx, refreshToken)
+}
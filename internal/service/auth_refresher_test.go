@@ -0,0 +1,294 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/auth"
+	"github.com/kamui-project/kamui-cli/internal/config"
+)
+
+func TestAuthRefresher_RefreshWindow(t *testing.T) {
+	base := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		expiresAt    time.Time
+		refreshToken string
+		wantRefresh  bool
+		wantErrMsg   string
+	}{
+		{name: "plenty of life left", expiresAt: base.Add(10 * time.Minute), refreshToken: "rt", wantRefresh: false},
+		{name: "near expiry refreshes", expiresAt: base.Add(90 * time.Second), refreshToken: "rt", wantRefresh: true},
+		{name: "expired refreshes", expiresAt: base.Add(-time.Minute), refreshToken: "rt", wantRefresh: true},
+		{name: "no expiry recorded", refreshToken: "rt", wantRefresh: false},
+		{name: "near expiry without refresh token uses remaining life", expiresAt: base.Add(90 * time.Second)},
+		{name: "expired without refresh token", expiresAt: base.Add(-time.Minute), wantErrMsg: "session expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.Save(&config.Config{
+				AccessToken:  "old-access",
+				RefreshToken: tt.refreshToken,
+				ExpiresAt:    tt.expiresAt,
+				ClientID:     "cid",
+				ClientSecret: "cs",
+			}); err != nil {
+				t.Fatal(err)
+			}
+
+			refreshed := false
+			r := &authRefresher{
+				configManager: m,
+				now:           func() time.Time { return base },
+				refreshTokens: func(ctx context.Context, apiURL, clientID, clientSecret, refreshToken string) (*auth.OAuthResult, error) {
+					refreshed = true
+					if clientID != "cid" || clientSecret != "cs" || refreshToken != tt.refreshToken {
+						t.Errorf("unexpected refresh args: %s %s %s", clientID, clientSecret, refreshToken)
+					}
+					return &auth.OAuthResult{AccessToken: "new-access", RefreshToken: "new-refresh", ExpiresIn: 3600}, nil
+				},
+			}
+
+			err := r.ensureAuthenticated(context.Background())
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("ensureAuthenticated() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ensureAuthenticated() error = %v", err)
+			}
+			if refreshed != tt.wantRefresh {
+				t.Errorf("refreshed = %v, want %v", refreshed, tt.wantRefresh)
+			}
+
+			cfg, _ := m.Load()
+			wantToken := "old-access"
+			if tt.wantRefresh {
+				wantToken = "new-access"
+			}
+			if cfg.AccessToken != wantToken {
+				t.Errorf("stored access token = %q, want %q", cfg.AccessToken, wantToken)
+			}
+		})
+	}
+}
+
+func TestAuthRefresher_ClearsOnInvalidRefreshToken(t *testing.T) {
+	m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+	if err := m.Save(&config.Config{AccessToken: "a", RefreshToken: "revoked"}); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &authRefresher{
+		configManager: m,
+		now:           time.Now,
+		refreshTokens: func(ctx context.Context, apiURL, clientID, clientSecret, refreshToken string) (*auth.OAuthResult, error) {
+			return nil, auth.ErrRefreshTokenInvalid
+		},
+	}
+
+	if _, err := r.refreshAccessToken(context.Background()); err == nil || !strings.Contains(err.Error(), "session expired") {
+		t.Fatalf("refreshAccessToken() error = %v, want session expired", err)
+	}
+	cfg, _ := m.Load()
+	if cfg.AccessToken != "" || cfg.RefreshToken != "" {
+		t.Errorf("tokens not cleared: %+v", cfg)
+	}
+}
+
+// TestNewAPIClient_RefreshesExpiredToken exercises the path every service
+// takes through getAPIClient: an expired access token with a refresh token
+// is refreshed before the client is built.
+func TestNewAPIClient_RefreshesExpiredToken(t *testing.T) {
+	m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+	if err := m.Save(&config.Config{
+		AccessToken:  "expired-access",
+		RefreshToken: "rt",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	refresher := newAuthRefresher(m, HTTPOptions{})
+	refreshes := 0
+	refresher.refreshTokens = func(ctx context.Context, apiURL, clientID, clientSecret, refreshToken string) (*auth.OAuthResult, error) {
+		refreshes++
+		return &auth.OAuthResult{AccessToken: "new-access", RefreshToken: "new-refresh", ExpiresIn: 3600}, nil
+	}
+	authService := &authService{configManager: m, refresher: refresher}
+
+	for _, svc := range []interface {
+		getAPIClient(ctx context.Context) (*api.Client, error)
+	}{
+		&projectService{configManager: m, authService: authService},
+		&appService{configManager: m, authService: authService},
+		&tokensService{configManager: m, authService: authService},
+	} {
+		client, err := svc.getAPIClient(context.Background())
+		if err != nil {
+			t.Fatalf("getAPIClient() error = %v", err)
+		}
+		if client == nil {
+			t.Fatal("getAPIClient() returned nil client")
+		}
+	}
+
+	if refreshes != 1 {
+		t.Errorf("refreshes = %d, want 1 (later services reuse the refreshed token)", refreshes)
+	}
+	token, err := m.GetAccessToken()
+	if err != nil || token != "new-access" {
+		t.Errorf("stored access token = %q, %v; want new-access", token, err)
+	}
+}
+
+func TestAuthErrors_AreSentinels(t *testing.T) {
+	expired := time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name   string
+		config *config.Config
+		call   func(m *config.Manager) error
+		want   error
+	}{
+		{
+			name:   "service call without tokens",
+			config: &config.Config{},
+			call: func(m *config.Manager) error {
+				_, err := NewProjectService(m, NewAuthService(m, HTTPOptions{}), HTTPOptions{}).ListProjects(context.Background())
+				return err
+			},
+			want: ErrNotLoggedIn,
+		},
+		{
+			name:   "service call with expired token and no refresh token",
+			config: &config.Config{AccessToken: "a", ExpiresAt: expired},
+			call: func(m *config.Manager) error {
+				_, err := NewAppService(m, NewAuthService(m, HTTPOptions{}), HTTPOptions{}).GetApp(context.Background(), "app-1")
+				return err
+			},
+			want: ErrSessionExpired,
+		},
+		{
+			name:   "stored token missing",
+			config: &config.Config{},
+			call: func(m *config.Manager) error {
+				_, err := accessToken(m)
+				return err
+			},
+			want: ErrNotLoggedIn,
+		},
+		{
+			name:   "stored token expired",
+			config: &config.Config{AccessToken: "a", ExpiresAt: expired},
+			call: func(m *config.Manager) error {
+				_, err := accessToken(m)
+				return err
+			},
+			want: ErrSessionExpired,
+		},
+		{
+			name:   "logout without tokens",
+			config: &config.Config{},
+			call: func(m *config.Manager) error {
+				return NewAuthService(m, HTTPOptions{}).Logout(context.Background(), nil)
+			},
+			want: ErrNotLoggedIn,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.Save(tt.config); err != nil {
+				t.Fatal(err)
+			}
+
+			err := tt.call(m)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("error = %v, want %v", err, tt.want)
+			}
+			// Callers add context; the sentinel must survive it
+			if wrapped := fmt.Errorf("failed to fetch projects: %w", err); !errors.Is(wrapped, tt.want) {
+				t.Errorf("errors.Is lost %v through wrapping", tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthRefresher_Relogin(t *testing.T) {
+	tests := []struct {
+		name        string
+		relogin     bool
+		loginErr    error
+		wantErr     error
+		wantLogins  int
+		wantRetried bool
+	}{
+		{name: "non-interactive keeps the error", wantErr: ErrSessionExpired},
+		{name: "login and retry", relogin: true, wantLogins: 1, wantRetried: true},
+		{name: "declined", relogin: true, loginErr: ErrSessionExpired, wantErr: ErrSessionExpired, wantLogins: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.Save(&config.Config{AccessToken: "a", RefreshToken: "revoked", ExpiresAt: time.Now().Add(-time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			logins := 0
+			r := &authRefresher{
+				configManager: m,
+				now:           time.Now,
+				refreshTokens: func(ctx context.Context, apiURL, clientID, clientSecret, refreshToken string) (*auth.OAuthResult, error) {
+					return nil, auth.ErrRefreshTokenInvalid
+				},
+			}
+			if tt.relogin {
+				r.relogin = func(ctx context.Context) error {
+					logins++
+					if tt.loginErr != nil {
+						return tt.loginErr
+					}
+					return m.SaveTokens("fresh-access", "fresh-refresh", 3600)
+				}
+			}
+
+			err := r.ensureAuthenticated(context.Background())
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil) != (err == nil) {
+				t.Fatalf("ensureAuthenticated() error = %v, want %v", err, tt.wantErr)
+			}
+			if logins != tt.wantLogins {
+				t.Errorf("logins = %d, want %d", logins, tt.wantLogins)
+			}
+			if tt.wantRetried {
+				if token, err := m.GetAccessToken(); err != nil || token != "fresh-access" {
+					t.Errorf("stored access token = %q, %v; want fresh-access", token, err)
+				}
+			}
+
+			// The session expiring again is not offered a second login
+			if err := m.Save(&config.Config{AccessToken: "a", RefreshToken: "revoked", ExpiresAt: time.Now().Add(-time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.refreshAccessToken(context.Background()); !errors.Is(err, ErrSessionExpired) {
+				t.Errorf("second refreshAccessToken() error = %v, want ErrSessionExpired", err)
+			}
+			if logins != tt.wantLogins {
+				t.Errorf("logins after a second expiry = %d, want %d", logins, tt.wantLogins)
+			}
+		})
+	}
+}
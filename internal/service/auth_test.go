@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+)
+
+func TestAuthService_LoginWithToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		wantErrMsg string
+		wantSaved  bool
+	}{
+		{name: "valid token", status: http.StatusOK, wantSaved: true},
+		{name: "rejected token", status: http.StatusUnauthorized, wantErrMsg: "token was rejected"},
+		{name: "forbidden token", status: http.StatusForbidden, wantErrMsg: "token was rejected"},
+		{name: "no /api/me saves anyway", status: http.StatusNotFound, wantSaved: true},
+		{name: "server error", status: http.StatusInternalServerError, wantErrMsg: "failed to verify token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/me" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				if got := r.Header.Get("Authorization"); got != "Bearer ci-token" {
+					t.Errorf("Authorization = %q", got)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(`{"id":"u1","name":"ci","email":"ci@example.com"}`))
+			}))
+			defer srv.Close()
+
+			// The API URL must be https, so trust the test server's certificate
+			roots := x509.NewCertPool()
+			roots.AddCert(srv.Certificate())
+
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			// An expired OAuth session left its scope behind
+			old := &config.Config{APIURL: srv.URL, AccessToken: "old", ExpiresAt: time.Now().Add(-time.Hour), Scope: "read"}
+			if err := m.Save(old); err != nil {
+				t.Fatal(err)
+			}
+
+			s := NewAuthService(m, HTTPOptions{Transport: api.TransportOptions{RootCAs: roots}})
+			err := s.LoginWithToken(context.Background(), "ci-token", 3600)
+			if tt.wantErrMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErrMsg) {
+					t.Fatalf("LoginWithToken() error = %v, want containing %q", err, tt.wantErrMsg)
+				}
+			} else if err != nil {
+				t.Fatalf("LoginWithToken() error = %v", err)
+			}
+
+			cfg, _ := m.Load()
+			if tt.wantSaved {
+				if cfg.AccessToken != "ci-token" || cfg.RefreshToken != "" || cfg.ExpiresAt.IsZero() {
+					t.Errorf("saved config = %+v, want ci-token with expiry and no refresh token", cfg)
+				}
+				if cfg.Scope != "" {
+					t.Errorf("scope = %q, want it cleared for a pre-issued token", cfg.Scope)
+				}
+			} else if cfg.AccessToken != "old" {
+				t.Errorf("token saved despite error: %q", cfg.AccessToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_ConcurrentRefresh(t *testing.T) {
+	var refreshes atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth/token" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		refreshes.Add(1)
+		// Stay in flight long enough for every caller to arrive
+		time.Sleep(50 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"new-access","refresh_token":"new-refresh","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+	if err := m.Save(&config.Config{
+		APIURL:       srv.URL,
+		AccessToken:  "expired-access",
+		RefreshToken: "concurrent-rt",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+		ClientID:     "cid",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	s := NewAuthService(m, HTTPOptions{Transport: api.TransportOptions{RootCAs: roots}})
+
+	const callers = 10
+	tokens := make([]string, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			tokens[i], errs[i] = s.GetAccessToken(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("refresh requests = %d, want 1", n)
+	}
+	for i := range tokens {
+		if errs[i] != nil || tokens[i] != "new-access" {
+			t.Errorf("caller %d: GetAccessToken() = %q, %v; want new-access", i, tokens[i], errs[i])
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// newAPIClient creates an API client with the current credentials,
+// refreshing them first if needed, that acts in the active organization.
+// The client retries once through AuthService.RefreshAccessToken when a
+// request comes back 401.
+func newAPIClient(ctx context.Context, configManager *config.Manager, authService iface.AuthService, httpOptions HTTPOptions) (*api.Client, error) {
+	// Ensure we're authenticated (refresh token if needed)
+	if err := authService.EnsureAuthenticated(ctx); err != nil {
+		return nil, err
+	}
+
+	token, err := accessToken(configManager)
+	if err != nil {
+		return nil, err
+	}
+
+	apiURL, err := configManager.GetAPIURL()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API URL: %w", err)
+	}
+
+	org, err := configManager.GetOrg()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+
+	client := httpOptions.newClient(apiURL, token)
+	client.SetTokenRefresher(authService.RefreshAccessToken)
+	client.SetOrg(org)
+	return client, nil
+}
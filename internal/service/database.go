@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// databaseService implements iface.DatabaseService
+type databaseService struct {
+	configManager *config.Manager
+	authService   iface.AuthService
+	httpOptions   HTTPOptions
+}
+
+// NewDatabaseService creates a new database service
+func NewDatabaseService(configManager *config.Manager, authService iface.AuthService, httpOptions HTTPOptions) iface.DatabaseService {
+	return &databaseService{
+		configManager: configManager,
+		authService:   authService,
+		httpOptions:   httpOptions,
+	}
+}
+
+// getAPIClient creates an API client with the current credentials
+func (s *databaseService) getAPIClient(ctx context.Context) (*api.Client, error) {
+	return newAPIClient(ctx, s.configManager, s.authService, s.httpOptions)
+}
+
+// GetConnectionInfo returns the connection details of a database by ID
+func (s *databaseService) GetConnectionInfo(ctx context.Context, dbID string) (*iface.DatabaseConnectionInfo, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.GetDatabaseConnection(ctx, dbID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch connection info: %w", err)
+	}
+
+	return &iface.DatabaseConnectionInfo{
+		Engine:   resp.Engine,
+		Host:     resp.Host,
+		Port:     resp.Port,
+		Username: resp.Username,
+		Password: resp.Password,
+		Database: resp.Database,
+	}, nil
+}
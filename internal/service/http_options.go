@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/auth"
+)
+
+// HTTPOptions configures the API clients created by the services.
+// Zero values fall back to api.DefaultTimeout and api.DefaultUploadTimeout.
+type HTTPOptions struct {
+	// Timeout applies to regular API requests
+	Timeout time.Duration
+
+	// UploadTimeout applies to file uploads such as static app ZIPs
+	UploadTimeout time.Duration
+
+	// Debug logs every HTTP request to stderr
+	Debug bool
+
+	// DebugBodies additionally logs request and response bodies (redacted)
+	DebugBodies bool
+
+	// Timings, if set, records the count and duration of every API request
+	Timings *api.Timings
+
+	// Transport configures the proxy and TLS verification of API and
+	// OAuth requests
+	Transport api.TransportOptions
+
+	// AllowInsecureHTTP accepts a plain http API URL for hosts other than
+	// the local machine
+	AllowInsecureHTTP bool
+
+	// Relogin, if set, is offered an expired session that can no longer be
+	// refreshed, at most once per process. It should log in again, e.g.
+	// after asking the user; an error leaves the session expired.
+	Relogin func(ctx context.Context) error
+}
+
+// transport returns the transport for API and OAuth requests. It is shared
+// by all clients with the same options so their connections are reused.
+func (o HTTPOptions) transport() http.RoundTripper {
+	return api.SharedTransport(o.Transport)
+}
+
+// newClient creates an API client with the configured timeouts applied
+func (o HTTPOptions) newClient(apiURL, token string) *api.Client {
+	client := api.NewClient(apiURL, token)
+	client.SetTransport(o.transport())
+	client.SetTimeout(o.Timeout)
+	client.SetUploadTimeout(o.UploadTimeout)
+	if o.Debug || o.DebugBodies {
+		client.EnableDebug(os.Stderr, o.DebugBodies)
+	}
+	if o.Timings != nil {
+		client.EnableTimings(o.Timings)
+	}
+	return client
+}
+
+// newOAuthFlow creates an OAuth flow that uses the configured transport
+func (o HTTPOptions) newOAuthFlow(apiURL string) *auth.OAuthFlow {
+	flow := auth.NewOAuthFlow(apiURL)
+	flow.SetTransport(o.transport())
+	return flow
+}
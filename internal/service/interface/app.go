@@ -2,6 +2,7 @@ package iface
 
 import (
 	"context"
+	"time"
 )
 
 // Installation represents a GitHub App installation
@@ -20,24 +21,40 @@ type Branch struct {
 
 // CreateAppInput represents the input for creating an app
 type CreateAppInput struct {
-	ProjectID       string
-	AppName         string
-	DisplayName     string
-	Language        string
-	DeployType      string
-	Owner           string
-	OwnerType       string
-	Repository      string
-	Branch          string
-	Directory       string
-	StartCommand    string
-	SetupCommand    string
-	PreCommand      string
-	Replicas        int
-	AppSpecType     string
-	EnvVars         map[string]string
-	HealthCheckPath string
-	DatabaseID      string
+	ProjectID        string
+	AppName          string
+	DisplayName      string
+	Language         string
+	DeployType       string
+	Owner            string
+	OwnerType        string
+	Repository       string
+	Branch           string
+	Directory        string
+	Image            string // repository within Registry, for docker deploy types
+	ImageTag         string
+	ImageDigest      string
+	Registry         string
+	RegistryUsername string // credentials for a private registry, if any
+	RegistryPassword string
+	StartCommand     string
+	SetupCommand     string
+	PreCommand       string
+	Replicas         int
+	AppSpecType      string
+	EnvVars          map[string]string
+	SecretKeys       []string // keys of EnvVars holding secrets, never echoed back
+	HealthCheckPath  string
+	// Zero values of the other health check settings use the platform
+	// defaults: GET on the app's port, any 2xx or 3xx status
+	HealthCheckMethod       string
+	HealthCheckPort         int
+	HealthCheckStatus       int
+	HealthCheckInterval     time.Duration
+	HealthCheckTimeout      time.Duration
+	HealthCheckInitialDelay time.Duration
+	DatabaseID              string
+	SessionAffinity         bool
 }
 
 // CreateAppOutput represents the result of creating an app
@@ -48,15 +65,34 @@ type CreateAppOutput struct {
 
 // AppDetail represents detailed app information from GET /api/apps/{id}
 type AppDetail struct {
-	ID            string
-	DisplayName   string
-	AppType       string
-	LanguageType  string
-	URL           string
-	CustomDomain  string
-	GithubOrgRepo string
-	GithubBranch  string
-	Status        *ProjectStatus
+	ID              string         `json:"id"`
+	DisplayName     string         `json:"display_name"`
+	AppType         string         `json:"app_type"`
+	LanguageType    string         `json:"language_type,omitempty"`
+	AppSpec         string         `json:"app_spec,omitempty"`
+	Replicas        int            `json:"replicas,omitempty"`
+	URL             string         `json:"url,omitempty"`
+	CustomDomain    string         `json:"custom_domain,omitempty"`
+	GithubOrgRepo   string         `json:"github_org_repo,omitempty"`
+	GithubBranch    string         `json:"github_branch,omitempty"`
+	SessionAffinity bool           `json:"session_affinity"`
+	Status          *ProjectStatus `json:"status,omitempty"`
+}
+
+// AppEnv holds an app's environment variables
+type AppEnv struct {
+	EnvVars    map[string]string
+	SecretKeys []string // keys of EnvVars holding secrets
+}
+
+// IsSecret reports whether key holds a secret
+func (e *AppEnv) IsSecret(key string) bool {
+	for _, k := range e.SecretKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
 }
 
 // CreateStaticAppInput represents the input for creating a static app via GitHub
@@ -80,6 +116,9 @@ type CreateStaticAppUploadInput struct {
 	Replicas    int
 	AppSpecType string // nano, small, medium, large
 	FilePath    string // local path to the ZIP file
+
+	// Progress, if set, is called with bytes sent and total body size
+	Progress func(sent, total int64)
 }
 
 // UpdateStaticAppInput represents the input for updating a static app via GitHub
@@ -103,6 +142,100 @@ type UpdateStaticAppUploadInput struct {
 	Replicas    int
 	AppSpecType string
 	FilePath    string
+
+	// Progress, if set, is called with bytes sent and total body size
+	Progress func(sent, total int64)
+}
+
+// UpdateStaticAppUploadOutput represents the result of uploading a new
+// static build
+type UpdateStaticAppUploadOutput struct {
+	AppID        string `json:"app_id"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+// RedeployAppInput represents the input for redeploying an app
+type RedeployAppInput struct {
+	AppID  string
+	Branch string // optional; GitHub-deployed apps only
+}
+
+// RedeployAppOutput represents the result of triggering a redeploy
+type RedeployAppOutput struct {
+	AppID        string `json:"app_id"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+// Deployment represents one deployment of an app
+type Deployment struct {
+	ID            string    `json:"id"`
+	Status        string    `json:"status"`
+	CommitSHA     string    `json:"commit_sha,omitempty"`
+	CommitMessage string    `json:"commit_message,omitempty"`
+	Branch        string    `json:"branch,omitempty"`
+	Current       bool      `json:"current"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// RollbackAppInput represents the input for rolling back an app
+type RollbackAppInput struct {
+	AppID        string
+	DeploymentID string // deployment to restore
+}
+
+// RollbackAppOutput represents the result of triggering a rollback
+type RollbackAppOutput struct {
+	AppID        string `json:"app_id"`
+	TargetID     string `json:"target_deployment_id"`
+	DeploymentID string `json:"deployment_id,omitempty"`
+}
+
+// UpdateAppInput represents the settings to change on an app. Nil fields
+// keep their current values, so a command sets only the fields it changes.
+type UpdateAppInput struct {
+	DisplayName *string
+	Replicas    *int
+	AppSpecType *string
+	EnvVars     *map[string]string // replaces every variable when set
+	SecretKeys  *[]string          // keys of EnvVars holding secrets
+}
+
+// IsEmpty reports whether input changes nothing
+func (input *UpdateAppInput) IsEmpty() bool {
+	return *input == UpdateAppInput{}
+}
+
+// TransferAppOutput represents the result of moving an app to another project
+type TransferAppOutput struct {
+	AppID     string `json:"app_id"`
+	ProjectID string `json:"project_id"`
+}
+
+// DNSRecord is a DNS record that must exist for a custom domain to resolve
+type DNSRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// AppDomain represents the custom domain of an app
+type AppDomain struct {
+	Domain     string      `json:"domain"`
+	Status     string      `json:"status,omitempty"` // e.g. "pending" until DNS is verified
+	DNSRecords []DNSRecord `json:"dns_records,omitempty"`
+}
+
+// Deployment states reported by GetDeployStatus. Other values, such as
+// "pending" or "building", mean the deployment is still in progress.
+const (
+	DeployStatusRunning = "running"
+	DeployStatusError   = "error"
+)
+
+// DeployStatus represents the deployment status of an app
+type DeployStatus struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"` // failure reason when Status is "error"
 }
 
 // AppService defines the interface for app operations
@@ -110,6 +243,10 @@ type AppService interface {
 	// GetInstallations returns all GitHub App installations for the user
 	GetInstallations(ctx context.Context) ([]Installation, error)
 
+	// GetGithubInstallURL returns the URL that installs the Kamui GitHub App
+	// on the user's repositories
+	GetGithubInstallURL(ctx context.Context) (string, error)
+
 	// GetBranches returns branches for a repository
 	GetBranches(ctx context.Context, owner, repo string) ([]Branch, error)
 
@@ -122,12 +259,47 @@ type AppService interface {
 	// CreateStaticAppUpload creates a new static app via file upload
 	CreateStaticAppUpload(ctx context.Context, input *CreateStaticAppUploadInput) (*CreateAppOutput, error)
 
+	// UpdateStaticUpload uploads a new ZIP build of an existing static app
+	UpdateStaticUpload(ctx context.Context, input *UpdateStaticAppUploadInput) (*UpdateStaticAppUploadOutput, error)
+
 	// ListApps returns all apps for a project
 	ListApps(ctx context.Context, projectID string) ([]App, error)
 
 	// GetApp returns detailed app information by ID
 	GetApp(ctx context.Context, appID string) (*AppDetail, error)
 
+	// GetAppEnv returns an app's environment variables
+	GetAppEnv(ctx context.Context, appID string) (*AppEnv, error)
+
 	// DeleteApp deletes an app by ID
 	DeleteApp(ctx context.Context, appID string) error
+
+	// Redeploy triggers a fresh deployment of an app without changing its config
+	Redeploy(ctx context.Context, input *RedeployAppInput) (*RedeployAppOutput, error)
+
+	// GetDeployStatus returns the current deployment status of an app
+	GetDeployStatus(ctx context.Context, appID string) (*DeployStatus, error)
+
+	// ListDeployments returns the recent deployments of an app, newest first
+	ListDeployments(ctx context.Context, appID string) ([]Deployment, error)
+
+	// Rollback redeploys an app from one of its previous deployments
+	Rollback(ctx context.Context, input *RollbackAppInput) (*RollbackAppOutput, error)
+
+	// GetDomain returns the custom domain of an app
+	GetDomain(ctx context.Context, appID string) (*AppDomain, error)
+
+	// SetDomain sets the custom domain of an app and returns the DNS records
+	// to configure
+	SetDomain(ctx context.Context, appID, domain string) (*AppDomain, error)
+
+	// RemoveDomain removes the custom domain of an app
+	RemoveDomain(ctx context.Context, appID string) error
+
+	// TransferApp moves an app to the project targetProjectID
+	TransferApp(ctx context.Context, appID, targetProjectID string) (*TransferAppOutput, error)
+
+	// UpdateApp changes the settings of an app set in input and returns the
+	// updated app
+	UpdateApp(ctx context.Context, appID string, input *UpdateAppInput) (*AppDetail, error)
 }
@@ -6,13 +6,53 @@ import (
 	"context"
 )
 
+// LoginOptions controls how Login authenticates
+type LoginOptions struct {
+	// Device uses the OAuth device authorization grant instead of the
+	// browser + local callback server flow (for headless environments)
+	Device bool
+
+	// CallbackPort overrides the local callback server port for the
+	// browser flow. nil keeps the default; 0 picks a free ephemeral port.
+	CallbackPort *int
+
+	// NoBrowser prints the authorization URL instead of opening a browser
+	NoBrowser bool
+
+	// Scope is the space-separated OAuth scope to request; empty requests
+	// the default "full" scope
+	Scope string
+}
+
+// LogoutOptions controls how Logout clears credentials
+type LogoutOptions struct {
+	// LocalOnly skips server-side token revocation and only clears the
+	// local config
+	LocalOnly bool
+
+	// Purge deletes the whole config file, including the OAuth client
+	// credentials kept for re-login. It succeeds even when logged out.
+	Purge bool
+}
+
+// User is the account the current credentials belong to
+type User struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
 // AuthService defines the interface for authentication operations
 type AuthService interface {
 	// Login performs OAuth authentication and saves credentials
-	Login(ctx context.Context) error
+	Login(ctx context.Context, opts *LoginOptions) error
 
-	// Logout clears stored credentials
-	Logout(ctx context.Context) error
+	// LoginWithToken validates and stores a pre-issued access token.
+	// expiresIn is in seconds; 0 means no known expiry.
+	LoginWithToken(ctx context.Context, token string, expiresIn int) error
+
+	// Logout revokes the stored tokens server-side and clears them locally
+	Logout(ctx context.Context, opts *LogoutOptions) error
 
 	// IsLoggedIn checks if the user is currently authenticated
 	IsLoggedIn() bool
@@ -22,5 +62,13 @@ type AuthService interface {
 
 	// EnsureAuthenticated checks login status and refreshes token if needed
 	EnsureAuthenticated(ctx context.Context) error
+
+	// RefreshAccessToken refreshes the access token unconditionally and
+	// returns the new one (used to retry after a 401)
+	RefreshAccessToken(ctx context.Context) (string, error)
+
+	// GetCurrentUser checks the stored credentials against the API and
+	// returns the user they belong to
+	GetCurrentUser(ctx context.Context) (*User, error)
 }
 
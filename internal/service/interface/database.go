@@ -0,0 +1,21 @@
+package iface
+
+import "context"
+
+// DatabaseConnectionInfo holds the details needed to connect to a database
+type DatabaseConnectionInfo struct {
+	// Engine is the database engine, e.g. "postgres"; empty if the API
+	// did not report one
+	Engine   string
+	Host     string
+	Port     int
+	Username string
+	Password string
+	Database string
+}
+
+// DatabaseService defines the interface for database operations
+type DatabaseService interface {
+	// GetConnectionInfo returns the connection details of a database by ID
+	GetConnectionInfo(ctx context.Context, dbID string) (*DatabaseConnectionInfo, error)
+}
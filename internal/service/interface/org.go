@@ -0,0 +1,18 @@
+package iface
+
+import "context"
+
+// Org is an organization the current user belongs to. Projects are scoped
+// to an organization.
+type Org struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Role    string `json:"role,omitempty"`
+	Default bool   `json:"default,omitempty"`
+}
+
+// OrgService defines the interface for organization operations
+type OrgService interface {
+	// ListOrgs returns the organizations the current user belongs to
+	ListOrgs(ctx context.Context) ([]Org, error)
+}
@@ -3,9 +3,18 @@ package iface
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 )
 
+// Status words returned by ProjectStatus.String
+const (
+	AppStatusRunning = "running"
+	AppStatusError   = "error"
+	AppStatusStopped = "stopped"
+	AppStatusUnknown = "unknown"
+)
+
 // ProjectStatus represents the status counts for a project or app
 type ProjectStatus struct {
 	StatusRunning int `json:"status_running"`
@@ -14,6 +23,40 @@ type ProjectStatus struct {
 	StatusUnknown int `json:"status_unknown"`
 }
 
+// String summarizes the counts as a single word: running if any replica
+// runs, else error if any failed, else stopped if any stopped, else
+// unknown. A nil status is unknown.
+func (s *ProjectStatus) String() string {
+	switch {
+	case s == nil:
+		return AppStatusUnknown
+	case s.StatusRunning > 0:
+		return AppStatusRunning
+	case s.StatusError > 0:
+		return AppStatusError
+	case s.StatusStopped > 0:
+		return AppStatusStopped
+	}
+	return AppStatusUnknown
+}
+
+// Healthy reports whether at least one replica runs and every replica does
+func (s *ProjectStatus) Healthy() bool {
+	return s != nil && s.StatusRunning > 0 && s.StatusStopped == 0 && s.StatusError == 0 && s.StatusUnknown == 0
+}
+
+// Summary lists every count, e.g. "running=2 stopped=0 error=1 unknown=0"
+func (s *ProjectStatus) Summary() string {
+	if s == nil {
+		s = &ProjectStatus{}
+	}
+	return fmt.Sprintf("running=%d stopped=%d error=%d unknown=%d", s.StatusRunning, s.StatusStopped, s.StatusError, s.StatusUnknown)
+}
+
+// ProjectRoleOwner is the Project.Role of projects the user owns; any other
+// role means the project was shared with them
+const ProjectRoleOwner = "owner"
+
 // Project represents a Kamui project
 type Project struct {
 	ID          string     `json:"id"`
@@ -21,6 +64,7 @@ type Project struct {
 	Description string     `json:"description,omitempty"`
 	PlanType    string     `json:"plan_type"`
 	Region      string     `json:"region"`
+	Role        string     `json:"role,omitempty"`
 	CreatedAt   time.Time  `json:"created_at"`
 	UpdatedAt   time.Time  `json:"updated_at"`
 	Apps        []App      `json:"apps,omitempty"`
@@ -75,6 +119,18 @@ func firstNonEmpty(values ...string) string {
 	return ""
 }
 
+// Region is a location projects can be created in
+type Region struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Plan is a plan projects can be created on
+type Plan struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
 // CreateProjectInput represents the input for creating a project
 type CreateProjectInput struct {
 	Name        string
@@ -83,17 +139,100 @@ type CreateProjectInput struct {
 	Region      string
 }
 
+// ListProjectsOptions controls how many projects are fetched
+type ListProjectsOptions struct {
+	// Limit caps the number of projects returned; 0 fetches all of them
+	Limit int
+
+	// OnPage, if set, is called with each page of projects as it arrives,
+	// before the next page is fetched, and the pages are not collected in
+	// ProjectList.Projects. An error from OnPage stops the listing.
+	OnPage func(projects []Project) error
+}
+
+// ProjectList is a possibly truncated list of projects
+type ProjectList struct {
+	Projects []Project
+
+	// HasMore is true when Limit cut the list short
+	HasMore bool
+}
+
+// ConditionalProjectList is the result of ListProjectsIfChanged
+type ConditionalProjectList struct {
+	// NotModified is true when the list still matches the given ETag;
+	// Projects is then nil
+	NotModified bool
+
+	Projects []Project
+
+	// ETag identifies this version of the list. It is empty when the
+	// server sent none or the list spans several pages.
+	ETag string
+}
+
+// UpdateProjectInput represents the input for updating a project.
+// Nil fields are left unchanged.
+type UpdateProjectInput struct {
+	Name        *string
+	Description *string
+}
+
+// ResourceUsage is how much of one resource a project uses against its plan
+// limit. A zero Limit means the plan does not cap the resource.
+type ResourceUsage struct {
+	Used  float64 `json:"used"`
+	Limit float64 `json:"limit"`
+	Unit  string  `json:"unit,omitempty"`
+}
+
+// PlanLimits are the per-app restrictions of a project's plan
+type PlanLimits struct {
+	MaxReplicas  int      `json:"max_replicas,omitempty"`   // 0 means no limit
+	AppSpecTypes []string `json:"app_spec_types,omitempty"` // empty means any spec
+}
+
+// ProjectUsage represents the resource usage and plan limits of a project
+type ProjectUsage struct {
+	PlanType string        `json:"plan_type,omitempty"`
+	CPU      ResourceUsage `json:"cpu"`
+	Memory   ResourceUsage `json:"memory"`
+	Storage  ResourceUsage `json:"storage"`
+	Limits   *PlanLimits   `json:"limits,omitempty"`
+}
+
 // ProjectService defines the interface for project operations
 type ProjectService interface {
 	// ListProjects returns all projects for the authenticated user
 	ListProjects(ctx context.Context) ([]Project, error)
 
+	// ListProjectsWithOptions returns up to opts.Limit projects, following
+	// pagination as needed
+	ListProjectsWithOptions(ctx context.Context, opts *ListProjectsOptions) (*ProjectList, error)
+
+	// ListProjectsIfChanged lists all projects unless the list still
+	// matches etag, the ETag of an earlier result. An empty etag always
+	// fetches the list.
+	ListProjectsIfChanged(ctx context.Context, etag string) (*ConditionalProjectList, error)
+
 	// GetProject returns a project by ID
 	GetProject(ctx context.Context, id string) (*Project, error)
 
 	// CreateProject creates a new project
 	CreateProject(ctx context.Context, input *CreateProjectInput) error
 
+	// UpdateProject updates a project's name and/or description
+	UpdateProject(ctx context.Context, id string, input *UpdateProjectInput) (*Project, error)
+
 	// DeleteProject deletes a project by ID
 	DeleteProject(ctx context.Context, id string) error
+
+	// GetUsage returns a project's resource usage and plan limits
+	GetUsage(ctx context.Context, id string) (*ProjectUsage, error)
+
+	// GetRegions returns the regions new projects can be created in
+	GetRegions(ctx context.Context) ([]Region, error)
+
+	// GetPlans returns the plans new projects can be created on
+	GetPlans(ctx context.Context) ([]Plan, error)
 }
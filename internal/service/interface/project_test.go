@@ -0,0 +1,58 @@
+package iface
+
+import "testing"
+
+func TestProjectStatus_String(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *ProjectStatus
+		want   string
+	}{
+		{name: "nil", status: nil, want: AppStatusUnknown},
+		{name: "no replicas", status: &ProjectStatus{}, want: AppStatusUnknown},
+		{name: "running wins over everything", status: &ProjectStatus{StatusRunning: 1, StatusError: 2, StatusStopped: 3, StatusUnknown: 4}, want: AppStatusRunning},
+		{name: "error wins over stopped", status: &ProjectStatus{StatusError: 1, StatusStopped: 3, StatusUnknown: 4}, want: AppStatusError},
+		{name: "stopped wins over unknown", status: &ProjectStatus{StatusStopped: 1, StatusUnknown: 4}, want: AppStatusStopped},
+		{name: "only unknown", status: &ProjectStatus{StatusUnknown: 2}, want: AppStatusUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectStatus_Healthy(t *testing.T) {
+	tests := []struct {
+		name   string
+		status *ProjectStatus
+		want   bool
+	}{
+		{name: "nil", status: nil, want: false},
+		{name: "no replicas", status: &ProjectStatus{}, want: false},
+		{name: "all running", status: &ProjectStatus{StatusRunning: 2}, want: true},
+		{name: "one stopped", status: &ProjectStatus{StatusRunning: 2, StatusStopped: 1}, want: false},
+		{name: "one unknown", status: &ProjectStatus{StatusRunning: 2, StatusUnknown: 1}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Healthy(); got != tt.want {
+				t.Errorf("Healthy() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectStatus_Summary(t *testing.T) {
+	if got, want := (&ProjectStatus{StatusRunning: 2, StatusError: 1}).Summary(), "running=2 stopped=0 error=1 unknown=0"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+	var nilStatus *ProjectStatus
+	if got, want := nilStatus.Summary(), "running=0 stopped=0 error=0 unknown=0"; got != want {
+		t.Errorf("nil Summary() = %q, want %q", got, want)
+	}
+}
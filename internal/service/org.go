@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// orgService implements iface.OrgService
+type orgService struct {
+	configManager *config.Manager
+	authService   iface.AuthService
+	httpOptions   HTTPOptions
+}
+
+// NewOrgService creates a new organization service
+func NewOrgService(configManager *config.Manager, authService iface.AuthService, httpOptions HTTPOptions) iface.OrgService {
+	return &orgService{
+		configManager: configManager,
+		authService:   authService,
+		httpOptions:   httpOptions,
+	}
+}
+
+// getAPIClient creates an API client with the current credentials
+func (s *orgService) getAPIClient(ctx context.Context) (*api.Client, error) {
+	return newAPIClient(ctx, s.configManager, s.authService, s.httpOptions)
+}
+
+// ListOrgs returns the organizations the current user belongs to
+func (s *orgService) ListOrgs(ctx context.Context) ([]iface.Org, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := client.ListOrgs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]iface.Org, len(orgs))
+	for i, o := range orgs {
+		out[i] = iface.Org{
+			ID:      o.ID,
+			Name:    o.Name,
+			Role:    o.Role,
+			Default: o.Default,
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+)
+
+func TestOrgService_PropagatesOrg(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		override   string
+		wantHeader string
+	}{
+		{name: "default org", wantHeader: ""},
+		{name: "configured org", configured: "org-config", wantHeader: "org-config"},
+		{name: "override wins", configured: "org-config", override: "org-flag", wantHeader: "org-flag"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeader string
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/api/orgs" {
+					t.Errorf("unexpected path %s", r.URL.Path)
+				}
+				gotHeader = r.Header.Get("X-Kamui-Org")
+				w.Write([]byte(`{"orgs":[{"id":"org-1","name":"Acme","role":"owner","default":true}]}`))
+			}))
+			defer srv.Close()
+
+			// The API URL must be https, so trust the test server's certificate
+			roots := x509.NewCertPool()
+			roots.AddCert(srv.Certificate())
+
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.Save(&config.Config{
+				APIURL:      srv.URL,
+				AccessToken: "token",
+				ExpiresAt:   time.Now().Add(time.Hour),
+				Org:         tt.configured,
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if tt.override != "" {
+				m.SetOrgOverride(tt.override)
+			}
+
+			httpOptions := HTTPOptions{Transport: api.TransportOptions{RootCAs: roots}}
+			s := NewOrgService(m, NewAuthService(m, httpOptions), httpOptions)
+			orgs, err := s.ListOrgs(context.Background())
+			if err != nil {
+				t.Fatalf("ListOrgs() error = %v", err)
+			}
+			if len(orgs) != 1 || orgs[0].ID != "org-1" || orgs[0].Role != "owner" || !orgs[0].Default {
+				t.Errorf("ListOrgs() = %+v", orgs)
+			}
+			if gotHeader != tt.wantHeader {
+				t.Errorf("X-Kamui-Org = %q, want %q", gotHeader, tt.wantHeader)
+			}
+		})
+	}
+}
@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/kamui-project/kamui-cli/internal/api"
@@ -13,49 +15,105 @@ import (
 type projectService struct {
 	configManager *config.Manager
 	authService   iface.AuthService
+	httpOptions   HTTPOptions
 }
 
 // NewProjectService creates a new project service
-func NewProjectService(configManager *config.Manager, authService iface.AuthService) iface.ProjectService {
+func NewProjectService(configManager *config.Manager, authService iface.AuthService, httpOptions HTTPOptions) iface.ProjectService {
 	return &projectService{
 		configManager: configManager,
 		authService:   authService,
+		httpOptions:   httpOptions,
 	}
 }
 
 // getAPIClient creates an API client with the current credentials
 func (s *projectService) getAPIClient(ctx context.Context) (*api.Client, error) {
-	// Ensure we're authenticated (refresh token if needed)
-	if err := s.authService.EnsureAuthenticated(ctx); err != nil {
+	return newAPIClient(ctx, s.configManager, s.authService, s.httpOptions)
+}
+
+// ListProjects returns all projects for the authenticated user
+func (s *projectService) ListProjects(ctx context.Context) ([]iface.Project, error) {
+	list, err := s.ListProjectsWithOptions(ctx, &iface.ListProjectsOptions{})
+	if err != nil {
 		return nil, err
 	}
+	return list.Projects, nil
+}
 
-	token, err := s.configManager.GetAccessToken()
+// ListProjectsWithOptions returns up to opts.Limit projects, fetching pages
+// only until the limit is reached
+func (s *projectService) ListProjectsWithOptions(ctx context.Context, opts *iface.ListProjectsOptions) (*iface.ProjectList, error) {
+	client, err := s.getAPIClient(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
+		return nil, err
 	}
 
-	apiURL, err := s.configManager.GetAPIURL()
+	list := &iface.ProjectList{Projects: []iface.Project{}}
+	seen := 0
+	var pageErr error
+	err = client.GetPages(ctx, "/api/projects", func(items json.RawMessage, hasMore bool) error {
+		var page []iface.Project
+		if err := json.Unmarshal(items, &page); err != nil {
+			return fmt.Errorf("failed to parse projects: %w", err)
+		}
+
+		stop := false
+		if opts.Limit > 0 && seen+len(page) >= opts.Limit {
+			list.HasMore = hasMore || seen+len(page) > opts.Limit
+			page = page[:opts.Limit-seen]
+			stop = true
+		}
+		seen += len(page)
+
+		if opts.OnPage != nil {
+			if pageErr = opts.OnPage(page); pageErr != nil {
+				return pageErr
+			}
+		} else {
+			list.Projects = append(list.Projects, page...)
+		}
+		if stop {
+			return api.ErrStopPaging
+		}
+		return nil
+	})
+	if pageErr != nil {
+		// Errors from OnPage are the caller's own; don't blame the fetch
+		return nil, pageErr
+	}
 	if err != nil {
-		return nil, fmt.Errorf("failed to get API URL: %w", err)
+		return nil, fmt.Errorf("failed to fetch projects: %w", err)
 	}
 
-	return api.NewClient(apiURL, token), nil
+	return list, nil
 }
 
-// ListProjects returns all projects for the authenticated user
-func (s *projectService) ListProjects(ctx context.Context) ([]iface.Project, error) {
+// ListProjectsIfChanged lists all projects with a conditional request, so
+// an unchanged list costs the server no more than a 304
+func (s *projectService) ListProjectsIfChanged(ctx context.Context, etag string) (*iface.ConditionalProjectList, error) {
 	client, err := s.getAPIClient(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var projects []iface.Project
-	if err := client.Get(ctx, "/api/projects", &projects); err != nil {
+	result := &iface.ConditionalProjectList{Projects: []iface.Project{}}
+	result.ETag, err = client.GetPagesIfNoneMatch(ctx, "/api/projects", etag, func(items json.RawMessage, hasMore bool) error {
+		var page []iface.Project
+		if err := json.Unmarshal(items, &page); err != nil {
+			return fmt.Errorf("failed to parse projects: %w", err)
+		}
+		result.Projects = append(result.Projects, page...)
+		return nil
+	})
+	if errors.Is(err, api.ErrNotModified) {
+		return &iface.ConditionalProjectList{NotModified: true, ETag: etag}, nil
+	}
+	if err != nil {
 		return nil, fmt.Errorf("failed to fetch projects: %w", err)
 	}
 
-	return projects, nil
+	return result, nil
 }
 
 // GetProject returns a project by ID
@@ -94,6 +152,33 @@ func (s *projectService) CreateProject(ctx context.Context, input *iface.CreateP
 	return nil
 }
 
+// UpdateProject updates a project's name and/or description
+func (s *projectService) UpdateProject(ctx context.Context, id string, input *iface.UpdateProjectInput) (*iface.Project, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &api.UpdateProjectRequest{
+		Name:        input.Name,
+		Description: input.Description,
+	}
+
+	var project iface.Project
+	if err := client.Put(ctx, fmt.Sprintf("/api/projects/%s", id), req, &project); err != nil {
+		return nil, fmt.Errorf("failed to update project: %w", err)
+	}
+
+	// The API may answer with just a message; fetch the updated project then
+	if project.ID == "" {
+		if err := client.Get(ctx, fmt.Sprintf("/api/projects/%s", id), &project); err != nil {
+			return nil, fmt.Errorf("failed to fetch project: %w", err)
+		}
+	}
+
+	return &project, nil
+}
+
 // DeleteProject deletes a project by ID
 func (s *projectService) DeleteProject(ctx context.Context, id string) error {
 	client, err := s.getAPIClient(ctx)
@@ -107,3 +192,52 @@ func (s *projectService) DeleteProject(ctx context.Context, id string) error {
 
 	return nil
 }
+
+// GetUsage returns a project's resource usage and plan limits
+func (s *projectService) GetUsage(ctx context.Context, id string) (*iface.ProjectUsage, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var usage iface.ProjectUsage
+	if err := client.Get(ctx, fmt.Sprintf("/api/projects/%s/usage", id), &usage); err != nil {
+		return nil, fmt.Errorf("failed to fetch project usage: %w", err)
+	}
+
+	return &usage, nil
+}
+
+// GetRegions returns the regions new projects can be created in
+func (s *projectService) GetRegions(ctx context.Context) ([]iface.Region, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Regions []iface.Region `json:"regions"`
+	}
+	if err := client.Get(ctx, "/api/regions", &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch regions: %w", err)
+	}
+
+	return resp.Regions, nil
+}
+
+// GetPlans returns the plans new projects can be created on
+func (s *projectService) GetPlans(ctx context.Context) ([]iface.Plan, error) {
+	client, err := s.getAPIClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		Plans []iface.Plan `json:"plans"`
+	}
+	if err := client.Get(ctx, "/api/plans", &resp); err != nil {
+		return nil, fmt.Errorf("failed to fetch plans: %w", err)
+	}
+
+	return resp.Plans, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// cachedProjectService wraps an iface.ProjectService and remembers the
+// result of ListProjects, so a command that resolves several names only
+// fetches the project list once. It lives for a single CLI invocation, so
+// there is no TTL; writes through the service drop the cached list. With a
+// disk cache, the list is also shared across invocations while fresh, and
+// revalidated with its ETag once stale.
+type cachedProjectService struct {
+	iface.ProjectService
+
+	// disk, if set, is consulted before fetching and updated after
+	disk *ProjectCache
+
+	mu       sync.Mutex
+	projects []iface.Project
+	fetched  bool
+}
+
+// NewCachedProjectService returns a ProjectService that caches ListProjects
+// in memory for the lifetime of the returned value
+func NewCachedProjectService(inner iface.ProjectService) iface.ProjectService {
+	return &cachedProjectService{ProjectService: inner}
+}
+
+// NewDiskCachedProjectService returns a ProjectService that caches
+// ListProjects in memory and on disk, and clears disk on writes
+func NewDiskCachedProjectService(inner iface.ProjectService, disk *ProjectCache) iface.ProjectService {
+	return &cachedProjectService{ProjectService: inner, disk: disk}
+}
+
+// ListProjects returns the cached project list, fetching it on first use.
+// Errors are not cached.
+func (s *cachedProjectService) ListProjects(ctx context.Context) ([]iface.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.fetched {
+		projects, ok := s.disk.Get()
+		if !ok {
+			var err error
+			if projects, err = s.fetch(ctx); err != nil {
+				return nil, err
+			}
+		}
+		s.projects = projects
+		s.fetched = true
+	}
+	// Hand out a copy so callers can't reorder or truncate the cached list
+	return append([]iface.Project(nil), s.projects...), nil
+}
+
+// fetch lists the projects from the inner service. With the disk cache
+// enabled, the request is conditional on the ETag of a stale entry, and an
+// unchanged list reuses that entry.
+func (s *cachedProjectService) fetch(ctx context.Context) ([]iface.Project, error) {
+	if !s.disk.Enabled() {
+		return s.ProjectService.ListProjects(ctx)
+	}
+
+	stale, etag, _ := s.disk.Stale()
+	result, err := s.ProjectService.ListProjectsIfChanged(ctx, etag)
+	if err != nil {
+		return nil, err
+	}
+	if result.NotModified {
+		s.disk.PutWithETag(stale, etag)
+		return stale, nil
+	}
+	s.disk.PutWithETag(result.Projects, result.ETag)
+	return result.Projects, nil
+}
+
+// CreateProject creates a project and drops the cached list
+func (s *cachedProjectService) CreateProject(ctx context.Context, input *iface.CreateProjectInput) error {
+	defer s.invalidate()
+	return s.ProjectService.CreateProject(ctx, input)
+}
+
+// UpdateProject updates a project and drops the cached list
+func (s *cachedProjectService) UpdateProject(ctx context.Context, id string, input *iface.UpdateProjectInput) (*iface.Project, error) {
+	defer s.invalidate()
+	return s.ProjectService.UpdateProject(ctx, id, input)
+}
+
+// DeleteProject deletes a project and drops the cached list
+func (s *cachedProjectService) DeleteProject(ctx context.Context, id string) error {
+	defer s.invalidate()
+	return s.ProjectService.DeleteProject(ctx, id)
+}
+
+// invalidate forgets the cached project list
+func (s *cachedProjectService) invalidate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects = nil
+	s.fetched = false
+	s.disk.Invalidate()
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// countingProjectService counts ListProjects calls; other methods are no-ops.
+// Conditional lists are served with etag, if set.
+type countingProjectService struct {
+	iface.ProjectService
+	listCalls        int
+	listErr          error
+	etag             string
+	notModifiedCalls int
+}
+
+func (s *countingProjectService) ListProjects(ctx context.Context) ([]iface.Project, error) {
+	s.listCalls++
+	if s.listErr != nil {
+		return nil, s.listErr
+	}
+	return []iface.Project{{ID: "proj-1", Name: "one"}, {ID: "proj-2", Name: "two"}}, nil
+}
+
+func (s *countingProjectService) ListProjectsIfChanged(ctx context.Context, etag string) (*iface.ConditionalProjectList, error) {
+	if etag != "" && etag == s.etag {
+		s.notModifiedCalls++
+		return &iface.ConditionalProjectList{NotModified: true, ETag: etag}, nil
+	}
+	projects, err := s.ListProjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &iface.ConditionalProjectList{Projects: projects, ETag: s.etag}, nil
+}
+
+func (s *countingProjectService) DeleteProject(ctx context.Context, id string) error {
+	return nil
+}
+
+func TestCachedProjectService(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingProjectService{}
+	cached := NewCachedProjectService(inner)
+
+	for i := 0; i < 3; i++ {
+		projects, err := cached.ListProjects(ctx)
+		if err != nil {
+			t.Fatalf("ListProjects() error = %v", err)
+		}
+		if len(projects) != 2 {
+			t.Fatalf("ListProjects() returned %d projects, want 2", len(projects))
+		}
+		// Mutating the returned slice must not affect later reads
+		projects[0].Name = "changed"
+	}
+	if inner.listCalls != 1 {
+		t.Errorf("inner ListProjects called %d times, want 1", inner.listCalls)
+	}
+	if projects, _ := cached.ListProjects(ctx); projects[0].Name != "one" {
+		t.Errorf("cached project name = %q, want %q", projects[0].Name, "one")
+	}
+
+	if err := cached.DeleteProject(ctx, "proj-1"); err != nil {
+		t.Fatalf("DeleteProject() error = %v", err)
+	}
+	if _, err := cached.ListProjects(ctx); err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if inner.listCalls != 2 {
+		t.Errorf("inner ListProjects called %d times after delete, want 2", inner.listCalls)
+	}
+}
+
+func TestCachedProjectService_DoesNotCacheErrors(t *testing.T) {
+	ctx := context.Background()
+	inner := &countingProjectService{listErr: errors.New("unavailable")}
+	cached := NewCachedProjectService(inner)
+
+	if _, err := cached.ListProjects(ctx); err == nil {
+		t.Fatal("ListProjects() error = nil, want error")
+	}
+	inner.listErr = nil
+	if _, err := cached.ListProjects(ctx); err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if inner.listCalls != 2 {
+		t.Errorf("inner ListProjects called %d times, want 2", inner.listCalls)
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// ProjectCache keeps ListProjects results on disk so back-to-back
+// invocations can skip refetching the project list. Entries are keyed by
+// profile and are only used for the API URL and organization they were
+// fetched from. An entry past its TTL that has an ETag can still be
+// revalidated with the server instead of refetched. The file holds project
+// metadata only, never credentials. A nil cache does nothing.
+type ProjectCache struct {
+	path    string
+	profile string
+	apiURL  string
+	org     string
+
+	// ttl is how long an entry stays fresh; 0 disables reads and writes,
+	// but Invalidate still clears the file
+	ttl time.Duration
+
+	now func() time.Time
+}
+
+// projectCacheFile is the on-disk layout of the cache
+type projectCacheFile struct {
+	Entries map[string]projectCacheEntry `json:"entries"`
+}
+
+// projectCacheEntry is one profile's cached project list
+type projectCacheEntry struct {
+	APIURL    string          `json:"api_url"`
+	Org       string          `json:"org,omitempty"`
+	ETag      string          `json:"etag,omitempty"`
+	FetchedAt time.Time       `json:"fetched_at"`
+	Projects  []iface.Project `json:"projects"`
+}
+
+// NewProjectCache returns a cache stored at path for the given profile and
+// API URL
+func NewProjectCache(path, profile, apiURL string, ttl time.Duration) *ProjectCache {
+	return &ProjectCache{
+		path:    path,
+		profile: profile,
+		apiURL:  apiURL,
+		ttl:     ttl,
+		now:     time.Now,
+	}
+}
+
+// WithOrg scopes the cache to an organization, so switching organizations
+// never serves another one's projects, and returns the cache
+func (c *ProjectCache) WithOrg(org string) *ProjectCache {
+	c.org = org
+	return c
+}
+
+// Enabled reports whether the cache reads and stores entries
+func (c *ProjectCache) Enabled() bool {
+	return c != nil && c.ttl > 0
+}
+
+// Get returns the cached project list if it is still fresh
+func (c *ProjectCache) Get() ([]iface.Project, bool) {
+	entry, ok := c.entry()
+	if !ok {
+		return nil, false
+	}
+	age := c.now().Sub(entry.FetchedAt)
+	if age < 0 || age > c.ttl {
+		return nil, false
+	}
+	return entry.Projects, true
+}
+
+// Stale returns the cached project list and its ETag regardless of age,
+// for revalidation with the server. It reports false when there is no
+// entry or the entry has no ETag.
+func (c *ProjectCache) Stale() ([]iface.Project, string, bool) {
+	entry, ok := c.entry()
+	if !ok || entry.ETag == "" {
+		return nil, "", false
+	}
+	return entry.Projects, entry.ETag, true
+}
+
+// entry returns this profile's entry if it was fetched from the same API
+// URL and organization
+func (c *ProjectCache) entry() (projectCacheEntry, bool) {
+	if !c.Enabled() {
+		return projectCacheEntry{}, false
+	}
+	entry, ok := c.load().Entries[c.profile]
+	if !ok || entry.APIURL != c.apiURL || entry.Org != c.org {
+		return projectCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// Put stores a freshly fetched project list. Failures are ignored; the
+// cache only saves a request.
+func (c *ProjectCache) Put(projects []iface.Project) {
+	c.PutWithETag(projects, "")
+}
+
+// PutWithETag stores a freshly fetched or revalidated project list with
+// the ETag it was served with
+func (c *ProjectCache) PutWithETag(projects []iface.Project, etag string) {
+	if !c.Enabled() {
+		return
+	}
+	file := c.load()
+	file.Entries[c.profile] = projectCacheEntry{
+		APIURL:    c.apiURL,
+		Org:       c.org,
+		ETag:      etag,
+		FetchedAt: c.now(),
+		Projects:  projects,
+	}
+
+	data, err := json.Marshal(file)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(c.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	// OpenFile keeps the mode of an existing file
+	if err := f.Chmod(0600); err != nil {
+		return
+	}
+	_, _ = f.Write(data)
+}
+
+// Invalidate removes the cache file, dropping every profile's entry
+func (c *ProjectCache) Invalidate() {
+	if c == nil {
+		return
+	}
+	_ = os.Remove(c.path)
+}
+
+// load reads the cache file, returning an empty cache when it is missing
+// or unreadable
+func (c *ProjectCache) load() *projectCacheFile {
+	file := &projectCacheFile{}
+	if data, err := os.ReadFile(c.path); err == nil {
+		_ = json.Unmarshal(data, file)
+	}
+	if file.Entries == nil {
+		file.Entries = map[string]projectCacheEntry{}
+	}
+	return file
+}
+
+// cacheInvalidatingAppService drops the on-disk project list after app
+// changes, since each project lists its apps
+type cacheInvalidatingAppService struct {
+	iface.AppService
+	cache *ProjectCache
+}
+
+// NewCacheInvalidatingAppService returns an AppService that invalidates
+// cache whenever an app is created, deleted, moved, or updated
+func NewCacheInvalidatingAppService(inner iface.AppService, cache *ProjectCache) iface.AppService {
+	return &cacheInvalidatingAppService{AppService: inner, cache: cache}
+}
+
+// CreateApp creates a dynamic app and invalidates the project cache
+func (s *cacheInvalidatingAppService) CreateApp(ctx context.Context, input *iface.CreateAppInput) (*iface.CreateAppOutput, error) {
+	defer s.cache.Invalidate()
+	return s.AppService.CreateApp(ctx, input)
+}
+
+// CreateStaticApp creates a static app and invalidates the project cache
+func (s *cacheInvalidatingAppService) CreateStaticApp(ctx context.Context, input *iface.CreateStaticAppInput) (*iface.CreateAppOutput, error) {
+	defer s.cache.Invalidate()
+	return s.AppService.CreateStaticApp(ctx, input)
+}
+
+// CreateStaticAppUpload creates an uploaded static app and invalidates the
+// project cache
+func (s *cacheInvalidatingAppService) CreateStaticAppUpload(ctx context.Context, input *iface.CreateStaticAppUploadInput) (*iface.CreateAppOutput, error) {
+	defer s.cache.Invalidate()
+	return s.AppService.CreateStaticAppUpload(ctx, input)
+}
+
+// DeleteApp deletes an app and invalidates the project cache
+func (s *cacheInvalidatingAppService) DeleteApp(ctx context.Context, appID string) error {
+	defer s.cache.Invalidate()
+	return s.AppService.DeleteApp(ctx, appID)
+}
+
+// TransferApp moves an app and invalidates the project cache
+func (s *cacheInvalidatingAppService) TransferApp(ctx context.Context, appID, targetProjectID string) (*iface.TransferAppOutput, error) {
+	defer s.cache.Invalidate()
+	return s.AppService.TransferApp(ctx, appID, targetProjectID)
+}
+
+// UpdateApp updates an app and invalidates the project cache
+func (s *cacheInvalidatingAppService) UpdateApp(ctx context.Context, appID string, input *iface.UpdateAppInput) (*iface.AppDetail, error) {
+	defer s.cache.Invalidate()
+	return s.AppService.UpdateApp(ctx, appID, input)
+}
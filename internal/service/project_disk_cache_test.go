@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+// stubAppService records DeleteApp calls; other methods are not used
+type stubAppService struct {
+	iface.AppService
+	deleted []string
+}
+
+func (s *stubAppService) DeleteApp(ctx context.Context, appID string) error {
+	s.deleted = append(s.deleted, appID)
+	return nil
+}
+
+func newTestProjectCache(t *testing.T, ttl time.Duration) (*ProjectCache, *time.Time) {
+	t.Helper()
+	now := time.Date(2026, 1, 2, 15, 0, 0, 0, time.UTC)
+	cache := NewProjectCache(filepath.Join(t.TempDir(), "cache.json"), "default", "https://api.example.com", ttl)
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestProjectCache_HitAndMiss(t *testing.T) {
+	ctx := context.Background()
+	cache, now := newTestProjectCache(t, 30*time.Second)
+
+	// First invocation: miss, fetched and stored
+	inner := &countingProjectService{}
+	if _, err := NewDiskCachedProjectService(inner, cache).ListProjects(ctx); err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if inner.listCalls != 1 {
+		t.Fatalf("inner ListProjects called %d times on a cold cache, want 1", inner.listCalls)
+	}
+
+	info, err := os.Stat(cache.path)
+	if err != nil {
+		t.Fatalf("cache file not written: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("cache file mode = %o, want 600", perm)
+	}
+
+	// Second invocation within the TTL: hit
+	inner = &countingProjectService{}
+	projects, err := NewDiskCachedProjectService(inner, cache).ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if inner.listCalls != 0 {
+		t.Errorf("inner ListProjects called %d times on a fresh cache, want 0", inner.listCalls)
+	}
+	if len(projects) != 2 || projects[1].Name != "two" {
+		t.Errorf("cached projects = %+v", projects)
+	}
+
+	// After the TTL: miss
+	*now = now.Add(31 * time.Second)
+	inner = &countingProjectService{}
+	if _, err := NewDiskCachedProjectService(inner, cache).ListProjects(ctx); err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if inner.listCalls != 1 {
+		t.Errorf("inner ListProjects called %d times on a stale cache, want 1", inner.listCalls)
+	}
+}
+
+func TestProjectCache_MissForOtherAPIURL(t *testing.T) {
+	cache, _ := newTestProjectCache(t, time.Minute)
+	cache.Put([]iface.Project{{ID: "proj-1"}})
+
+	other := NewProjectCache(cache.path, "default", "https://api.staging.example.com", time.Minute)
+	if _, ok := other.Get(); ok {
+		t.Error("Get() hit for an entry fetched from a different API URL")
+	}
+}
+
+func TestProjectCache_MissForOtherOrg(t *testing.T) {
+	cache, _ := newTestProjectCache(t, time.Minute)
+	cache = cache.WithOrg("org-a")
+	cache.Put([]iface.Project{{ID: "proj-1"}})
+
+	if _, ok := cache.Get(); !ok {
+		t.Fatal("Get() missed for the org the entry was fetched in")
+	}
+	other := NewProjectCache(cache.path, "default", "https://api.example.com", time.Minute).WithOrg("org-b")
+	if _, ok := other.Get(); ok {
+		t.Error("Get() hit for an entry fetched in a different org")
+	}
+}
+
+func TestProjectCache_RevalidatesStaleEntry(t *testing.T) {
+	ctx := context.Background()
+	cache, now := newTestProjectCache(t, 30*time.Second)
+
+	// Cold cache: fetched with its ETag
+	inner := &countingProjectService{etag: `"v1"`}
+	if _, err := NewDiskCachedProjectService(inner, cache).ListProjects(ctx); err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+
+	// Stale and unchanged on the server: revalidated, not refetched
+	*now = now.Add(time.Minute)
+	inner = &countingProjectService{etag: `"v1"`}
+	projects, err := NewDiskCachedProjectService(inner, cache).ListProjects(ctx)
+	if err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if inner.listCalls != 0 || inner.notModifiedCalls != 1 {
+		t.Errorf("inner fetched %d time(s) and revalidated %d time(s), want 0 and 1", inner.listCalls, inner.notModifiedCalls)
+	}
+	if len(projects) != 2 || projects[1].Name != "two" {
+		t.Errorf("revalidated projects = %+v", projects)
+	}
+	if _, ok := cache.Get(); !ok {
+		t.Error("Get() missed after revalidation, want the entry fresh again")
+	}
+
+	// Stale and changed on the server: refetched
+	*now = now.Add(time.Minute)
+	inner = &countingProjectService{etag: `"v2"`}
+	if _, err := NewDiskCachedProjectService(inner, cache).ListProjects(ctx); err != nil {
+		t.Fatalf("ListProjects() error = %v", err)
+	}
+	if inner.listCalls != 1 {
+		t.Errorf("inner ListProjects called %d times for a changed list, want 1", inner.listCalls)
+	}
+	if _, etag, _ := cache.Stale(); etag != `"v2"` {
+		t.Errorf("cached ETag = %q, want \"v2\"", etag)
+	}
+}
+
+func TestProjectService_NotModifiedReusesCache(t *testing.T) {
+	requests := 0
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/projects" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"id":"proj-1","name":"one"}]`))
+	}))
+	defer srv.Close()
+
+	// The API URL must be https, so trust the test server's certificate
+	roots := x509.NewCertPool()
+	roots.AddCert(srv.Certificate())
+
+	m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+	if err := m.Save(&config.Config{APIURL: srv.URL, AccessToken: "token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	httpOptions := HTTPOptions{Transport: api.TransportOptions{RootCAs: roots}}
+	inner := NewProjectService(m, NewAuthService(m, httpOptions), httpOptions)
+
+	cache := NewProjectCache(filepath.Join(t.TempDir(), "cache.json"), "default", srv.URL, time.Second)
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		// Each invocation starts with a stale cache
+		now = now.Add(time.Minute)
+		projects, err := NewDiskCachedProjectService(inner, cache).ListProjects(context.Background())
+		if err != nil {
+			t.Fatalf("ListProjects() error = %v", err)
+		}
+		if len(projects) != 1 || projects[0].Name != "one" {
+			t.Errorf("invocation %d: projects = %+v", i+1, projects)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("server saw %d requests, want 2", requests)
+	}
+}
+
+func TestProjectCache_Disabled(t *testing.T) {
+	cache, _ := newTestProjectCache(t, 0)
+	cache.Put([]iface.Project{{ID: "proj-1"}})
+
+	if _, err := os.Stat(cache.path); !os.IsNotExist(err) {
+		t.Errorf("disabled cache wrote %s (stat error %v)", cache.path, err)
+	}
+}
+
+func TestProjectCache_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	cache, _ := newTestProjectCache(t, time.Minute)
+
+	tests := []struct {
+		name   string
+		mutate func() error
+	}{
+		{
+			name: "delete project",
+			mutate: func() error {
+				return NewDiskCachedProjectService(&countingProjectService{}, cache).DeleteProject(ctx, "proj-1")
+			},
+		},
+		{
+			name: "delete app",
+			mutate: func() error {
+				return NewCacheInvalidatingAppService(&stubAppService{}, cache).DeleteApp(ctx, "app-1")
+			},
+		},
+		{
+			name: "mutation without the cache enabled",
+			mutate: func() error {
+				disabled := NewProjectCache(cache.path, "default", cache.apiURL, 0)
+				return NewCacheInvalidatingAppService(&stubAppService{}, disabled).DeleteApp(ctx, "app-1")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache.Put([]iface.Project{{ID: "proj-1", Name: "one"}})
+			if _, ok := cache.Get(); !ok {
+				t.Fatal("Get() missed right after Put()")
+			}
+
+			if err := tt.mutate(); err != nil {
+				t.Fatalf("mutation error = %v", err)
+			}
+			if _, ok := cache.Get(); ok {
+				t.Error("Get() hit after a mutation, want the cache invalidated")
+			}
+		})
+	}
+}
+
+func TestProjectCache_StoresNoSecrets(t *testing.T) {
+	cache, _ := newTestProjectCache(t, time.Minute)
+	cache.Put([]iface.Project{{ID: "proj-1", Name: "one"}})
+
+	data, err := os.ReadFile(cache.path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range []string{"access_token", "refresh_token", "client_secret"} {
+		if strings.Contains(string(data), field) {
+			t.Errorf("cache file contains %q: %s", field, data)
+		}
+	}
+}
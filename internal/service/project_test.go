@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kamui-project/kamui-cli/internal/api"
+	"github.com/kamui-project/kamui-cli/internal/config"
+	iface "github.com/kamui-project/kamui-cli/internal/service/interface"
+)
+
+func TestProjectService_ListProjectsOnPage(t *testing.T) {
+	errStop := errors.New("stdout closed")
+
+	tests := []struct {
+		name      string
+		limit     int
+		failAfter int
+		wantPages string
+		wantMore  bool
+		wantErr   error
+	}{
+		{name: "every page", wantPages: "p1,p2|p3"},
+		{name: "limit at a page boundary", limit: 2, wantPages: "p1,p2", wantMore: true},
+		{name: "limit within a page", limit: 1, wantPages: "p1", wantMore: true},
+		{name: "error from OnPage stops the listing", failAfter: 1, wantPages: "p1,p2", wantErr: errStop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("cursor") == "c2" {
+					w.Write([]byte(`{"items":[{"id":"p3"}]}`))
+					return
+				}
+				w.Write([]byte(`{"items":[{"id":"p1"},{"id":"p2"}],"next":"c2"}`))
+			}))
+			defer srv.Close()
+
+			roots := x509.NewCertPool()
+			roots.AddCert(srv.Certificate())
+
+			m := config.NewManagerWithPath(filepath.Join(t.TempDir(), "config.json"))
+			if err := m.Save(&config.Config{APIURL: srv.URL, AccessToken: "token", ExpiresAt: time.Now().Add(time.Hour)}); err != nil {
+				t.Fatal(err)
+			}
+
+			httpOptions := HTTPOptions{Transport: api.TransportOptions{RootCAs: roots}}
+			s := NewProjectService(m, NewAuthService(m, httpOptions), httpOptions)
+
+			var pages []string
+			list, err := s.ListProjectsWithOptions(context.Background(), &iface.ListProjectsOptions{
+				Limit: tt.limit,
+				OnPage: func(projects []iface.Project) error {
+					ids := make([]string, len(projects))
+					for i, p := range projects {
+						ids[i] = p.ID
+					}
+					pages = append(pages, strings.Join(ids, ","))
+					if tt.failAfter > 0 && len(pages) >= tt.failAfter {
+						return errStop
+					}
+					return nil
+				},
+			})
+			if got := strings.Join(pages, "|"); got != tt.wantPages {
+				t.Errorf("pages = %s, want %s", got, tt.wantPages)
+			}
+			if tt.wantErr != nil {
+				if err != tt.wantErr {
+					t.Fatalf("ListProjectsWithOptions() error = %v, want %v unwrapped", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ListProjectsWithOptions() error = %v", err)
+			}
+			if len(list.Projects) != 0 {
+				t.Errorf("Projects = %v, want none when OnPage is set", list.Projects)
+			}
+			if list.HasMore != tt.wantMore {
+				t.Errorf("HasMore = %v, want %v", list.HasMore, tt.wantMore)
+			}
+		})
+	}
+}
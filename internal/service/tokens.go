@@ -2,7 +2,6 @@ package service
 
 import (
 	"context"
-	"fmt"
 
 	"github.com/kamui-project/kamui-cli/internal/api"
 	"github.com/kamui-project/kamui-cli/internal/config"
@@ -13,29 +12,21 @@ import (
 type tokensService struct {
 	configManager *config.Manager
 	authService   iface.AuthService
+	httpOptions   HTTPOptions
 }
 
 // NewTokensService creates a new tokens service.
-func NewTokensService(configManager *config.Manager, authService iface.AuthService) iface.TokensService {
+func NewTokensService(configManager *config.Manager, authService iface.AuthService, httpOptions HTTPOptions) iface.TokensService {
 	return &tokensService{
 		configManager: configManager,
 		authService:   authService,
+		httpOptions:   httpOptions,
 	}
 }
 
+// getAPIClient creates an API client with the current credentials
 func (s *tokensService) getAPIClient(ctx context.Context) (*api.Client, error) {
-	if err := s.authService.EnsureAuthenticated(ctx); err != nil {
-		return nil, err
-	}
-	token, err := s.configManager.GetAccessToken()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get access token: %w", err)
-	}
-	apiURL, err := s.configManager.GetAPIURL()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get API URL: %w", err)
-	}
-	return api.NewClient(apiURL, token), nil
+	return newAPIClient(ctx, s.configManager, s.authService, s.httpOptions)
 }
 
 func (s *tokensService) Create(ctx context.Context, name string, expiresInDays int) (string, string, error) {